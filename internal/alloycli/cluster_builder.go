@@ -4,9 +4,11 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"runtime"
 	"strconv"
 	"time"
 
+	"github.com/KimMachineGun/automemlimit/memlimit"
 	"github.com/go-kit/log"
 	"github.com/grafana/ckit/advertise"
 	"github.com/prometheus/client_golang/prometheus"
@@ -39,6 +41,9 @@ type ClusterOptions struct {
 	TLSCertPath            string
 	TLSKeyPath             string
 	TLSServerName          string
+
+	NodeWeight                 int
+	EnableWeightedDistribution bool
 }
 
 func buildClusterService(opts ClusterOptions) (*cluster.Service, error) {
@@ -70,6 +75,13 @@ func NewClusterService(
 		TLSCertPath:            opts.TLSCertPath,
 		TLSKeyPath:             opts.TLSKeyPath,
 		TLSServerName:          opts.TLSServerName,
+
+		NodeWeight:                 opts.NodeWeight,
+		EnableWeightedDistribution: opts.EnableWeightedDistribution,
+	}
+
+	if config.NodeWeight <= 0 {
+		config.NodeWeight = detectNodeWeight()
 	}
 
 	if config.NodeName == "" {
@@ -99,6 +111,19 @@ func NewClusterService(
 	return cluster.New(config)
 }
 
+// detectNodeWeight returns a weight reflecting the resources available to the
+// process: one per CPU, and no more than one per GiB of memory when a cgroup
+// limits the memory of the process.
+func detectNodeWeight() int {
+	// GOMAXPROCS defaults to the number of CPUs available to the process,
+	// which accounts for the CPU limits of cgroups.
+	weight := runtime.GOMAXPROCS(0)
+	if limit, err := memlimit.FromCgroup(); err == nil && limit > 0 {
+		weight = min(weight, int(limit>>30))
+	}
+	return max(1, weight)
+}
+
 func useAllInterfaces(interfaces []string) bool {
 	return len(interfaces) == 1 && interfaces[0] == "all"
 }
@@ -2,6 +2,7 @@ package alloycli
 
 import (
 	"os"
+	"runtime"
 	"testing"
 
 	"github.com/go-kit/log"
@@ -62,3 +63,9 @@ func TestGetAdvertiseAddress(t *testing.T) {
 		require.Equal(t, "127.0.0.1:80", addr)
 	})
 }
+
+func TestDetectNodeWeight(t *testing.T) {
+	weight := detectNodeWeight()
+	require.GreaterOrEqual(t, weight, 1)
+	require.LessOrEqual(t, weight, runtime.NumCPU())
+}
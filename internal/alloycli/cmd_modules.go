@@ -0,0 +1,466 @@
+package alloycli
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	prom_config "github.com/prometheus/common/config"
+	"github.com/spf13/cobra"
+
+	"github.com/grafana/alloy/internal/nodeconf/importsource"
+	"github.com/grafana/alloy/internal/util"
+	"github.com/grafana/alloy/internal/vcs"
+	"github.com/grafana/alloy/syntax/ast"
+	"github.com/grafana/alloy/syntax/parser"
+	"github.com/grafana/alloy/syntax/vm"
+)
+
+func modulesCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "modules",
+		Short: "Manage the modules imported by a configuration",
+		Long: `The modules command contains a collection of utilities to pin the
+modules imported by import.git and import.http blocks in a lockfile.`,
+	}
+
+	cmd.AddCommand(
+		modulesSubcommand(resolveLock, "lock", "Pin the imported modules in a lockfile",
+			`The lock subcommand resolves every import.git and import.http block of the
+configuration, including the ones of the imported modules, to an exact commit
+or content digest and writes them to a lockfile.
+
+Modules which are already pinned by the lockfile keep their pin. Pins of
+modules which are no longer imported are removed.`),
+		modulesSubcommand(resolveUpdate, "update", "Update the imported modules pinned in a lockfile",
+			`The update subcommand resolves every imported module again and updates the
+lockfile with the latest commit or content digest. Vendored modules are
+downloaded again.`),
+		modulesSubcommand(resolveVendor, "vendor", "Vendor the imported modules to a local directory",
+			`The vendor subcommand downloads every module pinned by the lockfile, and
+every module which isn't pinned yet, to a local directory. alloy run then
+loads the vendored modules from the local directory instead of the network,
+which is useful for air-gapped deployments.`),
+	)
+
+	return cmd
+}
+
+func modulesSubcommand(mode resolveMode, use, short, long string) *cobra.Command {
+	m := &alloyModules{mode: mode}
+
+	cmd := &cobra.Command{
+		Use:          use + " [flags] path",
+		Short:        short,
+		Long:         long,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return m.Run(cmd.Context(), args[0], cmd.ErrOrStderr())
+		},
+	}
+
+	cmd.Flags().StringVar(&m.lockfile, "lockfile", m.lockfile, fmt.Sprintf("Path of the lockfile. Defaults to %s in the directory of the configuration.", importsource.LockfileName))
+	cmd.Flags().StringVar(&m.vendorDir, "vendor.dir", m.vendorDir, fmt.Sprintf("Directory where modules are vendored. Defaults to %s in the directory of the lockfile.", defaultVendorDir))
+	return cmd
+}
+
+// defaultVendorDir is the name of the directory where modules are vendored,
+// relative to the lockfile.
+const defaultVendorDir = "alloy-modules"
+
+// resolveMode determines how the modules pinned by an existing lockfile are
+// handled.
+type resolveMode int
+
+const (
+	resolveLock   resolveMode = iota // Keep existing pins.
+	resolveUpdate                    // Resolve every module again.
+	resolveVendor                    // Keep existing pins and vendor every module.
+)
+
+type alloyModules struct {
+	mode      resolveMode
+	lockfile  string
+	vendorDir string
+}
+
+func (m *alloyModules) Run(ctx context.Context, configPath string, warnings io.Writer) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	modulePath, err := util.ExtractDirPath(configPath)
+	if err != nil {
+		return fmt.Errorf("reading config path %q: %w", configPath, err)
+	}
+	lockfilePath := m.lockfile
+	if lockfilePath == "" {
+		lockfilePath = filepath.Join(modulePath, importsource.LockfileName)
+	}
+	vendorDir := m.vendorDir
+	if vendorDir == "" {
+		vendorDir = filepath.Join(filepath.Dir(lockfilePath), defaultVendorDir)
+	}
+
+	prev, err := importsource.ReadLockfile(lockfilePath)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	sources, err := loadSourceFiles(configPath, "alloy", false, "")
+	if err != nil {
+		return fmt.Errorf("reading config path %q: %w", configPath, err)
+	}
+
+	workDir, err := os.MkdirTemp("", "alloy-modules")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(workDir)
+
+	r := &moduleResolver{
+		mode:      m.mode,
+		prev:      prev,
+		lock:      importsource.NewLockfile(filepath.Dir(lockfilePath)),
+		vendorDir: vendorDir,
+		workDir:   workDir,
+		warnings:  warnings,
+		seen:      make(map[string]struct{}),
+		gitDirs:   make(map[string]string),
+	}
+	for name, bb := range sources {
+		if err := r.resolveSource(ctx, name, bb, modulePath); err != nil {
+			return err
+		}
+	}
+
+	return r.lock.WriteFile(lockfilePath)
+}
+
+// moduleResolver walks a configuration and the modules it imports to pin
+// every remote module in a lockfile.
+type moduleResolver struct {
+	mode      resolveMode
+	prev      *importsource.Lockfile // Existing lockfile, may be nil.
+	lock      *importsource.Lockfile // Lockfile being built.
+	vendorDir string
+	workDir   string
+	warnings  io.Writer
+
+	seen    map[string]struct{} // Modules already resolved.
+	gitDirs map[string]string   // Directories of the repositories checked out, by repository and revision.
+	n       int                 // Number of clones in workDir.
+}
+
+func (r *moduleResolver) resolveSource(ctx context.Context, name string, bb []byte, modulePath string) error {
+	file, err := parser.ParseFile(name, bb)
+	if err != nil {
+		return fmt.Errorf("parsing %s: %w", name, err)
+	}
+	return r.resolveBody(ctx, file.Body, modulePath)
+}
+
+func (r *moduleResolver) resolveBody(ctx context.Context, body ast.Body, modulePath string) error {
+	for _, stmt := range body {
+		block, ok := stmt.(*ast.BlockStmt)
+		if !ok {
+			continue
+		}
+
+		var err error
+		switch block.GetBlockName() {
+		case "declare":
+			err = r.resolveBody(ctx, block.Body, modulePath)
+		case importsource.BlockNameFile:
+			err = r.resolveFile(ctx, block, modulePath)
+		case importsource.BlockNameGit:
+			err = r.resolveGit(ctx, block, modulePath)
+		case importsource.BlockNameHTTP:
+			err = r.resolveHTTP(ctx, block, modulePath)
+		}
+		if err != nil {
+			return fmt.Errorf("%s %q: %w", block.GetBlockName(), block.Label, err)
+		}
+	}
+	return nil
+}
+
+// evaluate decodes the arguments of an import block. Blocks whose arguments
+// depend on other components can't be resolved and are skipped.
+func (r *moduleResolver) evaluate(block *ast.BlockStmt, modulePath string, args any) bool {
+	scope := vm.NewScope(map[string]any{importsource.ModulePath: modulePath})
+	if err := vm.New(block.Body).Evaluate(scope, args); err != nil {
+		fmt.Fprintf(r.warnings, "skipping %s %q: %s\n", block.GetBlockName(), block.Label, err)
+		return false
+	}
+	return true
+}
+
+func (r *moduleResolver) resolveFile(ctx context.Context, block *ast.BlockStmt, modulePath string) error {
+	var args importsource.FileArguments
+	if !r.evaluate(block, modulePath, &args) {
+		return nil
+	}
+
+	dir, err := util.ExtractDirPath(args.Filename)
+	if err != nil {
+		return err
+	}
+	return r.resolveLocal(ctx, args.Filename, dir)
+}
+
+// resolveLocal resolves the modules imported by the module stored at path,
+// which is a file or a directory of .alloy files.
+func (r *moduleResolver) resolveLocal(ctx context.Context, path string, modulePath string) error {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if !fi.IsDir() {
+		bb, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return r.resolveSource(ctx, path, bb, modulePath)
+	}
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".alloy") {
+			continue
+		}
+		name := filepath.Join(path, e.Name())
+		bb, err := os.ReadFile(name)
+		if err != nil {
+			return err
+		}
+		if err := r.resolveSource(ctx, name, bb, modulePath); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *moduleResolver) resolveGit(ctx context.Context, block *ast.BlockStmt, modulePath string) error {
+	var args importsource.GitArguments
+	if !r.evaluate(block, modulePath, &args) {
+		return nil
+	}
+
+	// The repository is checked out once per revision, but every path of it
+	// is resolved.
+	checkout := args.Repository + " " + args.Revision
+	dir, ok := r.gitDirs[checkout]
+	if !ok {
+		var err error
+		if dir, err = r.checkoutGit(ctx, args); err != nil {
+			return err
+		}
+		r.gitDirs[checkout] = dir
+	}
+
+	key := "git " + checkout + " " + args.Path
+	if _, ok := r.seen[key]; ok {
+		return nil
+	}
+	r.seen[key] = struct{}{}
+	return r.resolveLocal(ctx, filepath.Join(dir, args.Path), dir)
+}
+
+// checkoutGit pins the revision of a repository in the lockfile and returns
+// the directory holding its files.
+func (r *moduleResolver) checkoutGit(ctx context.Context, args importsource.GitArguments) (string, error) {
+	entry := importsource.LockedGit{Repository: args.Repository, Revision: args.Revision}
+	revision := args.Revision
+	prev, pinned := r.prev.LookupGit(args.Repository, args.Revision)
+	if pinned {
+		entry.Vendor = prev.Vendor
+		if r.mode != resolveUpdate {
+			entry.Commit = prev.Commit
+			revision = prev.Commit
+		}
+	}
+
+	// Pinned vendored modules are already on disk.
+	if r.mode != resolveUpdate && entry.Vendor != "" {
+		dir := r.prev.VendorPath(entry.Vendor)
+		if _, err := os.Stat(dir); err == nil {
+			r.lock.Git = append(r.lock.Git, entry)
+			return dir, nil
+		}
+	}
+
+	r.n++
+	cloneDir := filepath.Join(r.workDir, fmt.Sprintf("git-%d", r.n))
+	repo, err := vcs.NewGitRepo(ctx, cloneDir, vcs.GitRepoOptions{
+		Repository: args.Repository,
+		Revision:   revision,
+		Auth:       args.GitAuthConfig,
+	})
+	if err != nil {
+		return "", err
+	}
+	if entry.Commit, err = repo.CurrentRevision(); err != nil {
+		return "", err
+	}
+
+	dir := cloneDir
+	if r.mode == resolveVendor || entry.Vendor != "" {
+		entry.Vendor = r.vendorName(args.Repository + "@" + args.Revision)
+		dir = r.lock.VendorPath(entry.Vendor)
+		if err := copyTree(cloneDir, dir); err != nil {
+			return "", fmt.Errorf("vendoring repository: %w", err)
+		}
+	}
+
+	r.lock.Git = append(r.lock.Git, entry)
+	return dir, nil
+}
+
+func (r *moduleResolver) resolveHTTP(ctx context.Context, block *ast.BlockStmt, modulePath string) error {
+	var args importsource.HTTPArguments
+	if !r.evaluate(block, modulePath, &args) {
+		return nil
+	}
+
+	key := "http " + args.URL
+	if _, ok := r.seen[key]; ok {
+		return nil
+	}
+	r.seen[key] = struct{}{}
+
+	entry := importsource.LockedHTTP{URL: args.URL}
+	prev, pinned := r.prev.LookupHTTP(args.URL)
+	if pinned {
+		entry.Vendor = prev.Vendor
+	}
+
+	var bb []byte
+	if r.mode != resolveUpdate && entry.Vendor != "" {
+		// Pinned vendored modules are read from disk, and downloaded again when
+		// they are missing.
+		bb, _ = os.ReadFile(r.prev.VendorPath(entry.Vendor))
+	}
+	if bb == nil {
+		var err error
+		if bb, err = fetchModule(ctx, args); err != nil {
+			return err
+		}
+	}
+
+	digest := sha256.Sum256(bb)
+	entry.SHA256 = hex.EncodeToString(digest[:])
+	if pinned && r.mode != resolveUpdate && !strings.EqualFold(entry.SHA256, prev.SHA256) {
+		return fmt.Errorf("content changed since it was locked: expected sha256 %s, got %s; run alloy modules update to pin the new content", prev.SHA256, entry.SHA256)
+	}
+
+	if r.mode == resolveVendor || entry.Vendor != "" {
+		entry.Vendor = r.vendorName(args.URL)
+		dest := r.lock.VendorPath(entry.Vendor)
+		if err := os.MkdirAll(filepath.Dir(dest), 0750); err != nil {
+			return err
+		}
+		if err := os.WriteFile(dest, bb, 0644); err != nil {
+			return fmt.Errorf("vendoring module: %w", err)
+		}
+	}
+
+	r.lock.HTTP = append(r.lock.HTTP, entry)
+	dir, _ := path.Split(args.URL)
+	return r.resolveSource(ctx, args.URL, bb, dir)
+}
+
+var unsafeVendorChars = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+// vendorName returns the path of a vendored module relative to the lockfile.
+func (r *moduleResolver) vendorName(source string) string {
+	name := strings.Trim(unsafeVendorChars.ReplaceAllString(source, "_"), "_")
+
+	// Vendored modules are stored relative to the lockfile when possible, so
+	// that the lockfile and the modules can be copied together.
+	dir, err := filepath.Abs(r.vendorDir)
+	if err != nil {
+		dir = r.vendorDir
+	}
+	if lockDir, err := filepath.Abs(r.lock.Dir()); err == nil {
+		if rel, err := filepath.Rel(lockDir, dir); err == nil && !strings.HasPrefix(rel, "..") {
+			dir = rel
+		}
+	}
+	return filepath.ToSlash(filepath.Join(dir, name))
+}
+
+// fetchModule downloads the module configured by args.
+func fetchModule(ctx context.Context, args importsource.HTTPArguments) ([]byte, error) {
+	cli, err := prom_config.NewClientFromConfig(*args.Client.Convert(), "alloy-modules")
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, args.PollTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, args.Method, args.URL, strings.NewReader(args.Body))
+	if err != nil {
+		return nil, err
+	}
+	for name, value := range args.Headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := cli.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("unexpected status code %s", resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// copyTree replaces dst with a copy of the worktree of the repository cloned
+// in src.
+func copyTree(src, dst string) error {
+	if err := os.RemoveAll(dst); err != nil {
+		return err
+	}
+	return filepath.WalkDir(src, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && d.Name() == ".git" {
+			return filepath.SkipDir
+		}
+
+		rel, err := filepath.Rel(src, p)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		if d.IsDir() {
+			return os.MkdirAll(target, 0750)
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		bb, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(target, bb, 0644)
+	})
+}
@@ -0,0 +1,172 @@
+package alloycli
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/alloy/internal/nodeconf/importsource"
+)
+
+func TestModules(t *testing.T) {
+	var (
+		mut     sync.Mutex
+		library = `declare "lib" {}`
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mut.Lock()
+		defer mut.Unlock()
+		_, _ = io.WriteString(w, library)
+	}))
+	defer srv.Close()
+	setLibrary := func(s string) {
+		mut.Lock()
+		defer mut.Unlock()
+		library = s
+	}
+
+	// The module of the repository imports another module over HTTP.
+	repoDir := t.TempDir()
+	repo, err := git.PlainInit(repoDir, false)
+	require.NoError(t, err)
+	commit := func(content string) string {
+		require.NoError(t, os.WriteFile(filepath.Join(repoDir, "module.alloy"), []byte(content), 0644))
+		wt, err := repo.Worktree()
+		require.NoError(t, err)
+		_, err = wt.Add("module.alloy")
+		require.NoError(t, err)
+		hash, err := wt.Commit("update module", &git.CommitOptions{
+			Author: &object.Signature{Name: "Go test", Email: "go-test@example.com", When: time.Now()},
+		})
+		require.NoError(t, err)
+		return hash.String()
+	}
+	module := `import.http "lib" { url = "` + srv.URL + `/lib.alloy" }`
+	commit1 := commit(module)
+
+	configDir := t.TempDir()
+	configPath := filepath.Join(configDir, "config.alloy")
+	require.NoError(t, os.WriteFile(configPath, []byte(`
+import.git "module" {
+	repository = "`+repoDir+`"
+	revision   = "master"
+	path       = "module.alloy"
+}
+
+import.string "skipped" {
+	content = local.file.missing.content
+}
+`), 0644))
+	lockfilePath := filepath.Join(configDir, importsource.LockfileName)
+
+	run := func(mode resolveMode) error {
+		m := &alloyModules{mode: mode}
+		return m.Run(context.Background(), configPath, io.Discard)
+	}
+	readLock := func() *importsource.Lockfile {
+		lock, err := importsource.ReadLockfile(lockfilePath)
+		require.NoError(t, err)
+		return lock
+	}
+
+	require.NoError(t, run(resolveLock))
+	lock := readLock()
+	require.Equal(t, []importsource.LockedGit{{Repository: repoDir, Revision: "master", Commit: commit1}}, lock.Git)
+	require.Equal(t, []importsource.LockedHTTP{{URL: srv.URL + "/lib.alloy", SHA256: sha256Hex(`declare "lib" {}`)}}, lock.HTTP)
+
+	// Existing pins are kept by lock and refreshed by update.
+	commit2 := commit(module + "\n")
+	require.NoError(t, run(resolveLock))
+	require.Equal(t, commit1, readLock().Git[0].Commit)
+	require.NoError(t, run(resolveUpdate))
+	require.Equal(t, commit2, readLock().Git[0].Commit)
+
+	// Content which doesn't match its pin is an error.
+	setLibrary(`declare "lib2" {}`)
+	require.ErrorContains(t, run(resolveLock), "content changed since it was locked")
+	require.NoError(t, run(resolveUpdate))
+	require.Equal(t, sha256Hex(`declare "lib2" {}`), readLock().HTTP[0].SHA256)
+
+	// Vendored modules are stored next to the lockfile.
+	require.NoError(t, run(resolveVendor))
+	lock = readLock()
+	require.NotEmpty(t, lock.Git[0].Vendor)
+	require.NotEmpty(t, lock.HTTP[0].Vendor)
+	bb, err := os.ReadFile(filepath.Join(lock.VendorPath(lock.Git[0].Vendor), "module.alloy"))
+	require.NoError(t, err)
+	require.Equal(t, module+"\n", string(bb))
+	bb, err = os.ReadFile(lock.VendorPath(lock.HTTP[0].Vendor))
+	require.NoError(t, err)
+	require.Equal(t, `declare "lib2" {}`, string(bb))
+	_, err = os.Stat(filepath.Join(lock.VendorPath(lock.Git[0].Vendor), ".git"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	// Vendored modules are locked without the network.
+	srv.Close()
+	require.NoError(t, os.RemoveAll(repoDir))
+	require.NoError(t, run(resolveLock))
+	require.Equal(t, lock, readLock())
+}
+
+func TestModules_RepositoryPaths(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `declare "lib" {}`)
+	}))
+	defer srv.Close()
+
+	// Only the second module of the repository imports another module.
+	repoDir := t.TempDir()
+	repo, err := git.PlainInit(repoDir, false)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(repoDir, "first.alloy"), []byte(`declare "first" {}`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(repoDir, "second.alloy"), []byte(`import.http "lib" { url = "`+srv.URL+`/lib.alloy" }`), 0644))
+	wt, err := repo.Worktree()
+	require.NoError(t, err)
+	_, err = wt.Add(".")
+	require.NoError(t, err)
+	hash, err := wt.Commit("add modules", &git.CommitOptions{
+		Author: &object.Signature{Name: "Go test", Email: "go-test@example.com", When: time.Now()},
+	})
+	require.NoError(t, err)
+
+	configDir := t.TempDir()
+	configPath := filepath.Join(configDir, "config.alloy")
+	require.NoError(t, os.WriteFile(configPath, []byte(`
+import.git "first" {
+	repository = "`+repoDir+`"
+	revision   = "master"
+	path       = "first.alloy"
+}
+
+import.git "second" {
+	repository = "`+repoDir+`"
+	revision   = "master"
+	path       = "second.alloy"
+}
+`), 0644))
+
+	m := &alloyModules{mode: resolveLock}
+	require.NoError(t, m.Run(context.Background(), configPath, io.Discard))
+
+	lock, err := importsource.ReadLockfile(filepath.Join(configDir, importsource.LockfileName))
+	require.NoError(t, err)
+	require.Equal(t, []importsource.LockedGit{{Repository: repoDir, Revision: "master", Commit: hash.String()}}, lock.Git)
+	require.Equal(t, []importsource.LockedHTTP{{URL: srv.URL + "/lib.alloy", SHA256: sha256Hex(`declare "lib" {}`)}}, lock.HTTP)
+}
+
+func sha256Hex(s string) string {
+	digest := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(digest[:])
+}
@@ -33,6 +33,7 @@ import (
 	"github.com/grafana/alloy/internal/converter"
 	convert_diag "github.com/grafana/alloy/internal/converter/diag"
 	"github.com/grafana/alloy/internal/featuregate"
+	"github.com/grafana/alloy/internal/nodeconf/importsource"
 	alloy_runtime "github.com/grafana/alloy/internal/runtime"
 	"github.com/grafana/alloy/internal/runtime/logging"
 	"github.com/grafana/alloy/internal/runtime/logging/level"
@@ -46,6 +47,7 @@ import (
 	uiservice "github.com/grafana/alloy/internal/service/ui"
 	"github.com/grafana/alloy/internal/static/config/instrumentation"
 	"github.com/grafana/alloy/internal/usagestats"
+	"github.com/grafana/alloy/internal/util"
 	"github.com/grafana/alloy/internal/util/windowspriority"
 	"github.com/grafana/alloy/syntax/diag"
 
@@ -152,11 +154,16 @@ depending on the nature of the reload error.
 		IntVar(&r.clusterWaitForSize, "cluster.wait-for-size", r.clusterWaitForSize, "Wait for the cluster to reach the specified number of instances before allowing components that use clustering to begin processing. Zero means disabled")
 	cmd.Flags().
 		DurationVar(&r.clusterWaitTimeout, "cluster.wait-timeout", 0, "Maximum duration to wait for minimum cluster size before proceeding with available nodes. Zero means wait forever, no timeout")
+	cmd.Flags().
+		IntVar(&r.clusterNodeWeight, "cluster.node-weight", r.clusterNodeWeight, "Weight to advertise to the cluster, which reflects the capacity of this node. Zero means detect the weight from the CPU and memory available")
+	cmd.Flags().
+		BoolVar(&r.clusterWeightedDistribution, "cluster.enable-weighted-distribution", r.clusterWeightedDistribution, "Distribute targets in proportion to the weights of the nodes")
 
 	// Config flags
 	cmd.Flags().StringVar(&r.configFormat, "config.format", r.configFormat, fmt.Sprintf("The format of the source file. Supported formats: %s.", supportedFormatsList()))
 	cmd.Flags().BoolVar(&r.configBypassConversionErrors, "config.bypass-conversion-errors", r.configBypassConversionErrors, "Enable bypassing errors when converting")
 	cmd.Flags().StringVar(&r.configExtraArgs, "config.extra-args", r.configExtraArgs, "Extra arguments from the original format used by the converter. Multiple arguments can be passed by separating them with a space.")
+	cmd.Flags().StringVar(&r.modulesLockfile, "modules.lockfile", r.modulesLockfile, fmt.Sprintf("Path of the lockfile pinning the imported modules. Defaults to %s in the directory of the configuration when it exists.", importsource.LockfileName))
 
 	// Misc flags
 	cmd.Flags().
@@ -197,9 +204,12 @@ type alloyRun struct {
 	clusterTLSServerName         string
 	clusterWaitForSize           int
 	clusterWaitTimeout           time.Duration
+	clusterNodeWeight            int
+	clusterWeightedDistribution  bool
 	configFormat                 string
 	configBypassConversionErrors bool
 	configExtraArgs              string
+	modulesLockfile              string
 	enableCommunityComps         bool
 	disableSupportBundle         bool
 	windowsPriority              string
@@ -290,6 +300,15 @@ func (fr *alloyRun) Run(cmd *cobra.Command, configPath string) error {
 		ready  func() bool
 	)
 
+	if fr.clusterWeightedDistribution {
+		if err := featuregate.CheckAllowed(
+			featuregate.StabilityExperimental,
+			fr.minStability,
+			"weighted distribution of targets in clustering"); err != nil {
+			return err
+		}
+	}
+
 	clusterService, err := buildClusterService(ClusterOptions{
 		Log:     log.With(l, "service", "cluster"),
 		Tracer:  t,
@@ -312,6 +331,9 @@ func (fr *alloyRun) Run(cmd *cobra.Command, configPath string) error {
 		TLSServerName:          fr.clusterTLSServerName,
 		MinimumClusterSize:     fr.clusterWaitForSize,
 		MinimumSizeWaitTimeout: fr.clusterWaitTimeout,
+
+		NodeWeight:                 fr.clusterNodeWeight,
+		EnableWeightedDistribution: fr.clusterWeightedDistribution,
 	})
 	if err != nil {
 		return err
@@ -371,6 +393,11 @@ func (fr *alloyRun) Run(cmd *cobra.Command, configPath string) error {
 	labelService := labelstore.New(l, reg)
 	alloyseed.Init(fr.storagePath, l)
 
+	moduleLock, err := fr.readModuleLock(configPath)
+	if err != nil {
+		return err
+	}
+
 	f := alloy_runtime.New(alloy_runtime.Options{
 		Logger:               l,
 		Tracer:               t,
@@ -378,6 +405,7 @@ func (fr *alloyRun) Run(cmd *cobra.Command, configPath string) error {
 		Reg:                  reg,
 		MinStability:         fr.minStability,
 		EnableCommunityComps: fr.enableCommunityComps,
+		ModuleLock:           moduleLock,
 		Services: []service.Service{
 			clusterService,
 			httpService,
@@ -485,6 +513,28 @@ func (fr *alloyRun) Run(cmd *cobra.Command, configPath string) error {
 	}
 }
 
+// readModuleLock reads the lockfile pinning the imported modules. The default
+// lockfile is only read when it exists.
+func (fr *alloyRun) readModuleLock(configPath string) (*importsource.Lockfile, error) {
+	path := fr.modulesLockfile
+	if path == "" {
+		dir, err := util.ExtractDirPath(configPath)
+		if err != nil {
+			return nil, nil
+		}
+		path = filepath.Join(dir, importsource.LockfileName)
+		if _, err := os.Stat(path); errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+	}
+
+	lock, err := importsource.ReadLockfile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading modules lockfile: %w", err)
+	}
+	return lock, nil
+}
+
 // getEnabledComponentsFunc returns a function that gets the current enabled components
 func getEnabledComponentsFunc(f *alloy_runtime.Runtime) func() map[string]interface{} {
 	return func() map[string]interface{} {
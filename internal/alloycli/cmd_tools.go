@@ -16,6 +16,7 @@ func toolsCommand() *cobra.Command {
 
 	cmd.AddCommand(
 		getTools("prometheus.remote_write", remotewrite.InstallTools),
+		relabelCommand(),
 	)
 
 	return cmd
@@ -0,0 +1,330 @@
+package alloycli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	alloy_relabel "github.com/grafana/alloy/internal/component/common/relabel"
+	"github.com/grafana/alloy/internal/component/discovery"
+	"github.com/grafana/alloy/syntax/ast"
+	"github.com/grafana/alloy/syntax/diag"
+	"github.com/grafana/alloy/syntax/parser"
+	"github.com/grafana/alloy/syntax/vm"
+)
+
+func relabelCommand() *cobra.Command {
+	r := &alloyRelabel{
+		output: "text",
+	}
+
+	cmd := &cobra.Command{
+		Use:   "relabel [flags] --rules file [targets file]",
+		Short: "Test relabeling rules against a set of targets",
+		Long: `The relabel subcommand applies relabeling rules to a set of targets and
+prints how each rule changed or dropped each target.
+
+The --rules flag points to a file which contains rule blocks, as found in
+discovery.relabel, prometheus.relabel, or loki.relabel. The rule blocks can
+either be at the top level of the file or inside of a component block, in which
+case every other argument of the component is ignored.
+
+The targets file contains a list of label sets in JSON or YAML format. If the
+targets file argument is not supplied or if it is "-", then relabel will read
+the targets from stdin.`,
+		Example: `  alloy tools relabel --rules rules.alloy targets.json
+  echo '[{"__address__": "localhost:9090"}]' | alloy tools relabel --rules config.alloy`,
+		Args:         cobra.RangeArgs(0, 1),
+		SilenceUsage: true,
+
+		RunE: func(cmd *cobra.Command, args []string) error {
+			targetsFile := "-"
+			if len(args) > 0 {
+				targetsFile = args[0]
+			}
+
+			err := r.Run(cmd.OutOrStdout(), targetsFile)
+
+			var diags diag.Diagnostics
+			if errors.As(err, &diags) {
+				for _, diag := range diags {
+					fmt.Fprintln(os.Stderr, diag)
+				}
+				return fmt.Errorf("could not decode rules")
+			}
+			return err
+		},
+	}
+
+	cmd.Flags().StringVarP(&r.rulesFile, "rules", "r", r.rulesFile, "File containing the rule blocks to apply")
+	cmd.Flags().StringVarP(&r.output, "output", "o", r.output, "Output format. Supported values: text, json")
+	_ = cmd.MarkFlagRequired("rules")
+	return cmd
+}
+
+type alloyRelabel struct {
+	rulesFile string
+	output    string
+}
+
+// relabelRule is a rule along with where it was defined.
+type relabelRule struct {
+	*alloy_relabel.Config
+	Position string
+}
+
+// relabelResult describes how a target was changed by the relabeling rules.
+type relabelResult struct {
+	Input   map[string]string `json:"input"`
+	Steps   []relabelStep     `json:"steps"`
+	Output  map[string]string `json:"output,omitempty"`
+	Dropped bool              `json:"dropped"`
+}
+
+// relabelStep describes the result of applying a single rule.
+type relabelStep struct {
+	Rule     int               `json:"rule"`
+	Position string            `json:"position"`
+	Action   string            `json:"action"`
+	Labels   map[string]string `json:"labels,omitempty"`
+	Dropped  bool              `json:"dropped,omitempty"`
+}
+
+func (r *alloyRelabel) Run(out io.Writer, targetsFile string) error {
+	switch r.output {
+	case "text", "json":
+	default:
+		return fmt.Errorf("unsupported output format %q", r.output)
+	}
+
+	bb, err := os.ReadFile(r.rulesFile)
+	if err != nil {
+		return err
+	}
+	rules, err := parseRelabelRules(r.rulesFile, bb)
+	if err != nil {
+		return err
+	}
+	if len(rules) == 0 {
+		return fmt.Errorf("no rule blocks found in %s", r.rulesFile)
+	}
+
+	if targetsFile == "-" {
+		bb, err = io.ReadAll(os.Stdin)
+	} else {
+		bb, err = os.ReadFile(targetsFile)
+	}
+	if err != nil {
+		return err
+	}
+	targets, err := parseRelabelTargets(bb)
+	if err != nil {
+		return err
+	}
+
+	results := make([]relabelResult, 0, len(targets))
+	for _, t := range targets {
+		results = append(results, applyRelabelRules(rules, t))
+	}
+
+	if r.output == "json" {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(results)
+	}
+	printRelabelResults(out, results)
+	return nil
+}
+
+// parseRelabelRules decodes every rule block in the file. Rule blocks may be
+// at the top level or nested one level deep inside of a component block.
+func parseRelabelRules(filename string, bb []byte) ([]relabelRule, error) {
+	f, err := parser.ParseFile(filename, bb)
+	if err != nil {
+		return nil, err
+	}
+
+	var blocks []*ast.BlockStmt
+	for _, stmt := range f.Body {
+		block, ok := stmt.(*ast.BlockStmt)
+		if !ok {
+			continue
+		}
+		if block.GetBlockName() == "rule" {
+			blocks = append(blocks, block)
+			continue
+		}
+		for _, inner := range block.Body {
+			if innerBlock, ok := inner.(*ast.BlockStmt); ok && innerBlock.GetBlockName() == "rule" {
+				blocks = append(blocks, innerBlock)
+			}
+		}
+	}
+
+	rules := make([]relabelRule, 0, len(blocks))
+	for _, block := range blocks {
+		var cfg alloy_relabel.Config
+		if err := vm.New(block).Evaluate(vm.NewScope(nil), &cfg); err != nil {
+			return nil, err
+		}
+		rules = append(rules, relabelRule{
+			Config:   &cfg,
+			Position: ast.StartPos(block).Position().String(),
+		})
+	}
+	return rules, nil
+}
+
+// parseRelabelTargets decodes a list of label sets, or a single label set, in
+// JSON or YAML format.
+func parseRelabelTargets(bb []byte) ([]map[string]string, error) {
+	var raw any
+	if err := yaml.Unmarshal(bb, &raw); err != nil {
+		return nil, fmt.Errorf("could not decode targets: %w", err)
+	}
+
+	var items []any
+	switch raw := raw.(type) {
+	case nil:
+		return nil, nil
+	case []any:
+		items = raw
+	case map[string]any:
+		items = []any{raw}
+	default:
+		return nil, fmt.Errorf("targets must be a list of label sets, got %T", raw)
+	}
+
+	targets := make([]map[string]string, 0, len(items))
+	for i, item := range items {
+		m, ok := item.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("target %d must be a label set, got %T", i+1, item)
+		}
+		t := make(map[string]string, len(m))
+		for k, v := range m {
+			switch v := v.(type) {
+			case map[string]any, []any:
+				return nil, fmt.Errorf("label %q of target %d must have a scalar value", k, i+1)
+			case nil:
+				t[k] = ""
+			default:
+				t[k] = fmt.Sprint(v)
+			}
+		}
+		targets = append(targets, t)
+	}
+	return targets, nil
+}
+
+// applyRelabelRules applies the rules to t one at a time, recording the
+// labels after each rule.
+func applyRelabelRules(rules []relabelRule, t map[string]string) relabelResult {
+	res := relabelResult{Input: t}
+
+	builder := discovery.NewTargetBuilderFrom(discovery.NewTargetFromMap(t))
+	for i, rule := range rules {
+		keep := alloy_relabel.ProcessBuilder(builder, rule.Config)
+		step := relabelStep{
+			Rule:     i + 1,
+			Position: rule.Position,
+			Action:   string(rule.Action),
+		}
+		if !keep {
+			step.Dropped = true
+			res.Steps = append(res.Steps, step)
+			res.Dropped = true
+			return res
+		}
+		step.Labels = builder.Target().AsMap()
+		res.Steps = append(res.Steps, step)
+	}
+
+	res.Output = builder.Target().AsMap()
+	return res
+}
+
+func printRelabelResults(out io.Writer, results []relabelResult) {
+	for i, res := range results {
+		if i > 0 {
+			fmt.Fprintln(out)
+		}
+		fmt.Fprintf(out, "Target %d: %s\n", i+1, formatLabelSet(res.Input))
+
+		prev := res.Input
+		for _, step := range res.Steps {
+			fmt.Fprintf(out, "  rule %d (%s) at %s:", step.Rule, step.Action, step.Position)
+			if step.Dropped {
+				fmt.Fprintln(out, " dropped target")
+				break
+			}
+
+			changes := diffLabelSets(prev, step.Labels)
+			if len(changes) == 0 {
+				fmt.Fprintln(out, " no change")
+			} else {
+				fmt.Fprintln(out)
+				for _, change := range changes {
+					fmt.Fprintf(out, "    %s\n", change)
+				}
+			}
+			prev = step.Labels
+		}
+
+		if res.Dropped {
+			fmt.Fprintln(out, "  Result: dropped")
+		} else {
+			fmt.Fprintf(out, "  Result: %s\n", formatLabelSet(res.Output))
+		}
+	}
+}
+
+// diffLabelSets returns a sorted, human-readable list of the labels which
+// were added, changed, or removed between before and after.
+func diffLabelSets(before, after map[string]string) []string {
+	var changes []string
+	for name, value := range after {
+		prevValue, ok := before[name]
+		switch {
+		case !ok:
+			changes = append(changes, fmt.Sprintf("+ %s=%q", name, value))
+		case prevValue != value:
+			changes = append(changes, fmt.Sprintf("~ %s=%q -> %q", name, prevValue, value))
+		}
+	}
+	for name, value := range before {
+		if _, ok := after[name]; !ok {
+			changes = append(changes, fmt.Sprintf("- %s=%q", name, value))
+		}
+	}
+	slices.SortFunc(changes, func(a, b string) int {
+		return strings.Compare(a[2:], b[2:])
+	})
+	return changes
+}
+
+func formatLabelSet(ls map[string]string) string {
+	names := make([]string, 0, len(ls))
+	for name := range ls {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	var sb strings.Builder
+	sb.WriteString("{")
+	for i, name := range names {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		fmt.Fprintf(&sb, "%s=%q", name, ls[name])
+	}
+	sb.WriteString("}")
+	return sb.String()
+}
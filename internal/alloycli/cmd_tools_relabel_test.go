@@ -0,0 +1,116 @@
+package alloycli
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testRelabelRules = `
+rule {
+  source_labels = ["__address__"]
+  regex         = "(.*):\\d+"
+  target_label  = "instance"
+}
+
+rule {
+  source_labels = ["env"]
+  regex         = "dev"
+  action        = "drop"
+}
+`
+
+func TestRelabelText(t *testing.T) {
+	dir := t.TempDir()
+	rulesFile := filepath.Join(dir, "rules.alloy")
+	targetsFile := filepath.Join(dir, "targets.json")
+	require.NoError(t, os.WriteFile(rulesFile, []byte(testRelabelRules), 0o644))
+	require.NoError(t, os.WriteFile(targetsFile, []byte(`[
+		{"__address__": "app:8080", "env": "prod"},
+		{"__address__": "app:8080", "env": "dev"}
+	]`), 0o644))
+
+	var out bytes.Buffer
+	r := &alloyRelabel{rulesFile: rulesFile, output: "text"}
+	require.NoError(t, r.Run(&out, targetsFile))
+
+	expect := `Target 1: {__address__="app:8080", env="prod"}
+  rule 1 (replace) at ` + rulesFile + `:2:1:
+    + instance="app"
+  rule 2 (drop) at ` + rulesFile + `:8:1: no change
+  Result: {__address__="app:8080", env="prod", instance="app"}
+
+Target 2: {__address__="app:8080", env="dev"}
+  rule 1 (replace) at ` + rulesFile + `:2:1:
+    + instance="app"
+  rule 2 (drop) at ` + rulesFile + `:8:1: dropped target
+  Result: dropped
+`
+	require.Equal(t, expect, out.String())
+}
+
+func TestRelabelJSON(t *testing.T) {
+	dir := t.TempDir()
+	rulesFile := filepath.Join(dir, "config.alloy")
+	targetsFile := filepath.Join(dir, "targets.yaml")
+	require.NoError(t, os.WriteFile(rulesFile, []byte(`
+discovery.relabel "default" {
+  targets = discovery.kubernetes.pods.targets
+
+  rule {
+    action = "labeldrop"
+    regex  = "__meta_.*"
+  }
+  rule {
+    source_labels = ["job"]
+    target_label  = "service"
+  }
+}
+`), 0o644))
+	require.NoError(t, os.WriteFile(targetsFile, []byte(`
+- job: api
+  __meta_kubernetes_pod_name: api-0
+  port: 8080
+`), 0o644))
+
+	var out bytes.Buffer
+	r := &alloyRelabel{rulesFile: rulesFile, output: "json"}
+	require.NoError(t, r.Run(&out, targetsFile))
+
+	var results []relabelResult
+	require.NoError(t, json.Unmarshal(out.Bytes(), &results))
+	require.Len(t, results, 1)
+	require.False(t, results[0].Dropped)
+	require.Len(t, results[0].Steps, 2)
+	require.Equal(t, map[string]string{"job": "api", "port": "8080"}, results[0].Steps[0].Labels)
+	require.Equal(t, map[string]string{"job": "api", "port": "8080", "service": "api"}, results[0].Output)
+}
+
+func TestParseRelabelTargets(t *testing.T) {
+	targets, err := parseRelabelTargets([]byte(`{"__address__": "localhost:9090"}`))
+	require.NoError(t, err)
+	require.Equal(t, []map[string]string{{"__address__": "localhost:9090"}}, targets)
+
+	_, err = parseRelabelTargets([]byte(`["localhost:9090"]`))
+	require.ErrorContains(t, err, "target 1 must be a label set")
+
+	_, err = parseRelabelTargets([]byte(`[{"labels": {"a": "b"}}]`))
+	require.ErrorContains(t, err, `label "labels" of target 1 must have a scalar value`)
+}
+
+func TestRelabelInvalidRule(t *testing.T) {
+	dir := t.TempDir()
+	rulesFile := filepath.Join(dir, "rules.alloy")
+	require.NoError(t, os.WriteFile(rulesFile, []byte(`
+rule {
+  action = "hashmod"
+}
+`), 0o644))
+
+	r := &alloyRelabel{rulesFile: rulesFile, output: "text"}
+	require.ErrorContains(t, r.Run(&bytes.Buffer{}, rulesFile), "requires non-zero modulus")
+}
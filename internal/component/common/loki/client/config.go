@@ -35,6 +35,35 @@ type Config struct {
 
 	// Queue controls configuration parameters specific to the queue client
 	Queue QueueConfig
+
+	// OnSendOutcome, if set, is called with the outcome of every batch the
+	// client sends or drops.
+	OnSendOutcome func(SendOutcome)
+}
+
+// SendOutcome describes the result of sending a batch of entries to Loki.
+type SendOutcome struct {
+	Host     string
+	TenantID string
+	// Entries is the number of entries in the batch.
+	Entries int
+	// StatusCode is the HTTP status code of the last attempt. It is 0 if no
+	// response was received.
+	StatusCode int
+	// Retries is the number of attempts made after the first one.
+	Retries int
+	// Dropped is true if the batch wasn't delivered.
+	Dropped bool
+	// Err is the error of the last attempt, if any.
+	Err error
+}
+
+// reportSendOutcome calls OnSendOutcome if it is set.
+func (cfg Config) reportSendOutcome(o SendOutcome) {
+	if cfg.OnSendOutcome != nil {
+		o.Host = cfg.URL.Host
+		cfg.OnSendOutcome(o)
+	}
 }
 
 // QueueConfig holds configurations for the queue-based remote-write client.
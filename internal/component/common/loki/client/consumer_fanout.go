@@ -282,7 +282,10 @@ func (c *client) sendBatch(tenantID string, batch *batch) {
 	c.metrics.encodedBytes.WithLabelValues(c.cfg.URL.Host, tenantID).Add(bufBytes)
 
 	backoff := backoff.New(c.ctx, c.cfg.BackoffConfig)
-	var status int
+	var (
+		status  int
+		retries int
+	)
 	for {
 		start := time.Now()
 		// send uses `timeout` internally, so `context.Background` is good enough.
@@ -295,12 +298,14 @@ func (c *client) sendBatch(tenantID string, batch *batch) {
 			level.Warn(c.logger).Log("msg", "dropping batch due to rate limiting applied at ingester")
 			c.metrics.droppedBytes.WithLabelValues(c.cfg.URL.Host, tenantID, ReasonRateLimited).Add(bufBytes)
 			c.metrics.droppedEntries.WithLabelValues(c.cfg.URL.Host, tenantID, ReasonRateLimited).Add(float64(entriesCount))
+			c.cfg.reportSendOutcome(SendOutcome{TenantID: tenantID, Entries: entriesCount, StatusCode: status, Retries: retries, Dropped: true, Err: err})
 			return
 		}
 
 		if err == nil {
 			c.metrics.sentBytes.WithLabelValues(c.cfg.URL.Host, tenantID).Add(bufBytes)
 			c.metrics.sentEntries.WithLabelValues(c.cfg.URL.Host, tenantID).Add(float64(entriesCount))
+			c.cfg.reportSendOutcome(SendOutcome{TenantID: tenantID, Entries: entriesCount, StatusCode: status, Retries: retries})
 			return
 		}
 
@@ -311,6 +316,7 @@ func (c *client) sendBatch(tenantID string, batch *batch) {
 
 		level.Debug(c.logger).Log("msg", "error sending batch, will retry", "status", status, "tenant", tenantID, "error", err)
 		c.metrics.batchRetries.WithLabelValues(c.cfg.URL.Host, tenantID).Inc()
+		retries++
 		backoff.Wait()
 
 		// Make sure it sends at least once before checking for retry.
@@ -328,6 +334,7 @@ func (c *client) sendBatch(tenantID string, batch *batch) {
 	}
 	c.metrics.droppedBytes.WithLabelValues(c.cfg.URL.Host, tenantID, dropReason).Add(bufBytes)
 	c.metrics.droppedEntries.WithLabelValues(c.cfg.URL.Host, tenantID, dropReason).Add(float64(entriesCount))
+	c.cfg.reportSendOutcome(SendOutcome{TenantID: tenantID, Entries: entriesCount, StatusCode: status, Retries: retries, Dropped: true, Err: err})
 }
 
 func (c *client) send(ctx context.Context, tenantID string, buf []byte) (int, error) {
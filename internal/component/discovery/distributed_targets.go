@@ -8,12 +8,19 @@ import (
 )
 
 // DistributedTargets uses the node's Lookup method to distribute discovery
-// targets when a component runs in a cluster.
+// targets when a component runs in a cluster. When the cluster distributes
+// work by weight, targets are assigned in proportion to the weight of the
+// peers instead.
 type DistributedTargets struct {
 	localTargets []Target
 	// localTargetKeys is used to cache the key hash computation. Improves time performance by ~20%.
 	localTargetKeys  []shard.Key
 	remoteTargetKeys map[shard.Key]struct{}
+
+	// balancer receives the number of local targets. It's nil when the
+	// cluster doesn't track the load of its peers.
+	balancer  cluster.Balancer
+	clustered bool
 }
 
 // NewDistributedTargets creates the abstraction that allows components to
@@ -25,25 +32,24 @@ func NewDistributedTargets(clusteringEnabled bool, cluster cluster.Cluster, allT
 // NewDistributedTargetsWithCustomLabels creates the abstraction that allows components to
 // dynamically shard targets between components. Passing in labels will limit the sharding to only use those labels for computing the hash key.
 // Passing in nil or empty array means look at all labels.
-func NewDistributedTargetsWithCustomLabels(clusteringEnabled bool, cluster cluster.Cluster, allTargets []Target, labels []string) *DistributedTargets {
-	if !clusteringEnabled || cluster == nil {
-		cluster = disabledCluster{}
+func NewDistributedTargetsWithCustomLabels(clusteringEnabled bool, c cluster.Cluster, allTargets []Target, labels []string) *DistributedTargets {
+	balancer, _ := c.(cluster.Balancer)
+	if !clusteringEnabled || c == nil {
+		c = disabledCluster{}
 	}
 
 	var localCap int
-	if !cluster.Ready() {
+	if !c.Ready() {
 		localCap = 0 // cluster not ready - won't take any traffic locally
-	} else if peerCount := len(cluster.Peers()); peerCount != 0 {
+	} else if peerCount := len(c.Peers()); peerCount != 0 {
 		localCap = (len(allTargets) + 1) / peerCount // if we have peers - calculate expected capacity
 	} else {
 		localCap = len(allTargets) // cluster ready but no peers? fall back to all traffic locally
 	}
 
-	localTargets := make([]Target, 0, localCap)
-	localTargetKeys := make([]shard.Key, 0, localCap)
-	remoteTargetKeys := make(map[shard.Key]struct{}, len(allTargets)-localCap)
-
 	// Need to handle duplicate entries.
+	uniqueTargets := make([]Target, 0, len(allTargets))
+	uniqueKeys := make([]shard.Key, 0, len(allTargets))
 	unique := make(map[shard.Key]struct{})
 	for _, tgt := range allTargets {
 		var targetKey shard.Key
@@ -59,19 +65,26 @@ func NewDistributedTargetsWithCustomLabels(clusteringEnabled bool, cluster clust
 			continue
 		}
 		unique[targetKey] = struct{}{}
+		uniqueTargets = append(uniqueTargets, tgt)
+		uniqueKeys = append(uniqueKeys, targetKey)
+	}
 
-		// Determine if target belongs locally. Make sure it doesn't if cluster not ready.
-		belongsToLocal := false
-		if cluster.Ready() {
-			peers, err := cluster.Lookup(targetKey, 1, shard.OpReadWrite)
-			belongsToLocal = err != nil || len(peers) == 0 || peers[0].Self
+	belongsToLocal := lookupOwnership(c, uniqueKeys)
+	if b, ok := c.(cluster.Balancer); ok && c.Ready() {
+		if weights := b.Weights(); weights != nil {
+			belongsToLocal = weightedOwnership(c, weights, uniqueKeys)
 		}
+	}
 
-		if belongsToLocal {
+	localTargets := make([]Target, 0, localCap)
+	localTargetKeys := make([]shard.Key, 0, localCap)
+	remoteTargetKeys := make(map[shard.Key]struct{}, len(uniqueKeys)-localCap)
+	for i, tgt := range uniqueTargets {
+		if belongsToLocal(i) {
 			localTargets = append(localTargets, tgt)
-			localTargetKeys = append(localTargetKeys, targetKey)
+			localTargetKeys = append(localTargetKeys, uniqueKeys[i])
 		} else {
-			remoteTargetKeys[targetKey] = struct{}{}
+			remoteTargetKeys[uniqueKeys[i]] = struct{}{}
 		}
 	}
 
@@ -79,7 +92,53 @@ func NewDistributedTargetsWithCustomLabels(clusteringEnabled bool, cluster clust
 		localTargets:     localTargets,
 		localTargetKeys:  localTargetKeys,
 		remoteTargetKeys: remoteTargetKeys,
+		balancer:         balancer,
+		clustered:        clusteringEnabled,
+	}
+}
+
+// lookupOwnership determines the owner of each key with the Lookup method of
+// the cluster.
+func lookupOwnership(c cluster.Cluster, keys []shard.Key) func(i int) bool {
+	return func(i int) bool {
+		// Make sure the target doesn't belong to the local node if cluster not ready.
+		if !c.Ready() {
+			return false
+		}
+		peers, err := c.Lookup(keys[i], 1, shard.OpReadWrite)
+		return err != nil || len(peers) == 0 || peers[0].Self
+	}
+}
+
+// weightedOwnership determines the owner of each key in proportion to the
+// weights of the peers.
+func weightedOwnership(c cluster.Cluster, weights map[string]int, keys []shard.Key) func(i int) bool {
+	var self string
+	for _, p := range c.Peers() {
+		if p.Self {
+			self = p.Name
+		}
+	}
+
+	owners := assignWeighted(keys, weights)
+	return func(i int) bool {
+		return owners[i] == "" || owners[i] == self
+	}
+}
+
+// ReportLoad reports the number of local targets to the cluster, which
+// advertises the number of targets owned by each peer. id identifies the
+// caller, and is usually the ID of the component. No targets are reported
+// when clustering is disabled.
+func (dt *DistributedTargets) ReportLoad(id string) {
+	if dt.balancer == nil {
+		return
+	}
+	var targets int
+	if dt.clustered {
+		targets = len(dt.localTargets)
 	}
+	dt.balancer.ReportTargets(id, targets)
 }
 
 // LocalTargets returns the targets that belong to the local cluster node.
@@ -3,6 +3,7 @@ package discovery
 import (
 	"fmt"
 	"math/rand"
+	"slices"
 	"testing"
 
 	"github.com/grafana/ckit/peer"
@@ -318,3 +319,82 @@ func (f *fakeCluster) Peers() []peer.Peer {
 func (f *fakeCluster) Ready() bool {
 	return true
 }
+
+func TestDistributedTargets_Weighted(t *testing.T) {
+	targets := make([]Target, 0, 1000)
+	for i := 0; i < 1000; i++ {
+		targets = append(targets, mkTarget("instance", fmt.Sprint(i)))
+	}
+	weights := map[string]int{"peer1": 1, "peer2": 1, "peer3": 2}
+
+	owned := make(map[string]int)
+	for _, self := range allTestPeers {
+		peers := make([]peer.Peer, 0, len(allTestPeers))
+		for _, p := range allTestPeers {
+			p.Self = p.Name == self.Name
+			peers = append(peers, p)
+		}
+		c := &weightedCluster{fakeCluster: fakeCluster{peers: peers}, weights: weights}
+
+		dt := NewDistributedTargets(true, c, targets)
+		owned[self.Name] = len(dt.LocalTargets())
+
+		dt.ReportLoad("prometheus.scrape.default")
+		require.Equal(t, map[string]int{"prometheus.scrape.default": owned[self.Name]}, c.reported)
+	}
+
+	// Every target is owned by exactly one peer, in proportion to the
+	// weights and within the bounded load.
+	require.Equal(t, 1000, owned["peer1"]+owned["peer2"]+owned["peer3"])
+	require.InDelta(t, 250, owned["peer1"], 50)
+	require.InDelta(t, 250, owned["peer2"], 50)
+	require.InDelta(t, 500, owned["peer3"], 100)
+	require.LessOrEqual(t, owned["peer3"], 625)
+}
+
+func TestAssignWeighted_BoundedLoad(t *testing.T) {
+	keys := make([]shard.Key, 100)
+	for i := range keys {
+		keys[i] = shard.Key(rand.Uint64())
+	}
+
+	// A single key always goes to the same peer, regardless of the order of
+	// the keys.
+	owners := assignWeighted(keys, map[string]int{"a": 1, "b": 1})
+	reversed := slices.Clone(keys)
+	slices.Reverse(reversed)
+	reversedOwners := assignWeighted(reversed, map[string]int{"a": 1, "b": 1})
+	for i := range keys {
+		require.Equal(t, owners[i], reversedOwners[len(keys)-1-i])
+	}
+
+	// A peer never owns more than its bounded share.
+	owners = assignWeighted(keys, map[string]int{"a": 1, "b": 1000})
+	var ownedByA int
+	for _, o := range owners {
+		if o == "a" {
+			ownedByA++
+		}
+	}
+	require.LessOrEqual(t, ownedByA, 1)
+
+	// Keys have no owner without peers.
+	require.Equal(t, []string{"", ""}, assignWeighted(keys[:2], nil))
+}
+
+type weightedCluster struct {
+	fakeCluster
+	weights  map[string]int
+	reported map[string]int
+}
+
+func (c *weightedCluster) Weights() map[string]int { return c.weights }
+
+func (c *weightedCluster) ReportTargets(componentID string, targets int) {
+	if c.reported == nil {
+		c.reported = make(map[string]int)
+	}
+	c.reported[componentID] = targets
+}
+
+func (c *weightedCluster) Loads() map[string]cluster.Load { return nil }
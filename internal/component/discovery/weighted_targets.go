@@ -0,0 +1,100 @@
+package discovery
+
+import (
+	"math"
+	"slices"
+	"sort"
+
+	"github.com/cespare/xxhash/v2"
+	"github.com/grafana/ckit/shard"
+)
+
+// boundedLoadFactor is how many times its share of the targets a peer can
+// own at most when targets are distributed by weight.
+const boundedLoadFactor = 1.25
+
+// assignWeighted assigns every key to a peer, in proportion to the weights of
+// the peers. It returns the name of the owner of each key, or an empty string
+// when there are no peers to assign keys to.
+//
+// Keys are assigned using weighted rendezvous hashing, with bounded loads: a
+// peer owns at most boundedLoadFactor times its share of the keys, and keys
+// overflowing a peer go to the next peer in their order of preference. Keys
+// are assigned in ascending order so that every peer computes the same
+// assignment for the same set of keys.
+func assignWeighted(keys []shard.Key, weights map[string]int) []string {
+	owners := make([]string, len(keys))
+
+	names := make([]string, 0, len(weights))
+	var totalWeight int
+	for name, w := range weights {
+		if w > 0 {
+			names = append(names, name)
+			totalWeight += w
+		}
+	}
+	if len(names) == 0 {
+		return owners
+	}
+	sort.Strings(names)
+
+	var (
+		seeds      = make([]uint64, len(names))
+		capacities = make([]int, len(names))
+		loads      = make([]int, len(names))
+	)
+	for i, name := range names {
+		seeds[i] = xxhash.Sum64String(name)
+		share := float64(len(keys)) * float64(weights[name]) / float64(totalWeight)
+		capacities[i] = max(1, int(math.Ceil(boundedLoadFactor*share)))
+	}
+
+	order := make([]int, len(keys))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortFunc(order, func(a, b int) int {
+		switch {
+		case keys[a] < keys[b]:
+			return -1
+		case keys[a] > keys[b]:
+			return 1
+		default:
+			return 0
+		}
+	})
+
+	for _, k := range order {
+		best, bestScore := -1, math.Inf(-1)
+		for i, name := range names {
+			if loads[i] >= capacities[i] {
+				continue
+			}
+			if score := rendezvousScore(uint64(keys[k]), seeds[i], weights[name]); score > bestScore {
+				best, bestScore = i, score
+			}
+		}
+		loads[best]++
+		owners[k] = names[best]
+	}
+	return owners
+}
+
+// rendezvousScore returns the score of a peer for a key. The peer with the
+// highest score owns the key, and each peer has the highest score for a
+// share of the keys proportional to its weight.
+func rendezvousScore(key, seed uint64, weight int) float64 {
+	// Map the hash to a float uniformly distributed in (0, 1).
+	u := (float64(mix64(key^seed)>>11) + 0.5) / (1 << 53)
+	return float64(weight) / -math.Log(u)
+}
+
+// mix64 is the finalizer of SplitMix64, which spreads the bits of x.
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
@@ -2,6 +2,7 @@ package file_match
 
 import (
 	"context"
+	"fmt"
 	"sync"
 	"time"
 
@@ -9,6 +10,7 @@ import (
 	"github.com/grafana/alloy/internal/component/discovery"
 	"github.com/grafana/alloy/internal/featuregate"
 	"github.com/grafana/alloy/internal/runtime/logging/level"
+	"github.com/grafana/alloy/internal/service/cluster"
 )
 
 func init() {
@@ -29,13 +31,19 @@ type Arguments struct {
 	PathTargets     []discovery.Target `alloy:"path_targets,attr"`
 	SyncPeriod      time.Duration      `alloy:"sync_period,attr,optional"`
 	IgnoreOlderThan time.Duration      `alloy:"ignore_older_than,attr,optional"`
+
+	Clustering cluster.ComponentBlock `alloy:"clustering,block,optional"`
 }
 
-var _ component.Component = (*Component)(nil)
+var (
+	_ component.Component = (*Component)(nil)
+	_ cluster.Component   = (*Component)(nil)
+)
 
 // Component implements the local.file_match component.
 type Component struct {
-	opts component.Options
+	opts    component.Options
+	cluster cluster.Cluster
 
 	mut            sync.Mutex
 	args           Arguments
@@ -46,8 +54,14 @@ type Component struct {
 
 // New creates a new local.file_match component.
 func New(o component.Options, args Arguments) (*Component, error) {
+	data, err := o.GetServiceData(cluster.ServiceName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get information about cluster: %w", err)
+	}
+
 	c := &Component{
 		opts:     o,
+		cluster:  data.(cluster.Cluster),
 		mut:      sync.Mutex{},
 		args:     args,
 		watches:  make([]watch, 0),
@@ -96,12 +110,27 @@ func (c *Component) Update(args component.Arguments) error {
 	}
 
 	// Always trigger immediate check when Update is called
+	c.triggerCheck()
+
+	return nil
+}
+
+// NotifyClusterChange implements cluster.Component.
+func (c *Component) NotifyClusterChange() {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	if !c.args.Clustering.Enabled {
+		return
+	}
+	c.triggerCheck()
+}
+
+func (c *Component) triggerCheck() {
 	select {
 	case c.targetsChanged <- struct{}{}:
 	default:
 	}
-
-	return nil
 }
 
 // Run satisfies the component interface.
@@ -140,5 +169,46 @@ func (c *Component) getWatchedFiles() []discovery.Target {
 		}
 		paths = append(paths, newPaths...)
 	}
-	return paths
+	return c.distribute(paths)
+}
+
+// distribute filters targets down to the files owned by this instance when
+// clustering is enabled. Ownership is determined by the path of the file only,
+// which is consistent with loki.source.file, so that both components agree on
+// the owner of a file.
+func (c *Component) distribute(targets []discovery.Target) []discovery.Target {
+	if !c.args.Clustering.Enabled {
+		return targets
+	}
+
+	var (
+		paths = make([]discovery.Target, 0, len(targets))
+		seen  = make(map[string]struct{}, len(targets))
+	)
+	for _, t := range targets {
+		path, _ := t.Get("__path__")
+		if _, ok := seen[path]; ok {
+			continue
+		}
+		seen[path] = struct{}{}
+		paths = append(paths, discovery.NewTargetFromMap(map[string]string{"__path__": path}))
+	}
+
+	distTargets := discovery.NewDistributedTargets(true, c.cluster, paths)
+	distTargets.ReportLoad(c.opts.ID)
+
+	local := make(map[string]struct{}, len(paths))
+	for _, t := range distTargets.LocalTargets() {
+		path, _ := t.Get("__path__")
+		local[path] = struct{}{}
+	}
+
+	localTargets := make([]discovery.Target, 0, len(local))
+	for _, t := range targets {
+		path, _ := t.Get("__path__")
+		if _, ok := local[path]; ok {
+			localTargets = append(localTargets, t)
+		}
+	}
+	return localTargets
 }
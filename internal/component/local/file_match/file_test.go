@@ -13,10 +13,13 @@ import (
 
 	"github.com/grafana/alloy/internal/component/discovery"
 
+	"github.com/grafana/ckit/peer"
+	"github.com/grafana/ckit/shard"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/require"
 
 	"github.com/grafana/alloy/internal/component"
+	"github.com/grafana/alloy/internal/service/cluster"
 	"github.com/grafana/alloy/internal/util"
 )
 
@@ -269,6 +272,47 @@ func TestMultiLabels(t *testing.T) {
 	require.True(t, contains([]discovery.Target{foundFiles[1]}, "t1.txt"))
 }
 
+func TestClustering(t *testing.T) {
+	dir := path.Join(os.TempDir(), "alloy_testing", "t4")
+	os.MkdirAll(dir, 0755)
+	writeFile(t, dir, "t1.txt")
+	writeFile(t, dir, "t2.txt")
+	t.Cleanup(func() {
+		os.RemoveAll(dir)
+	})
+	c := createComponent(t, dir, []string{path.Join(dir, "*.txt")}, nil)
+	c.args.Clustering.Enabled = true
+	c.cluster = &ownedPathsCluster{owned: path.Join(dir, "t1.txt")}
+
+	foundFiles := c.getWatchedFiles()
+	require.Len(t, foundFiles, 1)
+	require.True(t, contains(foundFiles, "t1.txt"))
+
+	// Every file is watched once clustering is disabled.
+	c.args.Clustering.Enabled = false
+	foundFiles = c.getWatchedFiles()
+	require.Len(t, foundFiles, 2)
+}
+
+// ownedPathsCluster is a cluster where this instance only owns a single path.
+type ownedPathsCluster struct {
+	owned string
+}
+
+func (c *ownedPathsCluster) Lookup(key shard.Key, _ int, _ shard.Op) ([]peer.Peer, error) {
+	target := discovery.NewTargetFromMap(map[string]string{"__path__": c.owned})
+	if shard.Key(target.NonMetaLabelsHash()) == key {
+		return []peer.Peer{{Name: "self", Self: true}}, nil
+	}
+	return []peer.Peer{{Name: "other"}}, nil
+}
+
+func (c *ownedPathsCluster) Peers() []peer.Peer {
+	return []peer.Peer{{Name: "self", Self: true}, {Name: "other"}}
+}
+
+func (c *ownedPathsCluster) Ready() bool { return true }
+
 // createComponent creates a component with the given paths and labels. The paths and excluded slices are zipped together
 // to create the set of targets to pass to the component.
 func createComponent(t *testing.T, dir string, paths []string, excluded []string) *Component {
@@ -299,6 +343,9 @@ func createComponentWithLabels(t *testing.T, dir string, paths []string, exclude
 		},
 		Registerer: prometheus.DefaultRegisterer,
 		Tracer:     nil,
+		GetServiceData: func(name string) (interface{}, error) {
+			return cluster.Mock(), nil
+		},
 	}, Arguments{
 		PathTargets: tPaths,
 		SyncPeriod:  1 * time.Second,
@@ -133,6 +133,20 @@ type GitLeaksConfig struct {
 	}
 }
 
+// LoadGitleaksConfig parses the gitleaks config file at path. If path is
+// empty, the config embedded in Alloy is used instead.
+func LoadGitleaksConfig(path string) (GitLeaksConfig, error) {
+	var cfg GitLeaksConfig
+	if path == "" {
+		// If no config file is explicitly provided, use the embedded one
+		_, err := toml.DecodeFS(embedFs, "gitleaks.toml", &cfg)
+		return cfg, err
+	}
+	// If a config file is provided, use that
+	_, err := toml.DecodeFile(path, &cfg)
+	return cfg, err
+}
+
 // metrics holds the set of metrics for secrets that are being redacted.
 type metrics struct {
 	// Total number of secrets redacted
@@ -415,19 +429,9 @@ func (c *Component) Update(args component.Arguments) error {
 	c.metrics = newMetrics(c.opts.Registerer, newArgs.OriginLabel)
 
 	// Parse GitLeaks configuration
-	var gitleaksCfg GitLeaksConfig
-	if c.args.GitleaksConfig == "" {
-		// If no config file is explicitly provided, use the embedded one
-		_, err := toml.DecodeFS(embedFs, "gitleaks.toml", &gitleaksCfg)
-		if err != nil {
-			return err
-		}
-	} else {
-		// If a config file is provided, use that
-		_, err := toml.DecodeFile(c.args.GitleaksConfig, &gitleaksCfg)
-		if err != nil {
-			return err
-		}
+	gitleaksCfg, err := LoadGitleaksConfig(c.args.GitleaksConfig)
+	if err != nil {
+		return err
 	}
 
 	var ruleGenericApiKey *Rule = nil
@@ -64,19 +64,26 @@ type Component struct {
 	serverMut sync.Mutex
 	server    *lokipush.PushAPIServer
 
-	fanout *loki.Fanout
+	fanout         *loki.Fanout
+	debugPublisher *source.DebugPublisher
 }
 
 func New(opts component.Options, args Arguments) (*Component, error) {
+	debugPublisher, err := source.NewDebugPublisher(opts)
+	if err != nil {
+		return nil, err
+	}
+
 	c := &Component{
 		opts:               opts,
 		handler:            loki.NewLogsBatchReceiver(),
 		uncheckedCollector: util.NewUncheckedCollector(nil),
 
-		fanout: loki.NewFanout(args.ForwardTo),
+		fanout:         loki.NewFanout(args.ForwardTo),
+		debugPublisher: debugPublisher,
 	}
 	opts.Registerer.MustRegister(c.uncheckedCollector)
-	err := c.Update(args)
+	err = c.Update(args)
 	if err != nil {
 		return nil, err
 	}
@@ -94,7 +101,7 @@ func (c *Component) Run(ctx context.Context) (err error) {
 		}
 	}()
 
-	source.ConsumeBatch(ctx, c.handler, c.fanout)
+	source.ConsumeBatch(ctx, c.handler, c.fanout, c.debugPublisher)
 	return
 }
 
@@ -151,3 +158,6 @@ func (c *Component) Update(args component.Arguments) error {
 
 	return nil
 }
+
+// LiveDebugging implements component.LiveDebugging.
+func (c *Component) LiveDebugging() {}
@@ -36,6 +36,7 @@ import (
 	fnet "github.com/grafana/alloy/internal/component/common/net"
 	"github.com/grafana/alloy/internal/component/common/relabel"
 	"github.com/grafana/alloy/internal/loki/util"
+	"github.com/grafana/alloy/internal/service/livedebugging"
 	"github.com/grafana/alloy/syntax/alloytypes"
 )
 
@@ -640,9 +641,10 @@ func newTestLokiClient(t *testing.T, args Arguments, opts component.Options) cli
 
 func defaultOptions() component.Options {
 	return component.Options{
-		ID:         "loki.source.api.test",
-		Logger:     log.NewNopLogger(),
-		Registerer: prometheus.NewRegistry(),
+		ID:             "loki.source.api.test",
+		Logger:         log.NewNopLogger(),
+		Registerer:     prometheus.NewRegistry(),
+		GetServiceData: getServiceData,
 	}
 }
 
@@ -681,3 +683,12 @@ func testArgsWithPorts(httpPort int, grpcPort int) Arguments {
 		MaxSendMessageSize:   100 * units.MiB,
 	}
 }
+
+func getServiceData(name string) (interface{}, error) {
+	switch name {
+	case livedebugging.ServiceName:
+		return livedebugging.NewLiveDebugging(), nil
+	default:
+		return nil, fmt.Errorf("service not found %s", name)
+	}
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/grafana/alloy/internal/component/common/loki"
 	fnet "github.com/grafana/alloy/internal/component/common/net"
 	alloy_relabel "github.com/grafana/alloy/internal/component/common/relabel"
+	"github.com/grafana/alloy/internal/component/loki/source"
 	"github.com/grafana/alloy/internal/component/loki/source/aws_firehose/internal"
 	"github.com/grafana/alloy/internal/util"
 )
@@ -51,8 +52,9 @@ func (a *Arguments) SetToDefault() {
 // Component is the main type for the `loki.source.awsfirehose` component.
 type Component struct {
 	// mut controls concurrent access to fanout
-	mut    sync.RWMutex
-	fanout []loki.LogsReceiver
+	mut            sync.RWMutex
+	fanout         []loki.LogsReceiver
+	debugPublisher *source.DebugPublisher
 
 	// destination is the main destination where the TargetServer writes received log entries to
 	destination loki.LogsReceiver
@@ -71,10 +73,16 @@ type Component struct {
 
 // New creates a new Component.
 func New(o component.Options, args Arguments) (*Component, error) {
+	debugPublisher, err := source.NewDebugPublisher(o)
+	if err != nil {
+		return nil, err
+	}
+
 	c := &Component{
 		opts:           o,
 		destination:    loki.NewLogsReceiver(),
 		fanout:         args.ForwardTo,
+		debugPublisher: debugPublisher,
 		serverMetrics:  util.NewUncheckedCollector(nil),
 		handlerMetrics: internal.NewMetrics(o.Registerer),
 
@@ -103,6 +111,7 @@ func (c *Component) Run(ctx context.Context) error {
 		case <-ctx.Done():
 			return nil
 		case entry := <-c.destination.Chan():
+			c.debugPublisher.Publish(entry)
 			c.mut.RLock()
 			for _, receiver := range c.fanout {
 				receiver.Chan() <- entry
@@ -178,6 +187,9 @@ func (c *Component) Update(args component.Arguments) error {
 	return nil
 }
 
+// LiveDebugging implements component.LiveDebugging.
+func (c *Component) LiveDebugging() {}
+
 // Send implements internal.Sender so that the component is able to receive logs decoded by the handler.
 func (c *Component) Send(ctx context.Context, entry loki.Entry) {
 	c.destination.Chan() <- entry
@@ -20,6 +20,7 @@ import (
 	"github.com/grafana/alloy/internal/component/common/loki"
 	fnet "github.com/grafana/alloy/internal/component/common/net"
 	alloy_config "github.com/grafana/alloy/internal/component/common/relabel"
+	"github.com/grafana/alloy/internal/service/livedebugging"
 	"github.com/grafana/alloy/internal/util"
 )
 
@@ -61,10 +62,11 @@ func TestComponentFromNestedController(t *testing.T) {
 	goleak.VerifyNone(t, goleak.IgnoreTopFunction("go.opencensus.io/stats/view.(*worker).start"))
 
 	opts := component.Options{
-		ID:            "foo/loki.source.awsfirehose.default",
-		Logger:        util.TestAlloyLogger(t),
-		Registerer:    prometheus.NewRegistry(),
-		OnStateChange: func(e component.Exports) {},
+		ID:             "foo/loki.source.awsfirehose.default",
+		Logger:         util.TestAlloyLogger(t),
+		Registerer:     prometheus.NewRegistry(),
+		OnStateChange:  func(e component.Exports) {},
+		GetServiceData: getServiceData,
 	}
 	ch1, ch2 := loki.NewLogsReceiver(), loki.NewLogsReceiver()
 	r1, r2 := newReceiver(ch1.Chan()), newReceiver(ch2.Chan())
@@ -98,10 +100,11 @@ func TestComponentFromNestedController(t *testing.T) {
 
 func TestComponent(t *testing.T) {
 	opts := component.Options{
-		ID:            "loki.source.awsfirehose",
-		Logger:        util.TestAlloyLogger(t),
-		Registerer:    prometheus.NewRegistry(),
-		OnStateChange: func(e component.Exports) {},
+		ID:             "loki.source.awsfirehose",
+		Logger:         util.TestAlloyLogger(t),
+		Registerer:     prometheus.NewRegistry(),
+		OnStateChange:  func(e component.Exports) {},
+		GetServiceData: getServiceData,
 	}
 
 	ch1, ch2 := loki.NewLogsReceiver(), loki.NewLogsReceiver()
@@ -176,10 +179,11 @@ func TestComponent(t *testing.T) {
 
 func TestComponent_UpdateWithNewArguments(t *testing.T) {
 	opts := component.Options{
-		ID:            "loki.source.awsfirehose",
-		Logger:        util.TestAlloyLogger(t),
-		Registerer:    prometheus.NewRegistry(),
-		OnStateChange: func(e component.Exports) {},
+		ID:             "loki.source.awsfirehose",
+		Logger:         util.TestAlloyLogger(t),
+		Registerer:     prometheus.NewRegistry(),
+		OnStateChange:  func(e component.Exports) {},
+		GetServiceData: getServiceData,
 	}
 
 	ch1, ch2 := loki.NewLogsReceiver(), loki.NewLogsReceiver()
@@ -311,3 +315,12 @@ func TestComponent_UpdateWithNewArguments(t *testing.T) {
 	require.JSONEq(t, expectedRecord, r2.received[0].Line)
 	require.NotContains(t, r2.received[0].Labels, model.LabelName("source_arn"), "expected received entry to not contain label")
 }
+
+func getServiceData(name string) (interface{}, error) {
+	switch name {
+	case livedebugging.ServiceName:
+		return livedebugging.NewLiveDebugging(), nil
+	default:
+		return nil, fmt.Errorf("service not found %s", name)
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/grafana/alloy/internal/component"
 	"github.com/grafana/alloy/internal/component/common/loki"
 	alloy_relabel "github.com/grafana/alloy/internal/component/common/relabel"
+	"github.com/grafana/alloy/internal/component/loki/source"
 	"github.com/grafana/alloy/internal/component/loki/source/azure_event_hubs/internal/parser"
 	kt "github.com/grafana/alloy/internal/component/loki/source/internal/kafkatarget"
 	"github.com/grafana/alloy/internal/featuregate"
@@ -76,11 +77,17 @@ func (a *Arguments) Validate() error {
 
 // New creates a new loki.source.azure_event_hubs component.
 func New(o component.Options, args Arguments) (*Component, error) {
+	debugPublisher, err := source.NewDebugPublisher(o)
+	if err != nil {
+		return nil, err
+	}
+
 	c := &Component{
-		mut:     sync.RWMutex{},
-		opts:    o,
-		handler: loki.NewLogsReceiver(),
-		fanout:  args.ForwardTo,
+		mut:            sync.RWMutex{},
+		opts:           o,
+		handler:        loki.NewLogsReceiver(),
+		fanout:         args.ForwardTo,
+		debugPublisher: debugPublisher,
 	}
 
 	// Call to Update() to start readers and set receivers once at the start.
@@ -93,11 +100,12 @@ func New(o component.Options, args Arguments) (*Component, error) {
 
 // Component implements the loki.source.azure_event_hubs component.
 type Component struct {
-	opts    component.Options
-	mut     sync.RWMutex
-	fanout  []loki.LogsReceiver
-	handler loki.LogsReceiver
-	target  *kt.TargetSyncer
+	opts           component.Options
+	mut            sync.RWMutex
+	fanout         []loki.LogsReceiver
+	debugPublisher *source.DebugPublisher
+	handler        loki.LogsReceiver
+	target         *kt.TargetSyncer
 }
 
 // Run implements component.Component.
@@ -117,6 +125,7 @@ func (c *Component) Run(ctx context.Context) error {
 		case <-ctx.Done():
 			return nil
 		case entry := <-c.handler.Chan():
+			c.debugPublisher.Publish(entry)
 			c.mut.RLock()
 			for _, receiver := range c.fanout {
 				receiver.Chan() <- entry
@@ -156,6 +165,9 @@ func (c *Component) Update(args component.Arguments) error {
 	return nil
 }
 
+// LiveDebugging implements component.LiveDebugging.
+func (c *Component) LiveDebugging() {}
+
 // Convert is used to bridge between the Alloy and Promtail types.
 func (a *Arguments) Convert() (kt.Config, error) {
 	lbls := make(model.LabelSet, len(a.Labels))
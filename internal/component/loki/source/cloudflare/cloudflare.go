@@ -97,7 +97,8 @@ type Component struct {
 	mut    sync.RWMutex
 	tailer *tailer
 
-	fanout *loki.Fanout
+	fanout         *loki.Fanout
+	debugPublisher *source.DebugPublisher
 }
 
 // New creates a new loki.source.cloudflare component.
@@ -116,12 +117,18 @@ func New(o component.Options, args Arguments) (*Component, error) {
 		return nil, err
 	}
 
+	debugPublisher, err := source.NewDebugPublisher(o)
+	if err != nil {
+		return nil, err
+	}
+
 	c := &Component{
-		opts:    o,
-		metrics: newMetrics(o.Registerer),
-		handler: loki.NewLogsReceiver(),
-		fanout:  loki.NewFanout(args.ForwardTo),
-		posFile: positionsFile,
+		opts:           o,
+		metrics:        newMetrics(o.Registerer),
+		handler:        loki.NewLogsReceiver(),
+		fanout:         loki.NewFanout(args.ForwardTo),
+		debugPublisher: debugPublisher,
+		posFile:        positionsFile,
 	}
 
 	// Call to Update() to start readers and set receivers once at the start.
@@ -146,7 +153,7 @@ func (c *Component) Run(ctx context.Context) error {
 		})
 	}()
 
-	source.Consume(ctx, c.handler, c.fanout)
+	source.Consume(ctx, c.handler, c.fanout, c.debugPublisher)
 	return nil
 }
 
@@ -173,6 +180,9 @@ func (c *Component) Update(args component.Arguments) error {
 	return nil
 }
 
+// LiveDebugging implements component.LiveDebugging.
+func (c *Component) LiveDebugging() {}
+
 // DebugInfo returns information about the status of targets.
 func (c *Component) DebugInfo() any {
 	c.mut.RLock()
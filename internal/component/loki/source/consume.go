@@ -12,12 +12,15 @@ import (
 // This function is typically used in component Run methods to handle the forwarding
 // of log entries from a component's internal handler to downstream receivers.
 // The fanout allows entries to be sent to multiple receivers concurrently.
-func Consume(ctx context.Context, recv loki.LogsReceiver, f *loki.Fanout) {
+// Forwarded entries are published to the live debugging service through p,
+// which may be nil.
+func Consume(ctx context.Context, recv loki.LogsReceiver, f *loki.Fanout, p *DebugPublisher) {
 	for {
 		select {
 		case <-ctx.Done():
 			return
 		case entry := <-recv.Chan():
+			p.Publish(entry)
 			// NOTE: the only error we can get is context.Canceled.
 			if err := f.Send(ctx, entry); err != nil {
 				return
@@ -32,12 +35,15 @@ func Consume(ctx context.Context, recv loki.LogsReceiver, f *loki.Fanout) {
 // This function is typically used in component Run methods to handle the forwarding
 // of log entries from a component's internal handler to downstream receivers.
 // The fanout allows entries to be sent to multiple receivers concurrently.
-func ConsumeBatch(ctx context.Context, recv loki.LogsBatchReceiver, f *loki.Fanout) {
+// Forwarded entries are published to the live debugging service through p,
+// which may be nil.
+func ConsumeBatch(ctx context.Context, recv loki.LogsBatchReceiver, f *loki.Fanout, p *DebugPublisher) {
 	for {
 		select {
 		case <-ctx.Done():
 			return
 		case batch := <-recv.Chan():
+			p.PublishBatch(batch)
 			// NOTE: the only error we can get is context.Canceled.
 			if err := f.SendBatch(ctx, batch); err != nil {
 				return
@@ -21,7 +21,7 @@ func TestConsume(t *testing.T) {
 
 		wg := sync.WaitGroup{}
 		wg.Go(func() {
-			Consume(ctx, producer, fanout)
+			Consume(ctx, producer, fanout, nil)
 		})
 
 		producer.Chan() <- loki.Entry{Entry: push.Entry{Line: "1"}}
@@ -35,7 +35,7 @@ func TestConsume(t *testing.T) {
 		ctx, cancel := context.WithCancel(context.Background())
 		wg := sync.WaitGroup{}
 		wg.Go(func() {
-			Consume(ctx, producer, fanout)
+			Consume(ctx, producer, fanout, nil)
 		})
 
 		producer.Chan() <- loki.Entry{Entry: push.Entry{Line: "1"}}
@@ -54,7 +54,7 @@ func TestConsumeBatch(t *testing.T) {
 
 		wg := sync.WaitGroup{}
 		wg.Go(func() {
-			ConsumeBatch(ctx, producer, fanout)
+			ConsumeBatch(ctx, producer, fanout, nil)
 		})
 
 		producer.Chan() <- []loki.Entry{{Entry: push.Entry{Line: "1"}}, {Entry: push.Entry{Line: "2"}}}
@@ -70,7 +70,7 @@ func TestConsumeBatch(t *testing.T) {
 		ctx, cancel := context.WithCancel(context.Background())
 		wg := sync.WaitGroup{}
 		wg.Go(func() {
-			ConsumeBatch(ctx, producer, fanout)
+			ConsumeBatch(ctx, producer, fanout, nil)
 		})
 
 		producer.Chan() <- []loki.Entry{{Entry: push.Entry{Line: "1"}}, {Entry: push.Entry{Line: "2"}}}
@@ -101,6 +101,7 @@ func (a *Arguments) Validate() error {
 var (
 	_ component.Component      = (*Component)(nil)
 	_ component.DebugComponent = (*Component)(nil)
+	_ component.LiveDebugging  = (*Component)(nil)
 )
 
 // Component implements the loki.source.file component.
@@ -117,7 +118,8 @@ type Component struct {
 	posFile   positions.Positions
 	rcs       []*relabel.Config
 
-	fanout *loki.Fanout
+	fanout         *loki.Fanout
+	debugPublisher *source.DebugPublisher
 }
 
 // New creates a new loki.source.file component.
@@ -136,14 +138,20 @@ func New(o component.Options, args Arguments) (*Component, error) {
 		return nil, err
 	}
 
+	debugPublisher, err := source.NewDebugPublisher(o)
+	if err != nil {
+		return nil, err
+	}
+
 	c := &Component{
-		opts:      o,
-		metrics:   newMetrics(o.Registerer),
-		exited:    atomic.NewBool(false),
-		handler:   loki.NewLogsReceiver(),
-		scheduler: source.NewScheduler[string](),
-		fanout:    loki.NewFanout(args.ForwardTo),
-		posFile:   positionsFile,
+		opts:           o,
+		metrics:        newMetrics(o.Registerer),
+		exited:         atomic.NewBool(false),
+		handler:        loki.NewLogsReceiver(),
+		scheduler:      source.NewScheduler[string](),
+		fanout:         loki.NewFanout(args.ForwardTo),
+		debugPublisher: debugPublisher,
+		posFile:        positionsFile,
 	}
 
 	// Call to Update() to start readers and set receivers once at the start.
@@ -169,7 +177,7 @@ func (c *Component) Run(ctx context.Context) error {
 	}()
 
 	// Start consume and fanout loop
-	source.Consume(ctx, c.handler, c.fanout)
+	source.Consume(ctx, c.handler, c.fanout, c.debugPublisher)
 	return nil
 }
 
@@ -307,6 +315,9 @@ func (c *Component) DebugInfo() interface{} {
 	return res
 }
 
+// LiveDebugging implements component.LiveDebugging.
+func (c *Component) LiveDebugging() {}
+
 type readerDebugInfo struct {
 	TargetsInfo []sourceInfo `alloy:"targets_info,block"`
 }
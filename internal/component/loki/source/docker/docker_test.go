@@ -1,6 +1,7 @@
 package docker
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
@@ -9,6 +10,7 @@ import (
 
 	"github.com/grafana/alloy/internal/component"
 	"github.com/grafana/alloy/internal/runtime/componenttest"
+	"github.com/grafana/alloy/internal/service/livedebugging"
 	"github.com/grafana/alloy/internal/util"
 	"github.com/grafana/alloy/syntax"
 )
@@ -62,10 +64,11 @@ func TestComponentDuplicateTargets(t *testing.T) {
 	require.NoError(t, ctrl.WaitRunning(time.Minute))
 
 	cmp, err := New(component.Options{
-		ID:         "loki.source.docker.test",
-		Logger:     util.TestAlloyLogger(t),
-		Registerer: prometheus.NewRegistry(),
-		DataPath:   t.TempDir(),
+		ID:             "loki.source.docker.test",
+		Logger:         util.TestAlloyLogger(t),
+		Registerer:     prometheus.NewRegistry(),
+		DataPath:       t.TempDir(),
+		GetServiceData: getServiceData,
 	}, args)
 	require.NoError(t, err)
 
@@ -93,3 +96,12 @@ func TestComponentDuplicateTargets(t *testing.T) {
 		require.Equal(t, "{__meta_docker_container_id=\"foo\", __meta_docker_port_private=\"8080\"}", ss.labelsStr)
 	}
 }
+
+func getServiceData(name string) (interface{}, error) {
+	switch name {
+	case livedebugging.ServiceName:
+		return livedebugging.NewLiveDebugging(), nil
+	default:
+		return nil, fmt.Errorf("service not found %s", name)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"iter"
 	"os"
 	"path/filepath"
 	"sync"
@@ -20,6 +21,7 @@ import (
 	"github.com/grafana/alloy/internal/component/loki/source/internal/positions"
 	"github.com/grafana/alloy/internal/featuregate"
 	"github.com/grafana/alloy/internal/runtime/logging/level"
+	"github.com/grafana/alloy/internal/service/cluster"
 )
 
 func init() {
@@ -52,6 +54,17 @@ type Arguments struct {
 	TailFromEnd          bool                 `alloy:"tail_from_end,attr,optional"`
 	LegacyPositionsFile  string               `alloy:"legacy_positions_file,attr,optional"`
 	OnPositionsFileError OnPositionsFileError `alloy:"on_positions_file_error,attr,optional"`
+	Clustering           Clustering           `alloy:"clustering,block,optional"`
+}
+
+// Clustering holds the clustering settings of loki.source.file.
+type Clustering struct {
+	cluster.ComponentBlock `alloy:",squash"`
+
+	// HandoffPath is a directory shared by every instance of the cluster,
+	// used to hand the positions of files over when they move to another
+	// instance.
+	HandoffPath string `alloy:"handoff_path,attr,optional"`
 }
 
 type OnPositionsFileError string
@@ -84,6 +97,9 @@ func (a *Arguments) SetToDefault() {
 }
 
 func (a *Arguments) Validate() error {
+	if a.Clustering.HandoffPath != "" && !a.Clustering.Enabled {
+		return errors.New("clustering handoff_path requires clustering to be enabled")
+	}
 	return a.FileMatch.Validate()
 }
 
@@ -125,11 +141,16 @@ type DecompressionConfig struct {
 	Format       CompressionFormat `alloy:"format,attr"`
 }
 
-var _ component.Component = (*Component)(nil)
+var (
+	_ component.Component     = (*Component)(nil)
+	_ component.LiveDebugging = (*Component)(nil)
+	_ cluster.Component       = (*Component)(nil)
+)
 
 // Component implements the loki.source.file component.
 type Component struct {
-	opts component.Options
+	opts    component.Options
+	cluster cluster.Cluster
 
 	metrics *metrics
 
@@ -151,9 +172,10 @@ type Component struct {
 	watcher *time.Ticker
 
 	handler loki.LogsReceiver
-	posFile positions.Positions
+	posFile *handoffPositions
 
-	fanout *loki.Fanout
+	fanout         *loki.Fanout
+	debugPublisher *source.DebugPublisher
 
 	stopping atomic.Bool
 }
@@ -179,14 +201,27 @@ func New(o component.Options, args Arguments) (*Component, error) {
 		return nil, err
 	}
 
+	data, err := o.GetServiceData(cluster.ServiceName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get information about cluster: %w", err)
+	}
+
+	debugPublisher, err := source.NewDebugPublisher(o)
+	if err != nil {
+		return nil, err
+	}
+
+	clusterData := data.(cluster.Cluster)
 	c := &Component{
-		opts:      o,
-		metrics:   newMetrics(o.Registerer),
-		handler:   loki.NewLogsReceiver(),
-		fanout:    loki.NewFanout(args.ForwardTo),
-		posFile:   positionsFile,
-		scheduler: source.NewScheduler[positions.Entry](),
-		watcher:   time.NewTicker(args.FileMatch.SyncPeriod),
+		opts:           o,
+		cluster:        clusterData,
+		metrics:        newMetrics(o.Registerer),
+		handler:        loki.NewLogsReceiver(),
+		fanout:         loki.NewFanout(args.ForwardTo),
+		debugPublisher: debugPublisher,
+		posFile:        newHandoffPositions(o.Logger, positionsFile, clusterData),
+		scheduler:      source.NewScheduler[positions.Entry](),
+		watcher:        time.NewTicker(args.FileMatch.SyncPeriod),
 	}
 
 	// Call to Update() to start sources and set receivers once at the start.
@@ -213,12 +248,16 @@ func (c *Component) Run(ctx context.Context) error {
 			close(c.handler.Chan())
 			c.mut.Unlock()
 		})
+
+		// Files owned by this instance move to other instances of the
+		// cluster while it's stopped.
+		c.posFile.releaseAll()
 	}()
 
 	var wg sync.WaitGroup
 
 	// Start consume and fanout loop
-	wg.Go(func() { source.Consume(ctx, c.handler, c.fanout) })
+	wg.Go(func() { source.Consume(ctx, c.handler, c.fanout, c.debugPublisher) })
 
 	wg.Go(func() {
 		for {
@@ -250,6 +289,18 @@ func (c *Component) Update(args component.Arguments) error {
 	c.mut.Lock()
 	defer c.mut.Unlock()
 
+	if newArgs.Clustering.HandoffPath != c.args.Clustering.HandoffPath {
+		var handoff *positions.Handoff
+		if newArgs.Clustering.HandoffPath != "" {
+			var err error
+			handoff, err = positions.NewHandoff(c.opts.Logger, filepath.Join(newArgs.Clustering.HandoffPath, c.opts.ID))
+			if err != nil {
+				return err
+			}
+		}
+		c.posFile.handoff.Store(handoff)
+	}
+
 	c.fanout.UpdateChildren(newArgs.ForwardTo)
 
 	// Choose resolver on FileMatch.
@@ -269,6 +320,20 @@ func (c *Component) Update(args component.Arguments) error {
 	return nil
 }
 
+// LiveDebugging implements component.LiveDebugging.
+func (c *Component) LiveDebugging() {}
+
+// NotifyClusterChange implements cluster.Component.
+func (c *Component) NotifyClusterChange() {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	if !c.args.Clustering.Enabled || c.stopping.Load() {
+		return
+	}
+	c.scheduleSources()
+}
+
 // scheduleSources resolves desired targets and reconciles the scheduler to
 // match the desired state.
 // Caller must hold write lock on c.mut before calling this function.
@@ -276,7 +341,7 @@ func (c *Component) scheduleSources() {
 	source.Reconcile(
 		c.opts.Logger,
 		c.scheduler,
-		c.resolver.Resolve(c.args.Targets),
+		c.distribute(c.resolver.Resolve(c.args.Targets)),
 		func(target resolvedTarget) positions.Entry {
 			return positions.Entry{Path: target.Path, Labels: target.Labels.String()}
 		},
@@ -298,7 +363,7 @@ func (c *Component) scheduleSources() {
 
 			c.metrics.totalBytes.WithLabelValues(target.Path).Set(float64(fi.Size()))
 
-			return c.newSource(sourceOptions{
+			src, err := c.newSource(sourceOptions{
 				path:                 target.Path,
 				labels:               target.Labels,
 				encoding:             c.args.Encoding,
@@ -308,10 +373,57 @@ func (c *Component) scheduleSources() {
 				onPositionsFileError: c.args.OnPositionsFileError,
 				legacyPositionUsed:   c.args.LegacyPositionsFile != "",
 			})
+			if err != nil || !c.args.Clustering.Enabled {
+				return src, err
+			}
+			return &handoffSource{Source: src, positions: c.posFile}, nil
 		},
 	)
 }
 
+// distribute filters resolved targets down to the files owned by this
+// instance when clustering is enabled. Ownership is determined by the path of
+// the file, which is consistent with local.file_match, so that both
+// components agree on the owner of a file.
+func (c *Component) distribute(targets iter.Seq[resolvedTarget]) iter.Seq[resolvedTarget] {
+	if !c.args.Clustering.Enabled {
+		return targets
+	}
+
+	var (
+		all   []resolvedTarget
+		paths []discovery.Target
+		seen  = make(map[string]struct{})
+	)
+	for target := range targets {
+		all = append(all, target)
+		if _, ok := seen[target.Path]; ok {
+			continue
+		}
+		seen[target.Path] = struct{}{}
+		paths = append(paths, discovery.NewTargetFromMap(map[string]string{labelPath: target.Path}))
+	}
+
+	local := make(map[string]struct{}, len(paths))
+	distTargets := discovery.NewDistributedTargets(true, c.cluster, paths)
+	distTargets.ReportLoad(c.opts.ID)
+	for _, target := range distTargets.LocalTargets() {
+		path, _ := target.Get(labelPath)
+		local[path] = struct{}{}
+	}
+
+	return func(yield func(resolvedTarget) bool) {
+		for _, target := range all {
+			if _, ok := local[target.Path]; !ok {
+				continue
+			}
+			if !yield(target) {
+				return
+			}
+		}
+	}
+}
+
 type debugInfo struct {
 	TargetsInfo []sourceDebugInfo `alloy:"targets_info,block"`
 }
@@ -0,0 +1,366 @@
+package file
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/grafana/ckit/peer"
+	"github.com/grafana/ckit/shard"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/alloy/internal/component"
+	"github.com/grafana/alloy/internal/component/common/loki"
+	"github.com/grafana/alloy/internal/component/discovery"
+	"github.com/grafana/alloy/internal/component/loki/source/internal/positions"
+	"github.com/grafana/alloy/internal/service/cluster"
+	"github.com/grafana/alloy/internal/service/livedebugging"
+	"github.com/grafana/alloy/internal/util"
+)
+
+func TestClustering_DistributesFiles(t *testing.T) {
+	dir := t.TempDir()
+	local, remote := filepath.Join(dir, "local.log"), filepath.Join(dir, "remote.log")
+	require.NoError(t, os.WriteFile(local, []byte("local\n"), 0600))
+	require.NoError(t, os.WriteFile(remote, []byte("remote\n"), 0600))
+
+	fc := &fakeCluster{owned: map[string]bool{local: true}}
+	receiver := loki.NewLogsReceiver()
+	c, err := New(clusteringOptions(t, fc), Arguments{
+		Targets: []discovery.Target{
+			discovery.NewTargetFromMap(map[string]string{"__path__": filepath.Join(dir, "*.log")}),
+		},
+		ForwardTo:  []loki.LogsReceiver{receiver},
+		FileWatch:  FileWatch{MinPollFrequency: 10 * time.Millisecond, MaxPollFrequency: 10 * time.Millisecond},
+		FileMatch:  FileMatch{Enabled: true, SyncPeriod: time.Hour},
+		Clustering: Clustering{ComponentBlock: cluster.ComponentBlock{Enabled: true}},
+	})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+	runComponent(t, ctx, c)
+
+	require.Equal(t, "local", receiveLine(t, receiver))
+	requireNoLine(t, receiver)
+
+	// The remote file moves to this instance.
+	fc.setOwned(local, remote)
+	c.NotifyClusterChange()
+	require.Equal(t, "remote", receiveLine(t, receiver))
+}
+
+func TestClustering_HandsPositionsOver(t *testing.T) {
+	var (
+		handoffPath = t.TempDir()
+		path        = filepath.Join(t.TempDir(), "app.log")
+	)
+	require.NoError(t, os.WriteFile(path, []byte("first\n"), 0600))
+
+	newComponent := func(fc *fakeCluster, receiver loki.LogsReceiver) *Component {
+		c, err := New(clusteringOptions(t, fc), Arguments{
+			Targets: []discovery.Target{
+				discovery.NewTargetFromMap(map[string]string{"__path__": path}),
+			},
+			ForwardTo: []loki.LogsReceiver{receiver},
+			FileWatch: FileWatch{MinPollFrequency: 10 * time.Millisecond, MaxPollFrequency: 10 * time.Millisecond},
+			FileMatch: FileMatch{SyncPeriod: time.Hour},
+			Clustering: Clustering{
+				ComponentBlock: cluster.ComponentBlock{Enabled: true},
+				HandoffPath:    handoffPath,
+			},
+		})
+		require.NoError(t, err)
+		return c
+	}
+
+	var (
+		clusterA, clusterB   = &fakeCluster{name: "a", owned: map[string]bool{path: true}}, &fakeCluster{name: "b"}
+		receiverA, receiverB = loki.NewLogsReceiver(), loki.NewLogsReceiver()
+		a, b                 = newComponent(clusterA, receiverA), newComponent(clusterB, receiverB)
+	)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+	runComponent(t, ctx, a)
+	runComponent(t, ctx, b)
+
+	require.Equal(t, "first", receiveLine(t, receiverA))
+	requireNoLine(t, receiverB)
+
+	// The file moves from a to b. b must continue from where a stopped.
+	clusterA.setOwned()
+	a.NotifyClusterChange()
+	require.Eventually(t, func() bool {
+		entries, err := os.ReadDir(filepath.Join(handoffPath, "loki.source.file.test"))
+		return err == nil && len(entries) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	clusterB.setOwned(path)
+	b.NotifyClusterChange()
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600)
+	require.NoError(t, err)
+	_, err = f.WriteString("second\n")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	require.Equal(t, "second", receiveLine(t, receiverB))
+	requireNoLine(t, receiverA)
+}
+
+func TestClustering_HandsPositionsOverWhileWriting(t *testing.T) {
+	var (
+		handoffPath = t.TempDir()
+		path        = filepath.Join(t.TempDir(), "app.log")
+	)
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	newComponent := func(fc *fakeCluster, receiver loki.LogsReceiver) *Component {
+		c, err := New(clusteringOptions(t, fc), Arguments{
+			Targets: []discovery.Target{
+				discovery.NewTargetFromMap(map[string]string{"__path__": path}),
+			},
+			ForwardTo: []loki.LogsReceiver{receiver},
+			FileWatch: FileWatch{MinPollFrequency: 10 * time.Millisecond, MaxPollFrequency: 10 * time.Millisecond},
+			FileMatch: FileMatch{SyncPeriod: time.Hour},
+			Clustering: Clustering{
+				ComponentBlock: cluster.ComponentBlock{Enabled: true},
+				HandoffPath:    handoffPath,
+			},
+		})
+		require.NoError(t, err)
+		return c
+	}
+
+	var (
+		clusterA, clusterB   = &fakeCluster{name: "a", owned: map[string]bool{path: true}}, &fakeCluster{name: "b"}
+		receiverA, receiverB = loki.NewLogsReceiver(), loki.NewLogsReceiver()
+		a, b                 = newComponent(clusterA, receiverA), newComponent(clusterB, receiverB)
+		linesA, linesB       = collectLines(t, receiverA), collectLines(t, receiverB)
+	)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+	runComponent(t, ctx, a)
+	runComponent(t, ctx, b)
+
+	// Lines are written until the file moved to b.
+	var (
+		written     atomic.Int64
+		stopWriting = make(chan struct{})
+		writerDone  = make(chan struct{})
+	)
+	go func() {
+		defer close(writerDone)
+		for i := 0; ; i++ {
+			select {
+			case <-stopWriting:
+				return
+			case <-time.After(time.Millisecond):
+			}
+			_, err := fmt.Fprintf(f, "%d\n", i)
+			require.NoError(t, err)
+			written.Add(1)
+		}
+	}()
+
+	require.Eventually(t, func() bool { return len(linesA.get()) >= 10 }, 5*time.Second, 10*time.Millisecond)
+
+	// b learns that it owns the file before a learns that it doesn't. b must
+	// wait for a to release the position rather than tail the file from its
+	// beginning.
+	clusterB.setOwned(path)
+	b.NotifyClusterChange()
+	time.Sleep(200 * time.Millisecond)
+	clusterA.setOwned()
+	a.NotifyClusterChange()
+
+	require.Eventually(t, func() bool { return len(linesB.get()) >= 10 }, 5*time.Second, 10*time.Millisecond)
+	close(stopWriting)
+	<-writerDone
+
+	// Every line is received exactly once, by a until it released the
+	// position and by b afterwards.
+	expect := make([]string, written.Load())
+	for i := range expect {
+		expect[i] = strconv.Itoa(i)
+	}
+	require.EventuallyWithT(t, func(c *assert.CollectT) {
+		assert.Equal(c, expect, append(linesA.get(), linesB.get()...))
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestClustering_HandoffTimeout(t *testing.T) {
+	prev := handoffTimeout
+	handoffTimeout = 100 * time.Millisecond
+	t.Cleanup(func() { handoffTimeout = prev })
+
+	var (
+		handoffPath = t.TempDir()
+		path        = filepath.Join(t.TempDir(), "app.log")
+	)
+	require.NoError(t, os.WriteFile(path, []byte("first\n"), 0600))
+
+	// The previous owner claimed the file and stopped without releasing it.
+	h, err := positions.NewHandoff(util.TestLogger(t), filepath.Join(handoffPath, "loki.source.file.test"))
+	require.NoError(t, err)
+	require.NoError(t, h.Claim(positions.Entry{Path: path, Labels: "{}"}, "gone"))
+
+	receiver := loki.NewLogsReceiver()
+	c, err := New(clusteringOptions(t, &fakeCluster{name: "a", owned: map[string]bool{path: true}}), Arguments{
+		Targets: []discovery.Target{
+			discovery.NewTargetFromMap(map[string]string{"__path__": path}),
+		},
+		ForwardTo: []loki.LogsReceiver{receiver},
+		FileWatch: FileWatch{MinPollFrequency: 10 * time.Millisecond, MaxPollFrequency: 10 * time.Millisecond},
+		FileMatch: FileMatch{SyncPeriod: time.Hour},
+		Clustering: Clustering{
+			ComponentBlock: cluster.ComponentBlock{Enabled: true},
+			HandoffPath:    handoffPath,
+		},
+	})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+	runComponent(t, ctx, c)
+
+	require.Equal(t, "first", receiveLine(t, receiver))
+	owner, err := h.Owner(positions.Entry{Path: path, Labels: "{}"})
+	require.NoError(t, err)
+	require.Equal(t, "a", owner)
+}
+
+func clusteringOptions(t *testing.T, c cluster.Cluster) component.Options {
+	return component.Options{
+		ID:            "loki.source.file.test",
+		Logger:        util.TestAlloyLogger(t),
+		Registerer:    prometheus.NewRegistry(),
+		OnStateChange: func(e component.Exports) {},
+		DataPath:      t.TempDir(),
+		GetServiceData: func(name string) (interface{}, error) {
+			switch name {
+			case livedebugging.ServiceName:
+				return livedebugging.NewLiveDebugging(), nil
+			case cluster.ServiceName:
+				return c, nil
+			default:
+				return nil, fmt.Errorf("service not found %s", name)
+			}
+		},
+	}
+}
+
+// runComponent runs c until ctx is canceled. The test waits for c to exit
+// before its temporary directories are removed, since c keeps writing
+// positions until then.
+func runComponent(t *testing.T, ctx context.Context, c *Component) {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		c.Run(ctx)
+	}()
+	t.Cleanup(func() { <-done })
+}
+
+func receiveLine(t *testing.T, receiver loki.LogsReceiver) string {
+	t.Helper()
+	select {
+	case entry := <-receiver.Chan():
+		return entry.Line
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "failed waiting for log line")
+		return ""
+	}
+}
+
+func requireNoLine(t *testing.T, receiver loki.LogsReceiver) {
+	t.Helper()
+	select {
+	case entry := <-receiver.Chan():
+		require.FailNow(t, "unexpected log line", entry.Line)
+	case <-time.After(200 * time.Millisecond):
+	}
+}
+
+// lineCollector receives the lines sent to a receiver until the test ends.
+type lineCollector struct {
+	mut   sync.Mutex
+	lines []string
+}
+
+func collectLines(t *testing.T, receiver loki.LogsReceiver) *lineCollector {
+	lc := &lineCollector{}
+	go func() {
+		for {
+			select {
+			case entry := <-receiver.Chan():
+				lc.mut.Lock()
+				lc.lines = append(lc.lines, entry.Line)
+				lc.mut.Unlock()
+			case <-t.Context().Done():
+				return
+			}
+		}
+	}()
+	return lc
+}
+
+func (lc *lineCollector) get() []string {
+	lc.mut.Lock()
+	defer lc.mut.Unlock()
+	return slices.Clone(lc.lines)
+}
+
+// fakeCluster is a cluster where this instance owns a set of files.
+type fakeCluster struct {
+	name  string // Name of this instance; "self" if empty.
+	mut   sync.Mutex
+	owned map[string]bool
+}
+
+func (f *fakeCluster) setOwned(paths ...string) {
+	f.mut.Lock()
+	defer f.mut.Unlock()
+	f.owned = make(map[string]bool, len(paths))
+	for _, path := range paths {
+		f.owned[path] = true
+	}
+}
+
+func (f *fakeCluster) Lookup(key shard.Key, _ int, _ shard.Op) ([]peer.Peer, error) {
+	f.mut.Lock()
+	defer f.mut.Unlock()
+	for path := range f.owned {
+		target := discovery.NewTargetFromMap(map[string]string{"__path__": path})
+		if shard.Key(target.NonMetaLabelsHash()) == key {
+			return []peer.Peer{{Name: f.self(), Self: true}}, nil
+		}
+	}
+	return []peer.Peer{{Name: "other"}}, nil
+}
+
+func (f *fakeCluster) Peers() []peer.Peer {
+	return []peer.Peer{{Name: f.self(), Self: true}, {Name: "other"}}
+}
+
+func (f *fakeCluster) self() string {
+	if f.name == "" {
+		return "self"
+	}
+	return f.name
+}
+
+func (f *fakeCluster) Ready() bool { return true }
@@ -22,6 +22,8 @@ import (
 	"github.com/grafana/alloy/internal/component/discovery"
 	"github.com/grafana/alloy/internal/runtime/componenttest"
 	"github.com/grafana/alloy/internal/runtime/logging"
+	"github.com/grafana/alloy/internal/service/cluster"
+	"github.com/grafana/alloy/internal/service/livedebugging"
 	"github.com/grafana/alloy/internal/util"
 	"github.com/grafana/alloy/syntax"
 )
@@ -373,10 +375,11 @@ func TestTwoTargets(t *testing.T) {
 	runTests(t, func(t *testing.T, match FileMatch) {
 		// Create opts for component
 		opts := component.Options{
-			Logger:        util.TestAlloyLogger(t),
-			Registerer:    prometheus.NewRegistry(),
-			OnStateChange: func(e component.Exports) {},
-			DataPath:      t.TempDir(),
+			Logger:         util.TestAlloyLogger(t),
+			Registerer:     prometheus.NewRegistry(),
+			OnStateChange:  func(e component.Exports) {},
+			DataPath:       t.TempDir(),
+			GetServiceData: getServiceData,
 		}
 
 		f, err := os.CreateTemp(opts.DataPath, "example")
@@ -504,10 +507,11 @@ func TestEncoding(t *testing.T) {
 		for _, tc := range testCases {
 			t.Run(tc.name, func(t *testing.T) {
 				opts := component.Options{
-					Logger:        util.TestAlloyLogger(t),
-					Registerer:    prometheus.NewRegistry(),
-					OnStateChange: func(e component.Exports) {},
-					DataPath:      t.TempDir(),
+					Logger:         util.TestAlloyLogger(t),
+					Registerer:     prometheus.NewRegistry(),
+					OnStateChange:  func(e component.Exports) {},
+					DataPath:       t.TempDir(),
+					GetServiceData: getServiceData,
 				}
 
 				filePath, err := filepath.Abs(filepath.Join("testdata", "encoding", tc.filename))
@@ -685,3 +689,14 @@ func checkMsg(t *testing.T, ch loki.LogsReceiver, msg string, timeout time.Durat
 		require.FailNow(t, "failed waiting for log line")
 	}
 }
+
+func getServiceData(name string) (interface{}, error) {
+	switch name {
+	case livedebugging.ServiceName:
+		return livedebugging.NewLiveDebugging(), nil
+	case cluster.ServiceName:
+		return cluster.Mock(), nil
+	default:
+		return nil, fmt.Errorf("service not found %s", name)
+	}
+}
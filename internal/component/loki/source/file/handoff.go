@@ -0,0 +1,205 @@
+package file
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-kit/log"
+	"go.uber.org/atomic"
+
+	"github.com/grafana/alloy/internal/component/loki/source"
+	"github.com/grafana/alloy/internal/component/loki/source/internal/positions"
+	"github.com/grafana/alloy/internal/runtime/logging/level"
+	"github.com/grafana/alloy/internal/service/cluster"
+)
+
+var (
+	// handoffTimeout is how long a new owner waits for the previous owner of
+	// a file to release its position. The previous owner is assumed to be gone
+	// after that. It can be replaced in tests.
+	handoffTimeout = 10 * time.Second
+
+	// handoffPollInterval is how frequently a new owner checks whether the
+	// previous owner released the position.
+	handoffPollInterval = 100 * time.Millisecond
+)
+
+// handoffPositions wraps the positions of the component to hand them over to
+// other instances of the cluster. Sources remove the position of a file when
+// they stop tailing it, which is when the position is released through the
+// handoff directory, if one is configured.
+type handoffPositions struct {
+	positions.Positions
+
+	logger  log.Logger
+	cluster cluster.Cluster
+	handoff atomic.Pointer[positions.Handoff]
+
+	mut sync.Mutex
+	// acquired holds the entries acquired since they were last released.
+	acquired map[positions.Entry]struct{}
+}
+
+func newHandoffPositions(logger log.Logger, p positions.Positions, c cluster.Cluster) *handoffPositions {
+	return &handoffPositions{
+		Positions: p,
+		logger:    logger,
+		cluster:   c,
+		acquired:  make(map[positions.Entry]struct{}),
+	}
+}
+
+// acquire takes over the position released for entry by another instance
+// before the entry starts being tailed on this instance. If another instance
+// still claims the entry, acquire first waits up to handoffTimeout for it to
+// release the position, so that both instances don't tail the file at once.
+// acquire returns early if ctx is canceled.
+func (p *handoffPositions) acquire(ctx context.Context, entry positions.Entry) {
+	h := p.handoff.Load()
+	if h == nil {
+		return
+	}
+
+	self := p.self()
+	if err := waitRelease(ctx, h, entry, self); err != nil {
+		if ctx.Err() != nil {
+			return
+		}
+		level.Warn(p.logger).Log("msg", "taking the file over without the position of the previous owner", "path", entry.Path, "err", err)
+	}
+
+	if err := h.Acquire(p.Positions, entry); err != nil {
+		level.Warn(p.logger).Log("msg", "failed to acquire the position released by another instance", "path", entry.Path, "err", err)
+	}
+	if err := h.Claim(entry, self); err != nil {
+		level.Warn(p.logger).Log("msg", "failed to claim file", "path", entry.Path, "err", err)
+	}
+
+	p.mut.Lock()
+	p.acquired[entry] = struct{}{}
+	p.mut.Unlock()
+}
+
+// waitRelease waits until entry isn't claimed by an instance other than self.
+func waitRelease(ctx context.Context, h *positions.Handoff, entry positions.Entry, self string) error {
+	ctx, cancel := context.WithTimeout(ctx, handoffTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(handoffPollInterval)
+	defer ticker.Stop()
+
+	for {
+		owner, err := h.Owner(entry)
+		if err != nil {
+			return err
+		}
+		if owner == "" || owner == self {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%s didn't release the position: %w", owner, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// self returns the name of this instance in the cluster.
+func (p *handoffPositions) self() string {
+	for _, peer := range p.cluster.Peers() {
+		if peer.Self {
+			return peer.Name
+		}
+	}
+	hostname, _ := os.Hostname()
+	return hostname
+}
+
+// Remove implements positions.Positions and releases the position of the
+// entry before removing it.
+func (p *handoffPositions) Remove(path, labels string) {
+	p.release(positions.Entry{Path: path, Labels: labels})
+	p.Positions.Remove(path, labels)
+}
+
+// releaseAll releases the position of every acquired entry. It's called
+// once the component stopped tailing files.
+func (p *handoffPositions) releaseAll() {
+	p.mut.Lock()
+	entries := make([]positions.Entry, 0, len(p.acquired))
+	for entry := range p.acquired {
+		entries = append(entries, entry)
+	}
+	p.mut.Unlock()
+
+	for _, entry := range entries {
+		p.release(entry)
+	}
+}
+
+func (p *handoffPositions) release(entry positions.Entry) {
+	h := p.handoff.Load()
+	if h == nil {
+		return
+	}
+
+	p.mut.Lock()
+	_, ok := p.acquired[entry]
+	p.mut.Unlock()
+	if !ok {
+		return
+	}
+
+	fi, statErr := os.Stat(entry.Path)
+	pos := p.Positions.GetString(entry.Path, entry.Labels)
+	offset, parseErr := strconv.ParseInt(pos, 10, 64)
+
+	var err error
+	switch {
+	case statErr != nil || parseErr != nil:
+		// There's nothing to hand over for files which are gone or were never read.
+		p.forget(entry)
+		err = h.Remove(entry)
+	case offset > fi.Size():
+		// The file was truncated, the source restarts from the beginning.
+		err = h.Remove(entry)
+	default:
+		p.forget(entry)
+		err = h.Release(entry, pos)
+	}
+	if err != nil {
+		level.Warn(p.logger).Log("msg", "failed to release position", "path", entry.Path, "err", err)
+	}
+}
+
+func (p *handoffPositions) forget(entry positions.Entry) {
+	p.mut.Lock()
+	delete(p.acquired, entry)
+	p.mut.Unlock()
+}
+
+// handoffSource acquires the position of a file before it starts tailing it.
+// Acquiring the position may wait for the previous owner to release it, so it
+// happens when the source runs rather than when it's scheduled.
+type handoffSource struct {
+	source.Source[positions.Entry]
+	positions *handoffPositions
+}
+
+func (s *handoffSource) Run(ctx context.Context) {
+	s.positions.acquire(ctx, s.Key())
+	s.Source.Run(ctx)
+}
+
+func (s *handoffSource) DebugInfo() any {
+	ds, ok := s.Source.(source.DebugSource)
+	if !ok {
+		return nil
+	}
+	return ds.DebugInfo()
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/grafana/alloy/internal/component"
 	"github.com/grafana/alloy/internal/component/common/loki"
 	alloy_relabel "github.com/grafana/alloy/internal/component/common/relabel"
+	"github.com/grafana/alloy/internal/component/loki/source"
 	"github.com/grafana/alloy/internal/component/loki/source/gcplog/gcptypes"
 	gt "github.com/grafana/alloy/internal/component/loki/source/gcplog/internal/gcplogtarget"
 	"github.com/grafana/alloy/internal/util"
@@ -59,21 +60,28 @@ type Component struct {
 	metrics       *gt.Metrics
 	serverMetrics *util.UncheckedCollector
 
-	mut    sync.RWMutex
-	fanout []loki.LogsReceiver
-	target gt.Target
+	mut            sync.RWMutex
+	fanout         []loki.LogsReceiver
+	debugPublisher *source.DebugPublisher
+	target         gt.Target
 
 	handler loki.LogsReceiver
 }
 
 // New creates a new loki.source.gcplog component.
 func New(o component.Options, args Arguments) (*Component, error) {
+	debugPublisher, err := source.NewDebugPublisher(o)
+	if err != nil {
+		return nil, err
+	}
+
 	c := &Component{
-		opts:          o,
-		metrics:       gt.NewMetrics(o.Registerer),
-		handler:       loki.NewLogsReceiver(),
-		fanout:        args.ForwardTo,
-		serverMetrics: util.NewUncheckedCollector(nil),
+		opts:           o,
+		metrics:        gt.NewMetrics(o.Registerer),
+		handler:        loki.NewLogsReceiver(),
+		fanout:         args.ForwardTo,
+		debugPublisher: debugPublisher,
+		serverMetrics:  util.NewUncheckedCollector(nil),
 	}
 
 	o.Registerer.MustRegister(c.serverMetrics)
@@ -103,6 +111,7 @@ func (c *Component) Run(ctx context.Context) error {
 		case <-ctx.Done():
 			return nil
 		case entry := <-c.handler.Chan():
+			c.debugPublisher.Publish(entry)
 			c.mut.RLock()
 			for _, receiver := range c.fanout {
 				receiver.Chan() <- entry
@@ -164,6 +173,9 @@ func (c *Component) Update(args component.Arguments) error {
 	return nil
 }
 
+// LiveDebugging implements component.LiveDebugging.
+func (c *Component) LiveDebugging() {}
+
 // DebugInfo returns information about the status of targets.
 func (c *Component) DebugInfo() interface{} {
 	c.mut.RLock()
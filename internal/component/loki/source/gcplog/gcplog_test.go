@@ -19,6 +19,7 @@ import (
 	fnet "github.com/grafana/alloy/internal/component/common/net"
 	alloy_relabel "github.com/grafana/alloy/internal/component/common/relabel"
 	"github.com/grafana/alloy/internal/component/loki/source/gcplog/gcptypes"
+	"github.com/grafana/alloy/internal/service/livedebugging"
 	"github.com/grafana/alloy/internal/util"
 )
 
@@ -30,10 +31,11 @@ func TestPushFromNestedController(t *testing.T) {
 	goleak.VerifyNone(t, goleak.IgnoreTopFunction("go.opencensus.io/stats/view.(*worker).start"))
 
 	opts := component.Options{
-		ID:            "foo/loki.source.gcplog.default",
-		Logger:        util.TestAlloyLogger(t),
-		Registerer:    prometheus.NewRegistry(),
-		OnStateChange: func(e component.Exports) {},
+		ID:             "foo/loki.source.gcplog.default",
+		Logger:         util.TestAlloyLogger(t),
+		Registerer:     prometheus.NewRegistry(),
+		OnStateChange:  func(e component.Exports) {},
+		GetServiceData: getServiceData,
 	}
 
 	ch1, ch2 := loki.NewLogsReceiver(), loki.NewLogsReceiver()
@@ -65,9 +67,10 @@ func TestPushFromNestedController(t *testing.T) {
 
 func TestPush(t *testing.T) {
 	opts := component.Options{
-		Logger:        util.TestAlloyLogger(t),
-		Registerer:    prometheus.NewRegistry(),
-		OnStateChange: func(e component.Exports) {},
+		Logger:         util.TestAlloyLogger(t),
+		Registerer:     prometheus.NewRegistry(),
+		OnStateChange:  func(e component.Exports) {},
+		GetServiceData: getServiceData,
 	}
 
 	ch1, ch2 := loki.NewLogsReceiver(), loki.NewLogsReceiver()
@@ -165,3 +168,12 @@ func mustNewRegexp(s string) alloy_relabel.Regexp {
 	}
 	return alloy_relabel.Regexp{Regexp: re}
 }
+
+func getServiceData(name string) (interface{}, error) {
+	switch name {
+	case livedebugging.ServiceName:
+		return livedebugging.NewLiveDebugging(), nil
+	default:
+		return nil, fmt.Errorf("service not found %s", name)
+	}
+}
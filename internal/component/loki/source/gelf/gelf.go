@@ -10,6 +10,7 @@ import (
 	"github.com/grafana/alloy/internal/component"
 	"github.com/grafana/alloy/internal/component/common/loki"
 	alloy_relabel "github.com/grafana/alloy/internal/component/common/relabel"
+	"github.com/grafana/alloy/internal/component/loki/source"
 	"github.com/grafana/alloy/internal/component/loki/source/gelf/internal/target"
 	"github.com/grafana/alloy/internal/featuregate"
 	"github.com/grafana/alloy/internal/loki/promtail/scrapeconfig"
@@ -27,7 +28,10 @@ func init() {
 	})
 }
 
-var _ component.Component = (*Component)(nil)
+var (
+	_ component.Component     = (*Component)(nil)
+	_ component.LiveDebugging = (*Component)(nil)
+)
 
 // Component is a receiver for graylog formatted log files.
 type Component struct {
@@ -37,6 +41,8 @@ type Component struct {
 	metrics   *target.Metrics
 	handler   *handler
 	receivers []loki.LogsReceiver
+
+	debugPublisher *source.DebugPublisher
 }
 
 // Run starts the component.
@@ -57,6 +63,7 @@ func (c *Component) Run(ctx context.Context) error {
 			if lokiEntry.Labels["job"] == "" {
 				lokiEntry.Labels["job"] = model.LabelValue(c.o.ID)
 			}
+			c.debugPublisher.Publish(lokiEntry)
 			for _, r := range c.receivers {
 				r.Chan() <- lokiEntry
 			}
@@ -90,6 +97,9 @@ func (c *Component) Update(args component.Arguments) error {
 	return nil
 }
 
+// LiveDebugging implements component.LiveDebugging.
+func (c *Component) LiveDebugging() {}
+
 // Arguments are the arguments for the component.
 type Arguments struct {
 	// ListenAddress only supports UDP.
@@ -121,11 +131,17 @@ func convertConfig(a Arguments) *scrapeconfig.GelfTargetConfig {
 
 // New creates a new gelf component.
 func New(o component.Options, args Arguments) (*Component, error) {
+	debugPublisher, err := source.NewDebugPublisher(o)
+	if err != nil {
+		return nil, err
+	}
+
 	metrics := target.NewMetrics(o.Registerer)
 	c := &Component{
-		o:       o,
-		metrics: metrics,
-		handler: &handler{c: make(chan loki.Entry)},
+		o:              o,
+		metrics:        metrics,
+		handler:        &handler{c: make(chan loki.Entry)},
+		debugPublisher: debugPublisher,
 	}
 	// Call to Update() to start readers and set receivers once at the start.
 	if err := c.Update(args); err != nil {
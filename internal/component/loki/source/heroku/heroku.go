@@ -9,6 +9,7 @@ import (
 	"github.com/grafana/alloy/internal/component/common/loki"
 	fnet "github.com/grafana/alloy/internal/component/common/net"
 	alloy_relabel "github.com/grafana/alloy/internal/component/common/relabel"
+	"github.com/grafana/alloy/internal/component/loki/source"
 	ht "github.com/grafana/alloy/internal/component/loki/source/heroku/internal/herokutarget"
 	"github.com/grafana/alloy/internal/featuregate"
 	"github.com/grafana/alloy/internal/runtime/logging/level"
@@ -53,25 +54,32 @@ type Component struct {
 	metrics       *ht.Metrics              // Metrics about Heroku entries.
 	serverMetrics *util.UncheckedCollector // Metircs about the HTTP server managed by the component.
 
-	mut    sync.RWMutex
-	args   Arguments
-	fanout []loki.LogsReceiver
-	target *ht.HerokuTarget
+	mut            sync.RWMutex
+	args           Arguments
+	fanout         []loki.LogsReceiver
+	debugPublisher *source.DebugPublisher
+	target         *ht.HerokuTarget
 
 	handler loki.LogsReceiver
 }
 
 // New creates a new loki.source.heroku component.
 func New(o component.Options, args Arguments) (*Component, error) {
+	debugPublisher, err := source.NewDebugPublisher(o)
+	if err != nil {
+		return nil, err
+	}
+
 	c := &Component{
-		opts:          o,
-		metrics:       ht.NewMetrics(o.Registerer),
-		mut:           sync.RWMutex{},
-		args:          Arguments{},
-		fanout:        args.ForwardTo,
-		target:        nil,
-		handler:       loki.NewLogsReceiver(),
-		serverMetrics: util.NewUncheckedCollector(nil),
+		opts:           o,
+		metrics:        ht.NewMetrics(o.Registerer),
+		mut:            sync.RWMutex{},
+		args:           Arguments{},
+		fanout:         args.ForwardTo,
+		debugPublisher: debugPublisher,
+		target:         nil,
+		handler:        loki.NewLogsReceiver(),
+		serverMetrics:  util.NewUncheckedCollector(nil),
 	}
 
 	o.Registerer.MustRegister(c.serverMetrics)
@@ -104,6 +112,7 @@ func (c *Component) Run(ctx context.Context) error {
 		case <-ctx.Done():
 			return nil
 		case entry := <-c.handler.Chan():
+			c.debugPublisher.Publish(entry)
 			c.mut.RLock()
 			for _, receiver := range c.fanout {
 				receiver.Chan() <- entry
@@ -159,6 +168,9 @@ func (c *Component) Update(args component.Arguments) error {
 	return nil
 }
 
+// LiveDebugging implements component.LiveDebugging.
+func (c *Component) LiveDebugging() {}
+
 // Convert is used to bridge between the Alloy and Promtail types.
 func (args *Arguments) Convert() *ht.HerokuDrainTargetConfig {
 	lbls := make(model.LabelSet, len(args.Labels))
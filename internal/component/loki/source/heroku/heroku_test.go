@@ -12,6 +12,7 @@ import (
 	fnet "github.com/grafana/alloy/internal/component/common/net"
 	alloy_relabel "github.com/grafana/alloy/internal/component/common/relabel"
 	"github.com/grafana/alloy/internal/component/loki/source/heroku/internal/herokutarget"
+	"github.com/grafana/alloy/internal/service/livedebugging"
 	"github.com/grafana/alloy/internal/util"
 	"github.com/grafana/regexp"
 	"github.com/phayes/freeport"
@@ -198,9 +199,10 @@ var rulesExport = alloy_relabel.Rules{
 
 func defaultOptions(t *testing.T) component.Options {
 	return component.Options{
-		Logger:        util.TestAlloyLogger(t),
-		Registerer:    prometheus.NewRegistry(),
-		OnStateChange: func(e component.Exports) {},
+		Logger:         util.TestAlloyLogger(t),
+		Registerer:     prometheus.NewRegistry(),
+		OnStateChange:  func(e component.Exports) {},
+		GetServiceData: getServiceData,
 	}
 }
 
@@ -262,3 +264,12 @@ func newRegexp() alloy_relabel.Regexp {
 func getEndpoint(target *herokutarget.HerokuTarget) string {
 	return fmt.Sprintf("http://%s%s", target.HTTPListenAddress(), target.DrainEndpoint())
 }
+
+func getServiceData(name string) (interface{}, error) {
+	switch name {
+	case livedebugging.ServiceName:
+		return livedebugging.NewLiveDebugging(), nil
+	default:
+		return nil, fmt.Errorf("service not found %s", name)
+	}
+}
@@ -0,0 +1,121 @@
+package positions
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/go-kit/log"
+)
+
+// Handoff hands positions over between the instances of a cluster through a
+// directory shared by every instance. The instance which stops reading an
+// entry releases its position, and the instance which takes the entry over
+// acquires it to continue from where the previous owner stopped.
+//
+// The instance reading an entry claims it, so that the next owner can wait for
+// the claim to be dropped by the release before it acquires the entry.
+//
+// Each entry is stored in its own positions file so that instances never
+// write to the same file concurrently.
+type Handoff struct {
+	logger log.Logger
+	dir    string
+}
+
+// NewHandoff creates a Handoff storing positions in dir.
+func NewHandoff(logger log.Logger, dir string) (*Handoff, error) {
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return nil, fmt.Errorf("failed to create handoff directory: %w", err)
+	}
+	return &Handoff{logger: logger, dir: dir}, nil
+}
+
+// Release stores the position of an entry which this instance stops reading,
+// and then drops the claim on the entry.
+func (h *Handoff) Release(entry Entry, pos string) error {
+	if err := writePositionFile(h.filename(entry), map[Entry]string{entry: pos}); err != nil {
+		return err
+	}
+	return removeIfExists(h.ownerFilename(entry))
+}
+
+// Claim records owner as the instance reading entry.
+func (h *Handoff) Claim(entry Entry, owner string) error {
+	// Write to a temporary file first so that Owner never reads a partial
+	// name.
+	filename := h.ownerFilename(entry)
+	tmp := filename + ".tmp"
+	if err := os.WriteFile(tmp, []byte(owner), positionFileMode); err != nil {
+		return err
+	}
+	return os.Rename(tmp, filename)
+}
+
+// Owner returns the instance which claimed entry, or an empty string if entry
+// isn't claimed.
+func (h *Handoff) Owner(entry Entry) (string, error) {
+	buf, err := os.ReadFile(h.ownerFilename(entry))
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	return string(buf), err
+}
+
+// Acquire moves the position released for entry by another instance into p.
+// The position already known by p is kept when it's further than the
+// released one, for example when this instance was the last one to read
+// entry before it restarted.
+func (h *Handoff) Acquire(p Positions, entry Entry) error {
+	released, err := readPositionsFile(Config{PositionsFile: h.filename(entry)}, h.logger)
+	if err != nil {
+		return err
+	}
+	pos, ok := released[entry]
+	if !ok {
+		return nil
+	}
+
+	releasedOffset, err := strconv.ParseInt(pos, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid released position %q: %w", pos, err)
+	}
+	if offset, err := p.Get(entry.Path, entry.Labels); err == nil && offset >= releasedOffset {
+		return nil
+	}
+	p.Put(entry.Path, entry.Labels, releasedOffset)
+	return nil
+}
+
+// Remove removes the position released for entry and the claim on entry, if
+// any.
+func (h *Handoff) Remove(entry Entry) error {
+	if err := removeIfExists(h.filename(entry)); err != nil {
+		return err
+	}
+	return removeIfExists(h.ownerFilename(entry))
+}
+
+func (h *Handoff) filename(entry Entry) string {
+	return filepath.Join(h.dir, entryHash(entry)+".yml")
+}
+
+func (h *Handoff) ownerFilename(entry Entry) string {
+	return filepath.Join(h.dir, entryHash(entry)+".owner")
+}
+
+func entryHash(entry Entry) string {
+	sum := sha256.Sum256([]byte(entry.Path + "\x00" + entry.Labels))
+	return hex.EncodeToString(sum[:])
+}
+
+func removeIfExists(filename string) error {
+	err := os.Remove(filename)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package positions
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandoff(t *testing.T) {
+	h, err := NewHandoff(log.NewNopLogger(), filepath.Join(t.TempDir(), "handoff"))
+	require.NoError(t, err)
+
+	p, err := New(log.NewNopLogger(), Config{
+		SyncPeriod:    time.Minute,
+		PositionsFile: filepath.Join(t.TempDir(), "positions.yml"),
+	})
+	require.NoError(t, err)
+	defer p.Stop()
+
+	entry := Entry{Path: "/var/log/app.log", Labels: `{job="app"}`}
+
+	// Nothing was released yet.
+	require.NoError(t, h.Acquire(p, entry))
+	pos, err := p.Get(entry.Path, entry.Labels)
+	require.NoError(t, err)
+	require.Equal(t, int64(0), pos)
+
+	require.NoError(t, h.Release(entry, "100"))
+	require.NoError(t, h.Acquire(p, entry))
+	pos, err = p.Get(entry.Path, entry.Labels)
+	require.NoError(t, err)
+	require.Equal(t, int64(100), pos)
+
+	// A position further than the released one is kept.
+	p.Put(entry.Path, entry.Labels, 200)
+	require.NoError(t, h.Acquire(p, entry))
+	pos, err = p.Get(entry.Path, entry.Labels)
+	require.NoError(t, err)
+	require.Equal(t, int64(200), pos)
+
+	// Releasing drops the claim of the previous owner.
+	owner, err := h.Owner(entry)
+	require.NoError(t, err)
+	require.Empty(t, owner)
+	require.NoError(t, h.Claim(entry, "a"))
+	owner, err = h.Owner(entry)
+	require.NoError(t, err)
+	require.Equal(t, "a", owner)
+	require.NoError(t, h.Release(entry, "300"))
+	owner, err = h.Owner(entry)
+	require.NoError(t, err)
+	require.Empty(t, owner)
+
+	require.NoError(t, h.Claim(entry, "b"))
+	require.NoError(t, h.Remove(entry))
+	require.NoError(t, h.Remove(entry))
+	entries, err := os.ReadDir(h.dir)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/grafana/alloy/internal/component"
 	"github.com/grafana/alloy/internal/component/common/loki"
 	alloy_relabel "github.com/grafana/alloy/internal/component/common/relabel"
+	"github.com/grafana/alloy/internal/component/loki/source"
 	"github.com/grafana/alloy/internal/component/loki/source/internal/positions"
 	"github.com/grafana/alloy/internal/component/loki/source/journal/internal/target"
 	"github.com/grafana/alloy/internal/featuregate"
@@ -34,7 +35,10 @@ func init() {
 	})
 }
 
-var _ component.Component = (*Component)(nil)
+var (
+	_ component.Component     = (*Component)(nil)
+	_ component.LiveDebugging = (*Component)(nil)
+)
 
 // Component represents reading from a journal
 type Component struct {
@@ -47,6 +51,7 @@ type Component struct {
 	targetsUpdated chan struct{}
 	args           Arguments
 	healthErr      error
+	debugPublisher *source.DebugPublisher
 }
 
 // New creates a new  component.
@@ -71,6 +76,11 @@ func New(o component.Options, args Arguments) (*Component, error) {
 		return nil, err
 	}
 
+	debugPublisher, err := source.NewDebugPublisher(o)
+	if err != nil {
+		return nil, err
+	}
+
 	c := &Component{
 		metrics:        target.NewMetrics(o.Registerer),
 		o:              o,
@@ -78,6 +88,7 @@ func New(o component.Options, args Arguments) (*Component, error) {
 		positions:      positionsFile,
 		targetsUpdated: make(chan struct{}, 1),
 		args:           args,
+		debugPublisher: debugPublisher,
 	}
 	err = c.Update(args)
 	return c, err
@@ -122,6 +133,7 @@ func (c *Component) Run(ctx context.Context) error {
 				Labels: entry.Labels,
 				Entry:  entry.Entry,
 			}
+			c.debugPublisher.Publish(lokiEntry)
 			for _, r := range c.args.Receivers {
 				select {
 				case <-ctx.Done():
@@ -147,6 +159,9 @@ func (c *Component) Update(args component.Arguments) error {
 	return nil
 }
 
+// LiveDebugging implements component.LiveDebugging.
+func (c *Component) LiveDebugging() {}
+
 // CurrentHealth implements component.HealthComponent. It returns an unhealthy
 // status if the server has terminated.
 func (c *Component) CurrentHealth() component.Health {
@@ -182,6 +197,7 @@ func (c *Component) startDrainingRoutine(parentCtx context.Context) func() {
 					Labels: entry.Labels,
 					Entry:  entry.Entry,
 				}
+				c.debugPublisher.Publish(lokiEntry)
 				for _, r := range receiversCopy {
 					r.Chan() <- lokiEntry
 				}
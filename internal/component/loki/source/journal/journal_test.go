@@ -4,6 +4,7 @@ package journal
 
 import (
 	"context"
+	"fmt"
 	"strings"
 	"testing"
 	"time"
@@ -11,6 +12,7 @@ import (
 	"github.com/coreos/go-systemd/v22/journal"
 	"github.com/grafana/alloy/internal/component"
 	"github.com/grafana/alloy/internal/component/common/loki"
+	"github.com/grafana/alloy/internal/service/livedebugging"
 	"github.com/grafana/alloy/internal/util"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/require"
@@ -21,10 +23,11 @@ func TestJournal(t *testing.T) {
 	tmp := t.TempDir()
 	lr := loki.NewLogsReceiver()
 	c, err := New(component.Options{
-		ID:         "loki.source.journal.test",
-		Logger:     util.TestAlloyLogger(t),
-		DataPath:   tmp,
-		Registerer: prometheus.DefaultRegisterer,
+		ID:             "loki.source.journal.test",
+		Logger:         util.TestAlloyLogger(t),
+		DataPath:       tmp,
+		Registerer:     prometheus.DefaultRegisterer,
+		GetServiceData: getServiceData,
 	}, Arguments{
 		FormatAsJson: false,
 		MaxAge:       7 * time.Hour,
@@ -55,3 +58,12 @@ func TestJournal(t *testing.T) {
 	}
 	require.True(t, found)
 }
+
+func getServiceData(name string) (interface{}, error) {
+	switch name {
+	case livedebugging.ServiceName:
+		return livedebugging.NewLiveDebugging(), nil
+	default:
+		return nil, fmt.Errorf("service not found %s", name)
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/grafana/alloy/internal/component/common/config"
 	"github.com/grafana/alloy/internal/component/common/loki"
 	alloy_relabel "github.com/grafana/alloy/internal/component/common/relabel"
+	"github.com/grafana/alloy/internal/component/loki/source"
 	kt "github.com/grafana/alloy/internal/component/loki/source/internal/kafkatarget"
 	"github.com/grafana/alloy/internal/featuregate"
 	"github.com/grafana/alloy/internal/runtime/logging/level"
@@ -92,21 +93,28 @@ func (a *Arguments) SetToDefault() {
 type Component struct {
 	opts component.Options
 
-	mut    sync.RWMutex
-	fanout []loki.LogsReceiver
-	target *kt.TargetSyncer
+	mut            sync.RWMutex
+	fanout         []loki.LogsReceiver
+	debugPublisher *source.DebugPublisher
+	target         *kt.TargetSyncer
 
 	handler loki.LogsReceiver
 }
 
 // New creates a new loki.source.kafka component.
 func New(o component.Options, args Arguments) (*Component, error) {
+	debugPublisher, err := source.NewDebugPublisher(o)
+	if err != nil {
+		return nil, err
+	}
+
 	c := &Component{
-		opts:    o,
-		mut:     sync.RWMutex{},
-		fanout:  args.ForwardTo,
-		target:  nil,
-		handler: loki.NewLogsReceiver(),
+		opts:           o,
+		mut:            sync.RWMutex{},
+		fanout:         args.ForwardTo,
+		debugPublisher: debugPublisher,
+		target:         nil,
+		handler:        loki.NewLogsReceiver(),
 	}
 
 	// Call to Update() to start readers and set receivers once at the start.
@@ -137,6 +145,7 @@ func (c *Component) Run(ctx context.Context) error {
 		case <-ctx.Done():
 			return nil
 		case entry := <-c.handler.Chan():
+			c.debugPublisher.Publish(entry)
 			c.mut.RLock()
 			for _, receiver := range c.fanout {
 				receiver.Chan() <- entry
@@ -173,6 +182,9 @@ func (c *Component) Update(args component.Arguments) error {
 	return nil
 }
 
+// LiveDebugging implements component.LiveDebugging.
+func (c *Component) LiveDebugging() {}
+
 // Convert is used to bridge between the Alloy and Promtail types.
 func (args *Arguments) Convert() kt.Config {
 	lbls := make(model.LabelSet, len(args.Labels))
@@ -17,6 +17,7 @@ import (
 	commonk8s "github.com/grafana/alloy/internal/component/common/kubernetes"
 	"github.com/grafana/alloy/internal/component/common/loki"
 	"github.com/grafana/alloy/internal/component/discovery"
+	"github.com/grafana/alloy/internal/component/loki/source"
 	"github.com/grafana/alloy/internal/component/loki/source/internal/positions"
 	"github.com/grafana/alloy/internal/component/loki/source/kubernetes/kubetail"
 	"github.com/grafana/alloy/internal/featuregate"
@@ -74,11 +75,14 @@ type Component struct {
 
 	receiversMut sync.RWMutex
 	receivers    []loki.LogsReceiver
+
+	debugPublisher *source.DebugPublisher
 }
 
 var (
 	_ component.Component      = (*Component)(nil)
 	_ component.DebugComponent = (*Component)(nil)
+	_ component.LiveDebugging  = (*Component)(nil)
 	_ cluster.Component        = (*Component)(nil)
 )
 
@@ -102,12 +106,18 @@ func New(o component.Options, args Arguments) (*Component, error) {
 		return nil, err
 	}
 
+	debugPublisher, err := source.NewDebugPublisher(o)
+	if err != nil {
+		return nil, err
+	}
+
 	c := &Component{
-		cluster:   data.(cluster.Cluster),
-		log:       o.Logger,
-		opts:      o,
-		handler:   loki.NewLogsReceiver(),
-		positions: positionsFile,
+		cluster:        data.(cluster.Cluster),
+		log:            o.Logger,
+		opts:           o,
+		handler:        loki.NewLogsReceiver(),
+		positions:      positionsFile,
+		debugPublisher: debugPublisher,
 	}
 	if err := c.Update(args); err != nil {
 		return nil, err
@@ -135,6 +145,7 @@ func (c *Component) Run(ctx context.Context) error {
 		case <-ctx.Done():
 			return nil
 		case entry := <-c.handler.Chan():
+			c.debugPublisher.Publish(entry)
 			c.receiversMut.RLock()
 			receivers := c.receivers
 			c.receiversMut.RUnlock()
@@ -187,8 +198,12 @@ func (c *Component) Update(args component.Arguments) error {
 	return nil
 }
 
+// LiveDebugging implements component.LiveDebugging.
+func (c *Component) LiveDebugging() {}
+
 func (c *Component) resyncTargets(targets []discovery.Target) {
 	distTargets := discovery.NewDistributedTargetsWithCustomLabels(c.args.Clustering.Enabled, c.cluster, targets, kubetail.ClusteringLabels)
+	distTargets.ReportLoad(c.opts.ID)
 	targets = distTargets.LocalTargets()
 
 	tailTargets := make([]*kubetail.Target, 0, len(targets))
@@ -89,12 +89,14 @@ type Component struct {
 	restConfig *rest.Config
 	scheduler  *source.Scheduler[string]
 
-	fanout *loki.Fanout
+	fanout         *loki.Fanout
+	debugPublisher *source.DebugPublisher
 }
 
 var (
 	_ component.Component      = (*Component)(nil)
 	_ component.DebugComponent = (*Component)(nil)
+	_ component.LiveDebugging  = (*Component)(nil)
 )
 
 // New creates a new loki.source.kubernetes_events component.
@@ -111,13 +113,19 @@ func New(o component.Options, args Arguments) (*Component, error) {
 		return nil, err
 	}
 
+	debugPublisher, err := source.NewDebugPublisher(o)
+	if err != nil {
+		return nil, err
+	}
+
 	c := &Component{
-		log:       o.Logger,
-		opts:      o,
-		positions: positionsFile,
-		handler:   loki.NewLogsReceiver(),
-		scheduler: source.NewScheduler[string](),
-		fanout:    loki.NewFanout(args.ForwardTo),
+		log:            o.Logger,
+		opts:           o,
+		positions:      positionsFile,
+		handler:        loki.NewLogsReceiver(),
+		scheduler:      source.NewScheduler[string](),
+		fanout:         loki.NewFanout(args.ForwardTo),
+		debugPublisher: debugPublisher,
 	}
 	if err := c.Update(args); err != nil {
 		return nil, err
@@ -136,7 +144,7 @@ func (c *Component) Run(ctx context.Context) error {
 		})
 	}()
 
-	source.Consume(ctx, c.handler, c.fanout)
+	source.Consume(ctx, c.handler, c.fanout, c.debugPublisher)
 	return nil
 }
 
@@ -186,6 +194,9 @@ func (c *Component) Update(args component.Arguments) error {
 	return nil
 }
 
+// LiveDebugging implements component.LiveDebugging.
+func (c *Component) LiveDebugging() {}
+
 // getNamespaces returns a iterator of namespaces to watch from the arguments. If the
 // list of namespaces is empty, returns a iterator to watch all namespaces.
 func getNamespaces(args Arguments) iter.Seq[string] {
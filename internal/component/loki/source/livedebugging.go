@@ -0,0 +1,73 @@
+package source
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/grafana/alloy/internal/component"
+	"github.com/grafana/alloy/internal/component/common/loki"
+	"github.com/grafana/alloy/internal/service/livedebugging"
+)
+
+// DebugPublisher publishes the log entries forwarded by a source component
+// to the live debugging service.
+//
+// A nil DebugPublisher doesn't publish anything.
+type DebugPublisher struct {
+	componentID livedebugging.ComponentID
+	publisher   livedebugging.DebugDataPublisher
+}
+
+// NewDebugPublisher creates a DebugPublisher for the component created with
+// opts.
+func NewDebugPublisher(opts component.Options) (*DebugPublisher, error) {
+	publisher, err := opts.GetServiceData(livedebugging.ServiceName)
+	if err != nil {
+		return nil, err
+	}
+	return &DebugPublisher{
+		componentID: livedebugging.ComponentID(opts.ID),
+		publisher:   publisher.(livedebugging.DebugDataPublisher),
+	}, nil
+}
+
+// Publish publishes a single entry.
+func (p *DebugPublisher) Publish(entry loki.Entry) {
+	if p == nil {
+		return
+	}
+	p.publisher.PublishIfActive(livedebugging.NewData(
+		p.componentID,
+		livedebugging.LokiLog,
+		1,
+		func() string {
+			return formatEntry(entry)
+		},
+	))
+}
+
+// PublishBatch publishes a batch of entries.
+func (p *DebugPublisher) PublishBatch(batch []loki.Entry) {
+	if p == nil || len(batch) == 0 {
+		return
+	}
+	p.publisher.PublishIfActive(livedebugging.NewData(
+		p.componentID,
+		livedebugging.LokiLog,
+		uint64(len(batch)),
+		func() string {
+			var sb strings.Builder
+			for i, entry := range batch {
+				if i > 0 {
+					sb.WriteString("\n")
+				}
+				sb.WriteString(formatEntry(entry))
+			}
+			return sb.String()
+		},
+	))
+}
+
+func formatEntry(entry loki.Entry) string {
+	return fmt.Sprintf("entry: %s, labels: %s", entry.Line, entry.Labels.String())
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/grafana/alloy/internal/component/common/config"
 	commonk8s "github.com/grafana/alloy/internal/component/common/kubernetes"
 	"github.com/grafana/alloy/internal/component/common/loki"
+	"github.com/grafana/alloy/internal/component/loki/source"
 	"github.com/grafana/alloy/internal/component/loki/source/internal/positions"
 	"github.com/grafana/alloy/internal/component/loki/source/kubernetes"
 	"github.com/grafana/alloy/internal/component/loki/source/kubernetes/kubetail"
@@ -100,11 +101,14 @@ type Component struct {
 
 	receiversMut sync.RWMutex
 	receivers    []loki.LogsReceiver
+
+	debugPublisher *source.DebugPublisher
 }
 
 var (
 	_ component.Component      = (*Component)(nil)
 	_ component.DebugComponent = (*Component)(nil)
+	_ component.LiveDebugging  = (*Component)(nil)
 	_ cluster.Component        = (*Component)(nil)
 )
 
@@ -133,6 +137,11 @@ func New(o component.Options, args Arguments) (*Component, error) {
 		controller = newController(o.Logger, reconciler)
 	)
 
+	debugPublisher, err := source.NewDebugPublisher(o)
+	if err != nil {
+		return nil, err
+	}
+
 	c := &Component{
 		log:  o.Logger,
 		opts: o,
@@ -141,8 +150,9 @@ func New(o component.Options, args Arguments) (*Component, error) {
 		reconciler: reconciler,
 		controller: controller,
 
-		positions: positionsFile,
-		handler:   loki.NewLogsReceiver(),
+		positions:      positionsFile,
+		handler:        loki.NewLogsReceiver(),
+		debugPublisher: debugPublisher,
 	}
 	if err := c.Update(args); err != nil {
 		return nil, err
@@ -196,6 +206,7 @@ func (c *Component) runHandler(ctx context.Context) {
 		case <-ctx.Done():
 			return
 		case entry := <-c.handler.Chan():
+			c.debugPublisher.Publish(entry)
 			c.receiversMut.RLock()
 			receivers := c.receivers
 			c.receiversMut.RUnlock()
@@ -233,6 +244,9 @@ func (c *Component) Update(args component.Arguments) error {
 	return nil
 }
 
+// LiveDebugging implements component.LiveDebugging.
+func (c *Component) LiveDebugging() {}
+
 // NotifyClusterChange implements cluster.Component.
 func (c *Component) NotifyClusterChange() {
 	c.mut.Lock()
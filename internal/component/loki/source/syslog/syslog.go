@@ -12,6 +12,7 @@ import (
 	"github.com/grafana/alloy/internal/component"
 	"github.com/grafana/alloy/internal/component/common/loki"
 	alloy_relabel "github.com/grafana/alloy/internal/component/common/relabel"
+	"github.com/grafana/alloy/internal/component/loki/source"
 	scrapeconfig "github.com/grafana/alloy/internal/component/loki/source/syslog/config"
 	st "github.com/grafana/alloy/internal/component/loki/source/syslog/internal/syslogtarget"
 	"github.com/grafana/alloy/internal/featuregate"
@@ -43,10 +44,11 @@ type Component struct {
 	opts    component.Options
 	metrics *st.Metrics
 
-	mut     sync.RWMutex
-	args    Arguments
-	fanout  []loki.LogsReceiver
-	targets []*st.SyslogTarget
+	mut            sync.RWMutex
+	args           Arguments
+	fanout         []loki.LogsReceiver
+	debugPublisher *source.DebugPublisher
+	targets        []*st.SyslogTarget
 
 	targetsUpdated chan struct{}
 	handler        loki.LogsReceiver
@@ -54,11 +56,17 @@ type Component struct {
 
 // New creates a new loki.source.syslog component.
 func New(o component.Options, args Arguments) (*Component, error) {
+	debugPublisher, err := source.NewDebugPublisher(o)
+	if err != nil {
+		return nil, err
+	}
+
 	c := &Component{
 		opts:           o,
 		metrics:        st.NewMetrics(o.Registerer),
 		handler:        loki.NewLogsReceiver(),
 		fanout:         args.ForwardTo,
+		debugPublisher: debugPublisher,
 		targetsUpdated: make(chan struct{}, 1),
 		targets:        []*st.SyslogTarget{},
 	}
@@ -97,6 +105,7 @@ func (c *Component) Run(ctx context.Context) error {
 		case <-ctx.Done():
 			return nil
 		case entry := <-c.handler.Chan():
+			c.debugPublisher.Publish(entry)
 			c.mut.RLock()
 			for _, receiver := range c.fanout {
 				receiver.Chan() <- entry
@@ -132,6 +141,9 @@ func (c *Component) Update(args component.Arguments) error {
 	return nil
 }
 
+// LiveDebugging implements component.LiveDebugging.
+func (c *Component) LiveDebugging() {}
+
 func (c *Component) checkExperimentalFeatures(args Arguments) error {
 	isExperimental := c.opts.MinStability.Permits(featuregate.StabilityExperimental)
 	if isExperimental {
@@ -163,6 +175,7 @@ func (c *Component) startDrainingRoutine() func() {
 			case <-readCtx.Done():
 				return
 			case entry := <-c.handler.Chan():
+				c.debugPublisher.Publish(entry)
 				for _, receiver := range fanoutCopy {
 					receiver.Chan() <- entry
 				}
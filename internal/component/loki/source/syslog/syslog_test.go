@@ -22,14 +22,16 @@ import (
 	alloy_relabel "github.com/grafana/alloy/internal/component/common/relabel"
 	"github.com/grafana/alloy/internal/component/loki/source/syslog/internal/syslogtarget"
 	"github.com/grafana/alloy/internal/runtime/componenttest"
+	"github.com/grafana/alloy/internal/service/livedebugging"
 	"github.com/grafana/alloy/internal/util"
 )
 
 func Test(t *testing.T) {
 	opts := component.Options{
-		Logger:        util.TestAlloyLogger(t),
-		Registerer:    prometheus.NewRegistry(),
-		OnStateChange: func(e component.Exports) {},
+		Logger:         util.TestAlloyLogger(t),
+		Registerer:     prometheus.NewRegistry(),
+		OnStateChange:  func(e component.Exports) {},
+		GetServiceData: getServiceData,
 	}
 
 	ch1, ch2 := loki.NewLogsReceiver(), loki.NewLogsReceiver()
@@ -110,9 +112,10 @@ func Test(t *testing.T) {
 
 func TestWithRelabelRules(t *testing.T) {
 	opts := component.Options{
-		Logger:        util.TestAlloyLogger(t),
-		Registerer:    prometheus.NewRegistry(),
-		OnStateChange: func(e component.Exports) {},
+		Logger:         util.TestAlloyLogger(t),
+		Registerer:     prometheus.NewRegistry(),
+		OnStateChange:  func(e component.Exports) {},
+		GetServiceData: getServiceData,
 	}
 
 	ch1 := loki.NewLogsReceiver()
@@ -202,9 +205,10 @@ func mustNewRegexp(s string) alloy_relabel.Regexp {
 
 func TestShutdownAndRebindOnSamePort(t *testing.T) {
 	opts := component.Options{
-		Logger:        util.TestAlloyLogger(t),
-		Registerer:    prometheus.NewRegistry(),
-		OnStateChange: func(e component.Exports) {},
+		Logger:         util.TestAlloyLogger(t),
+		Registerer:     prometheus.NewRegistry(),
+		OnStateChange:  func(e component.Exports) {},
+		GetServiceData: getServiceData,
 	}
 
 	addr := componenttest.GetFreeAddr(t)
@@ -308,10 +312,11 @@ func TestExperimentalFeaturesStabilityLevel(t *testing.T) {
 	for _, tc := range cases {
 		t.Run(tc.label, func(t *testing.T) {
 			opts := component.Options{
-				Logger:        util.TestAlloyLogger(t),
-				Registerer:    prometheus.NewRegistry(),
-				OnStateChange: func(e component.Exports) {},
-				MinStability:  featuregate.StabilityGenerallyAvailable,
+				Logger:         util.TestAlloyLogger(t),
+				Registerer:     prometheus.NewRegistry(),
+				OnStateChange:  func(e component.Exports) {},
+				MinStability:   featuregate.StabilityGenerallyAvailable,
+				GetServiceData: getServiceData,
 			}
 
 			lc := DefaultListenerConfig
@@ -337,3 +342,12 @@ func TestExperimentalFeaturesStabilityLevel(t *testing.T) {
 		})
 	}
 }
+
+func getServiceData(name string) (interface{}, error) {
+	switch name {
+	case livedebugging.ServiceName:
+		return livedebugging.NewLiveDebugging(), nil
+	default:
+		return nil, fmt.Errorf("service not found %s", name)
+	}
+}
@@ -4,6 +4,7 @@ package windowsevent
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -18,6 +19,7 @@ import (
 
 	"github.com/grafana/alloy/internal/component"
 	"github.com/grafana/alloy/internal/component/common/loki"
+	"github.com/grafana/alloy/internal/service/livedebugging"
 	"github.com/grafana/alloy/internal/util"
 )
 
@@ -37,8 +39,9 @@ func TestEventLogger(t *testing.T) {
 		OnStateChange: func(e component.Exports) {
 
 		},
-		Registerer: prometheus.DefaultRegisterer,
-		Tracer:     nil,
+		Registerer:     prometheus.DefaultRegisterer,
+		Tracer:         nil,
+		GetServiceData: getServiceData,
 	}, Arguments{
 		Locale:               0,
 		EventLogName:         "Application",
@@ -95,8 +98,9 @@ func TestLegacyBookmarkConversion(t *testing.T) {
 		OnStateChange: func(e component.Exports) {
 
 		},
-		Registerer: prometheus.DefaultRegisterer,
-		Tracer:     nil,
+		Registerer:     prometheus.DefaultRegisterer,
+		Tracer:         nil,
+		GetServiceData: getServiceData,
 	}, Arguments{
 		Locale:               0,
 		EventLogName:         "Application",
@@ -122,3 +126,12 @@ func TestLegacyBookmarkConversion(t *testing.T) {
 	go c.Run(ctx)
 	cancelFunc()
 }
+
+func getServiceData(name string) (interface{}, error) {
+	switch name {
+	case livedebugging.ServiceName:
+		return livedebugging.NewLiveDebugging(), nil
+	default:
+		return nil, fmt.Errorf("service not found %s", name)
+	}
+}
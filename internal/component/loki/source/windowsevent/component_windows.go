@@ -11,6 +11,7 @@ import (
 
 	"github.com/grafana/alloy/internal/component"
 	"github.com/grafana/alloy/internal/component/common/loki"
+	"github.com/grafana/alloy/internal/component/loki/source"
 	"github.com/grafana/alloy/internal/featuregate"
 	"github.com/grafana/alloy/internal/loki/promtail/scrapeconfig"
 	"github.com/grafana/alloy/internal/loki/util"
@@ -29,7 +30,8 @@ func init() {
 }
 
 var (
-	_ component.Component = (*Component)(nil)
+	_ component.Component     = (*Component)(nil)
+	_ component.LiveDebugging = (*Component)(nil)
 )
 
 // Component implements the loki.source.windowsevent component.
@@ -41,6 +43,8 @@ type Component struct {
 	target    *Target
 	handle    *handler
 	receivers []loki.LogsReceiver
+
+	debugPublisher *source.DebugPublisher
 }
 
 type handler struct {
@@ -57,12 +61,17 @@ func (h *handler) Stop() {
 
 // New creates a new loki.source.windowsevent component.
 func New(o component.Options, args Arguments) (*Component, error) {
+	debugPublisher, err := source.NewDebugPublisher(o)
+	if err != nil {
+		return nil, err
+	}
 
 	c := &Component{
-		opts:      o,
-		receivers: args.ForwardTo,
-		handle:    &handler{handler: make(chan loki.Entry)},
-		args:      args,
+		opts:           o,
+		receivers:      args.ForwardTo,
+		handle:         &handler{handler: make(chan loki.Entry)},
+		args:           args,
+		debugPublisher: debugPublisher,
 	}
 
 	// Call to Update() to start readers and set receivers once at the start.
@@ -91,6 +100,7 @@ func (c *Component) Run(ctx context.Context) error {
 				Labels: entry.Labels,
 				Entry:  entry.Entry,
 			}
+			c.debugPublisher.Publish(lokiEntry)
 			for _, receiver := range c.receivers {
 				receiver.Chan() <- lokiEntry
 			}
@@ -138,6 +148,9 @@ func (c *Component) Update(args component.Arguments) error {
 	return nil
 }
 
+// LiveDebugging implements component.LiveDebugging.
+func (c *Component) LiveDebugging() {}
+
 // createBookmark will create bookmark for saving the positions file.
 // If LegacyBookMark is specified and the BookmarkPath doesnt exist it will copy over the legacy bookmark to the new path.
 func createBookmark(args Arguments) error {
@@ -14,6 +14,7 @@ import (
 	"github.com/grafana/alloy/internal/component/common/loki/wal"
 	"github.com/grafana/alloy/internal/featuregate"
 	"github.com/grafana/alloy/internal/loki/util"
+	"github.com/grafana/alloy/internal/service/livedebugging"
 	"github.com/prometheus/common/model"
 )
 
@@ -74,7 +75,8 @@ type Exports struct {
 }
 
 var (
-	_ component.Component = (*Component)(nil)
+	_ component.Component     = (*Component)(nil)
+	_ component.LiveDebugging = (*Component)(nil)
 )
 
 // Component implements the loki.write component.
@@ -91,12 +93,20 @@ type Component struct {
 	// sink is the place where log entries received by this component should be written to.
 	// It will in turn write to client.Consumer.
 	sink loki.EntryHandler
+
+	debugDataPublisher livedebugging.DebugDataPublisher
 }
 
 // New creates a new loki.write component.
 func New(o component.Options, args Arguments) (*Component, error) {
+	debugDataPublisher, err := o.GetServiceData(livedebugging.ServiceName)
+	if err != nil {
+		return nil, err
+	}
+
 	c := &Component{
-		opts: o,
+		opts:               o,
+		debugDataPublisher: debugDataPublisher.(livedebugging.DebugDataPublisher),
 	}
 
 	// Create and immediately export the receiver which remains the same for
@@ -135,6 +145,15 @@ func (c *Component) Run(ctx context.Context) error {
 		case <-ctx.Done():
 			return nil
 		case entry := <-c.receiver.Chan():
+			c.debugDataPublisher.PublishIfActive(livedebugging.NewData(
+				livedebugging.ComponentID(c.opts.ID),
+				livedebugging.LokiLog,
+				1,
+				func() string {
+					return fmt.Sprintf("entry: %s, labels: %s", entry.Line, entry.Labels.String())
+				},
+			))
+
 			c.mut.RLock()
 			select {
 			case <-ctx.Done():
@@ -174,6 +193,7 @@ func (c *Component) Update(args component.Arguments) error {
 		}
 		cfgs[i].Headers[alloyseed.LegacyHeaderName] = uid
 		cfgs[i].Headers[alloyseed.HeaderName] = uid
+		cfgs[i].OnSendOutcome = c.publishSendOutcome
 	}
 	walCfg := wal.Config{
 		Enabled:       newArgs.WAL.Enabled,
@@ -202,6 +222,32 @@ func (c *Component) Update(args component.Arguments) error {
 	return nil
 }
 
+// publishSendOutcome publishes the outcome of sending a batch to Loki.
+func (c *Component) publishSendOutcome(o client.SendOutcome) {
+	endpoint := o.Host
+	if o.TenantID != "" {
+		endpoint = fmt.Sprintf("%s (tenant %s)", o.Host, o.TenantID)
+	}
+	result := livedebugging.OutcomeSent
+	if o.Dropped {
+		result = livedebugging.OutcomeDropped
+	}
+	c.debugDataPublisher.PublishIfActive(livedebugging.NewOutcomeData(
+		livedebugging.ComponentID(c.opts.ID),
+		uint64(o.Entries),
+		livedebugging.Outcome{
+			Result:     result,
+			Endpoint:   endpoint,
+			StatusCode: o.StatusCode,
+			Retries:    o.Retries,
+			Err:        o.Err,
+		},
+	))
+}
+
+// LiveDebugging implements component.LiveDebugging.
+func (c *Component) LiveDebugging() {}
+
 func newEntryHandler(handler loki.EntryHandler, externalLabels model.LabelSet) loki.EntryHandler {
 	return loki.NewEntryMutatorHandler(handler, func(e loki.Entry) loki.Entry {
 		if len(externalLabels) == 0 {
@@ -1,6 +1,7 @@
 package write
 
 import (
+	"context"
 	"fmt"
 	"math"
 	"net/http"
@@ -10,17 +11,21 @@ import (
 	"time"
 
 	"github.com/grafana/loki/pkg/push"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/common/model"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/atomic"
 
+	"github.com/grafana/alloy/internal/component"
 	"github.com/grafana/alloy/internal/component/common/loki"
 	"github.com/grafana/alloy/internal/component/common/loki/wal"
 	"github.com/grafana/alloy/internal/component/discovery"
 	lsf "github.com/grafana/alloy/internal/component/loki/source/file"
 	loki_util "github.com/grafana/alloy/internal/loki/util"
 	"github.com/grafana/alloy/internal/runtime/componenttest"
+	"github.com/grafana/alloy/internal/service/livedebugging"
 	"github.com/grafana/alloy/internal/util"
+	"github.com/grafana/alloy/internal/util/testlivedebugging"
 	"github.com/grafana/alloy/syntax"
 )
 
@@ -205,6 +210,77 @@ LOOP:
 	require.Equal(t, entries[1].Line, logEntry.Entry.Line)
 }
 
+func TestLiveDebugging(t *testing.T) {
+	// The server rejects every request with a status code which isn't retried.
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	var args Arguments
+	require.NoError(t, syntax.Unmarshal([]byte(fmt.Sprintf(`
+		endpoint {
+			url        = "%s"
+			batch_wait = "10ms"
+		}
+	`, srv.URL)), &args))
+
+	liveDebuggingLog := testlivedebugging.NewLog()
+	c, err := New(component.Options{
+		ID:             "loki.write.test",
+		Logger:         util.TestAlloyLogger(t),
+		Registerer:     prometheus.NewRegistry(),
+		OnStateChange:  func(e component.Exports) {},
+		DataPath:       t.TempDir(),
+		GetServiceData: getServiceDataWithLiveDebugging(liveDebuggingLog, "loki.write.test"),
+	}, args)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+	go c.Run(ctx)
+
+	c.receiver.Chan() <- loki.Entry{
+		Labels: model.LabelSet{"foo": "bar"},
+		Entry:  push.Entry{Timestamp: time.Now(), Line: "very important log"},
+	}
+
+	require.Eventually(t, func() bool {
+		return len(liveDebuggingLog.Get()) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	logs := liveDebuggingLog.Get()
+	require.Equal(t, `entry: very important log, labels: {foo="bar"}`, logs[0])
+	require.Contains(t, logs[1], "outcome=dropped")
+	require.Contains(t, logs[1], "status=400")
+	require.Contains(t, logs[1], "retries=0")
+}
+
+func getServiceDataWithLiveDebugging(log *testlivedebugging.Log, componentID string) func(string) (interface{}, error) {
+	ld := livedebugging.NewLiveDebugging()
+	host := &testlivedebugging.FakeServiceHost{
+		ComponentsInfo: map[component.ID]testlivedebugging.FakeInfo{
+			component.ParseID(componentID): {ComponentName: "loki.write", Component: &testlivedebugging.FakeComponentLiveDebugging{}},
+		},
+	}
+	ld.SetEnabled(true)
+	ld.AddCallback(
+		host,
+		"callback1",
+		livedebugging.ComponentID(componentID),
+		func(data livedebugging.Data) { log.Append(data.DataFunc()) },
+	)
+
+	return func(name string) (interface{}, error) {
+		switch name {
+		case livedebugging.ServiceName:
+			return ld, nil
+		default:
+			return nil, fmt.Errorf("service not found %s", name)
+		}
+	}
+}
+
 func TestEntrySentToTwoWriteComponents(t *testing.T) {
 	t.Run("wal disabled", func(t *testing.T) {
 		testMultipleEndpoint(t, func(arguments *Arguments) {})
@@ -171,7 +171,9 @@ func hasFieldOfType(obj interface{}, fieldType reflect.Type) bool {
 			return true
 		}
 
-		if fv.Kind() == reflect.Interface && fieldType.AssignableTo(ft) {
+		// Fields of an empty interface type, such as any, can hold values of
+		// every type and don't export a type of their own.
+		if fv.Kind() == reflect.Interface && ft.NumMethod() > 0 && fieldType.AssignableTo(ft) {
 			return true
 		}
 
@@ -67,6 +67,10 @@ func Test_inferMetadata(t *testing.T) {
 				exports: []Type{TypeOTELReceiver},
 			},
 		},
+		{
+			name:     "remote.http",
+			expected: Metadata{},
+		},
 		{
 			name: "faro.receiver",
 			expected: Metadata{
@@ -9,7 +9,10 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	otelcomponent "go.opentelemetry.io/collector/component"
 	otelconnector "go.opentelemetry.io/collector/connector"
+	otelconsumer "go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/pipeline"
 	sdkprometheus "go.opentelemetry.io/otel/exporters/prometheus"
 	"go.opentelemetry.io/otel/sdk/metric"
@@ -28,8 +31,10 @@ import (
 	"github.com/grafana/alloy/internal/util/zapadapter"
 )
 
+// The connector types are bit flags, so that connectors which support several
+// signals can combine them.
 const (
-	ConnectorTracesToTraces = iota + 1
+	ConnectorTracesToTraces = 1 << iota
 	ConnectorTracesToMetrics
 	ConnectorTracesToLogs
 	ConnectorMetricsToTraces
@@ -66,6 +71,18 @@ type Arguments interface {
 	DebugMetricsConfig() otelcolCfg.DebugMetricsArguments
 }
 
+// RouterArguments is implemented by Arguments of connectors which send data to
+// one of several pipelines, rather than to every consumer in NextConsumers.
+// The upstream connector receives a router over the pipelines returned by
+// Pipelines for each output signal.
+type RouterArguments interface {
+	Arguments
+
+	// Pipelines returns the consumers of each pipeline the connector may send
+	// data to. Consumers are only used for the signal of their pipeline ID.
+	Pipelines() map[pipeline.ID][]otelcol.Consumer
+}
+
 // Connector is an Alloy component shim which manages an OpenTelemetry
 // Collector connector component.
 type Connector struct {
@@ -203,25 +220,33 @@ func (p *Connector) Update(args component.Arguments) error {
 	}
 
 	if !outputsToLogs(connectorType) && len(next.Logs) > 0 {
-		return errors.New("logs output is not supported yet")
+		return errors.New("this connector cannot output logs")
 	}
 
 	if !outputsToTraces(connectorType) && len(next.Traces) > 0 {
-		return errors.New("traces output is not supported yet")
+		return errors.New("this connector cannot output traces")
+	}
+
+	if len(next.Traces) > 0 {
+		nextTraces := p.tracesConsumer(next.Traces)
+
+		// Create traces to traces connector if supported
+		if (connectorType & ConnectorTracesToTraces) != 0 {
+			tracesConnector, err = p.factory.CreateTracesToTraces(p.ctx, settings, connectorConfig, nextTraces)
+			if err != nil && !errors.Is(err, pipeline.ErrSignalNotSupported) {
+				return err
+			} else if tracesConnector != nil {
+				components = append(components, tracesConnector)
+			}
+		}
 	}
 
 	if len(next.Metrics) > 0 {
-		fanout := fanoutconsumer.Metrics(next.Metrics)
-		metricsInterceptor := interceptconsumer.Metrics(fanout,
-			func(ctx context.Context, md pmetric.Metrics) error {
-				livedebuggingpublisher.PublishMetricsIfActive(p.debugDataPublisher, p.opts.ID, md, otelcol.GetComponentMetadata(next.Metrics))
-				return fanout.ConsumeMetrics(ctx, md)
-			},
-		)
+		nextMetrics := p.metricsConsumer(next.Metrics)
 
 		// Create traces to metrics connector if supported
 		if (connectorType & ConnectorTracesToMetrics) != 0 {
-			tracesConnector, err = p.factory.CreateTracesToMetrics(p.ctx, settings, connectorConfig, metricsInterceptor)
+			tracesConnector, err = p.factory.CreateTracesToMetrics(p.ctx, settings, connectorConfig, nextMetrics)
 			if err != nil && !errors.Is(err, pipeline.ErrSignalNotSupported) {
 				return err
 			} else if tracesConnector != nil {
@@ -231,7 +256,7 @@ func (p *Connector) Update(args component.Arguments) error {
 
 		// Create metrics to metrics connector if supported
 		if (connectorType & ConnectorMetricsToMetrics) != 0 {
-			metricsConnector, err = p.factory.CreateMetricsToMetrics(p.ctx, settings, connectorConfig, metricsInterceptor)
+			metricsConnector, err = p.factory.CreateMetricsToMetrics(p.ctx, settings, connectorConfig, nextMetrics)
 			if err != nil && !errors.Is(err, pipeline.ErrSignalNotSupported) {
 				return err
 			} else if metricsConnector != nil {
@@ -241,7 +266,21 @@ func (p *Connector) Update(args component.Arguments) error {
 
 		// Create logs to metrics connector if supported
 		if (connectorType & ConnectorLogsToMetrics) != 0 {
-			logsConnector, err = p.factory.CreateLogsToMetrics(p.ctx, settings, connectorConfig, metricsInterceptor)
+			logsConnector, err = p.factory.CreateLogsToMetrics(p.ctx, settings, connectorConfig, nextMetrics)
+			if err != nil && !errors.Is(err, pipeline.ErrSignalNotSupported) {
+				return err
+			} else if logsConnector != nil {
+				components = append(components, logsConnector)
+			}
+		}
+	}
+
+	if len(next.Logs) > 0 {
+		nextLogs := p.logsConsumer(next.Logs)
+
+		// Create logs to logs connector if supported
+		if (connectorType & ConnectorLogsToLogs) != 0 {
+			logsConnector, err = p.factory.CreateLogsToLogs(p.ctx, settings, connectorConfig, nextLogs)
 			if err != nil && !errors.Is(err, pipeline.ErrSignalNotSupported) {
 				return err
 			} else if logsConnector != nil {
@@ -263,6 +302,87 @@ func (p *Connector) Update(args component.Arguments) error {
 	return nil
 }
 
+// tracesConsumer returns the consumer to pass to connectors which output
+// traces. Data sent to it is published to live debugging before being
+// forwarded to next, or to the pipelines of a RouterArguments.
+func (p *Connector) tracesConsumer(next []otelcol.Consumer) otelconsumer.Traces {
+	intercept := func(consumers []otelcol.Consumer) otelconsumer.Traces {
+		fanout := fanoutconsumer.Traces(consumers)
+		return interceptconsumer.Traces(fanout,
+			func(ctx context.Context, td ptrace.Traces) error {
+				livedebuggingpublisher.PublishTracesIfActive(p.debugDataPublisher, p.opts.ID, td, otelcol.GetComponentMetadata(consumers))
+				return fanout.ConsumeTraces(ctx, td)
+			},
+		)
+	}
+
+	router, ok := p.args.(RouterArguments)
+	if !ok {
+		return intercept(next)
+	}
+	routes := make(map[pipeline.ID]otelconsumer.Traces)
+	for id, consumers := range router.Pipelines() {
+		if id.Signal() == pipeline.SignalTraces {
+			routes[id] = intercept(consumers)
+		}
+	}
+	return otelconnector.NewTracesRouter(routes)
+}
+
+// metricsConsumer returns the consumer to pass to connectors which output
+// metrics. Data sent to it is published to live debugging before being
+// forwarded to next, or to the pipelines of a RouterArguments.
+func (p *Connector) metricsConsumer(next []otelcol.Consumer) otelconsumer.Metrics {
+	intercept := func(consumers []otelcol.Consumer) otelconsumer.Metrics {
+		fanout := fanoutconsumer.Metrics(consumers)
+		return interceptconsumer.Metrics(fanout,
+			func(ctx context.Context, md pmetric.Metrics) error {
+				livedebuggingpublisher.PublishMetricsIfActive(p.debugDataPublisher, p.opts.ID, md, otelcol.GetComponentMetadata(consumers))
+				return fanout.ConsumeMetrics(ctx, md)
+			},
+		)
+	}
+
+	router, ok := p.args.(RouterArguments)
+	if !ok {
+		return intercept(next)
+	}
+	routes := make(map[pipeline.ID]otelconsumer.Metrics)
+	for id, consumers := range router.Pipelines() {
+		if id.Signal() == pipeline.SignalMetrics {
+			routes[id] = intercept(consumers)
+		}
+	}
+	return otelconnector.NewMetricsRouter(routes)
+}
+
+// logsConsumer returns the consumer to pass to connectors which output logs.
+// Data sent to it is published to live debugging before being forwarded to
+// next, or to the pipelines of a RouterArguments.
+func (p *Connector) logsConsumer(next []otelcol.Consumer) otelconsumer.Logs {
+	intercept := func(consumers []otelcol.Consumer) otelconsumer.Logs {
+		fanout := fanoutconsumer.Logs(consumers)
+		return interceptconsumer.Logs(fanout,
+			func(ctx context.Context, ld plog.Logs) error {
+				livedebuggingpublisher.PublishLogsIfActive(p.debugDataPublisher, p.opts.ID, ld, otelcol.GetComponentMetadata(consumers))
+				return fanout.ConsumeLogs(ctx, ld)
+			},
+		)
+	}
+
+	router, ok := p.args.(RouterArguments)
+	if !ok {
+		return intercept(next)
+	}
+	routes := make(map[pipeline.ID]otelconsumer.Logs)
+	for id, consumers := range router.Pipelines() {
+		if id.Signal() == pipeline.SignalLogs {
+			routes[id] = intercept(consumers)
+		}
+	}
+	return otelconnector.NewLogsRouter(routes)
+}
+
 // CurrentHealth implements component.HealthComponent.
 func (p *Connector) CurrentHealth() component.Health {
 	return p.sched.CurrentHealth()
@@ -0,0 +1,67 @@
+package routing
+
+import (
+	"context"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/routingconnector"
+	otelcomponent "go.opentelemetry.io/collector/component"
+	otelconnector "go.opentelemetry.io/collector/connector"
+	otelconsumer "go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+// factory wraps the upstream routing connector factory.
+//
+// The upstream connector expects every pipeline in its routing table to be
+// one of the pipelines it outputs to. Since the connector is created once per
+// signal, factory gives each signal a copy of the routing table which only
+// holds the routes with consumers for that signal.
+type factory struct {
+	otelconnector.Factory
+}
+
+func (f factory) CreateTracesToTraces(ctx context.Context, set otelconnector.Settings, cfg otelcomponent.Config, next otelconsumer.Traces) (otelconnector.Traces, error) {
+	return f.Factory.CreateTracesToTraces(ctx, set, configForSignal(cfg, pipeline.SignalTraces), next)
+}
+
+func (f factory) CreateMetricsToMetrics(ctx context.Context, set otelconnector.Settings, cfg otelcomponent.Config, next otelconsumer.Metrics) (otelconnector.Metrics, error) {
+	return f.Factory.CreateMetricsToMetrics(ctx, set, configForSignal(cfg, pipeline.SignalMetrics), next)
+}
+
+func (f factory) CreateLogsToLogs(ctx context.Context, set otelconnector.Settings, cfg otelcomponent.Config, next otelconsumer.Logs) (otelconnector.Logs, error) {
+	return f.Factory.CreateLogsToLogs(ctx, set, configForSignal(cfg, pipeline.SignalLogs), next)
+}
+
+// configForSignal returns a copy of cfg which only references pipelines of
+// the given signal.
+func configForSignal(cfg otelcomponent.Config, signal pipeline.Signal) *routingconnector.Config {
+	in := cfg.(*routingconnector.Config)
+
+	out := &routingconnector.Config{
+		ErrorMode:        in.ErrorMode,
+		DefaultPipelines: filterPipelines(in.DefaultPipelines, signal),
+	}
+	for _, item := range in.Table {
+		pipelines := filterPipelines(item.Pipelines, signal)
+		if len(pipelines) == 0 {
+			continue
+		}
+		out.Table = append(out.Table, routingconnector.RoutingTableItem{
+			Context:   item.Context,
+			Statement: item.Statement,
+			Condition: item.Condition,
+			Pipelines: pipelines,
+		})
+	}
+	return out
+}
+
+func filterPipelines(ids []pipeline.ID, signal pipeline.Signal) []pipeline.ID {
+	var res []pipeline.ID
+	for _, id := range ids {
+		if id.Signal() == signal {
+			res = append(res, id)
+		}
+	}
+	return res
+}
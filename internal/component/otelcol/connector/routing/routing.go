@@ -0,0 +1,277 @@
+// Package routing provides an otelcol.connector.routing component.
+package routing
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/grafana/alloy/internal/component"
+	"github.com/grafana/alloy/internal/component/otelcol"
+	otelcolCfg "github.com/grafana/alloy/internal/component/otelcol/config"
+	"github.com/grafana/alloy/internal/component/otelcol/connector"
+	"github.com/grafana/alloy/internal/featuregate"
+	"github.com/grafana/alloy/syntax"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/routingconnector"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	otelcomponent "go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+func init() {
+	component.Register(component.Registration{
+		Name:      "otelcol.connector.routing",
+		Stability: featuregate.StabilityExperimental,
+		Args:      Arguments{},
+		Exports:   otelcol.ConsumerExports{},
+
+		Build: func(opts component.Options, args component.Arguments) (component.Component, error) {
+			fact := factory{routingconnector.NewFactory()}
+			return connector.New(opts, fact, args.(Arguments))
+		},
+	})
+}
+
+// The contexts a route can be evaluated in.
+const (
+	contextResource  = "resource"
+	contextSpan      = "span"
+	contextMetric    = "metric"
+	contextDataPoint = "datapoint"
+	contextLog       = "log"
+	contextRequest   = "request"
+)
+
+// defaultPipeline is the name of the pipelines which receive data that doesn't
+// match any route.
+const defaultPipeline = "default"
+
+// Arguments configures the otelcol.connector.routing component.
+type Arguments struct {
+	// ErrorMode determines how the connector reacts to errors that occur while
+	// evaluating a route.
+	ErrorMode ottl.ErrorMode `alloy:"error_mode,attr,optional"`
+
+	// Routes are evaluated in order, and data is sent to the first route it
+	// matches. A route is only evaluated for the signals its output has
+	// consumers for.
+	Routes []Route `alloy:"route,block,optional"`
+
+	// Output configures where to send data which doesn't match any route.
+	// Required.
+	Output *otelcol.ConsumerArguments `alloy:"output,block"`
+
+	// DebugMetrics configures component internal metrics. Optional.
+	DebugMetrics otelcolCfg.DebugMetricsArguments `alloy:"debug_metrics,block,optional"`
+}
+
+// Route configures a single entry of the routing table.
+type Route struct {
+	Context   string `alloy:"context,attr,optional"`
+	Condition string `alloy:"condition,attr,optional"`
+	Statement string `alloy:"statement,attr,optional"`
+
+	// Attribute and Value match a resource attribute without writing an OTTL
+	// condition.
+	Attribute string `alloy:"attribute,attr,optional"`
+	Value     string `alloy:"value,attr,optional"`
+
+	// Output configures where to send data which matches the route. Required.
+	Output *otelcol.ConsumerArguments `alloy:"output,block"`
+}
+
+var (
+	_ connector.RouterArguments = Arguments{}
+	_ syntax.Defaulter          = (*Arguments)(nil)
+	_ syntax.Defaulter          = (*Route)(nil)
+	_ syntax.Validator          = (*Route)(nil)
+)
+
+// DefaultArguments holds default settings for Arguments.
+var DefaultArguments = Arguments{
+	ErrorMode: ottl.PropagateError,
+}
+
+// SetToDefault implements syntax.Defaulter.
+func (args *Arguments) SetToDefault() {
+	*args = DefaultArguments
+	args.DebugMetrics.SetToDefault()
+}
+
+// SetToDefault implements syntax.Defaulter.
+func (r *Route) SetToDefault() {
+	*r = Route{Context: contextResource}
+}
+
+// Validate implements syntax.Validator.
+func (r *Route) Validate() error {
+	var set int
+	for _, s := range []string{r.Condition, r.Statement, r.Attribute} {
+		if s != "" {
+			set++
+		}
+	}
+	if set != 1 {
+		return errors.New("exactly one of condition, statement, or attribute must be set")
+	}
+	if r.Value != "" && r.Attribute == "" {
+		return errors.New("value can only be set along with attribute")
+	}
+
+	switch r.Context {
+	case contextResource:
+	case contextSpan, contextMetric, contextDataPoint, contextLog:
+		if r.Attribute != "" {
+			return fmt.Errorf("attribute can only be used in the %q context", contextResource)
+		}
+	case contextRequest:
+		if r.Condition == "" {
+			return fmt.Errorf("the %q context requires a condition", contextRequest)
+		}
+	default:
+		return fmt.Errorf("unsupported context %q", r.Context)
+	}
+
+	if r.Output != nil {
+		for signal, consumers := range outputsBySignal(r.Output) {
+			if len(consumers) > 0 && !contextSupportsSignal(r.Context, signal) {
+				return fmt.Errorf("the %q context cannot route %s", r.Context, signal)
+			}
+		}
+	}
+	return nil
+}
+
+// contextSupportsSignal reports whether routes in the given context can be
+// evaluated for the given signal.
+func contextSupportsSignal(context string, signal pipeline.Signal) bool {
+	switch context {
+	case contextSpan:
+		return signal == pipeline.SignalTraces
+	case contextMetric, contextDataPoint:
+		return signal == pipeline.SignalMetrics
+	case contextLog:
+		return signal == pipeline.SignalLogs
+	default:
+		return true
+	}
+}
+
+// condition returns the OTTL condition of the route.
+func (r *Route) condition() string {
+	if r.Attribute != "" {
+		return fmt.Sprintf("attributes[%s] == %s", strconv.Quote(r.Attribute), strconv.Quote(r.Value))
+	}
+	return r.Condition
+}
+
+// routePipeline returns the ID of the pipeline of the route at index i.
+func routePipeline(signal pipeline.Signal, i int) pipeline.ID {
+	return pipeline.NewIDWithName(signal, fmt.Sprintf("route_%d", i))
+}
+
+// Convert implements connector.Arguments.
+func (args Arguments) Convert() (otelcomponent.Config, error) {
+	cfg := &routingconnector.Config{
+		ErrorMode: args.ErrorMode,
+		DefaultPipelines: pipelineIDs(args.Output, func(signal pipeline.Signal) pipeline.ID {
+			return pipeline.NewIDWithName(signal, defaultPipeline)
+		}),
+	}
+
+	for i, route := range args.Routes {
+		item := routingconnector.RoutingTableItem{
+			Context:   route.Context,
+			Statement: route.Statement,
+			Condition: route.condition(),
+			Pipelines: pipelineIDs(route.Output, func(signal pipeline.Signal) pipeline.ID {
+				return routePipeline(signal, i)
+			}),
+		}
+		cfg.Table = append(cfg.Table, item)
+	}
+
+	return cfg, nil
+}
+
+// Pipelines implements connector.RouterArguments.
+func (args Arguments) Pipelines() map[pipeline.ID][]otelcol.Consumer {
+	res := make(map[pipeline.ID][]otelcol.Consumer)
+	for signal, consumers := range outputsBySignal(args.Output) {
+		if len(consumers) > 0 {
+			res[pipeline.NewIDWithName(signal, defaultPipeline)] = consumers
+		}
+	}
+	for i, route := range args.Routes {
+		for signal, consumers := range outputsBySignal(route.Output) {
+			if len(consumers) > 0 {
+				res[routePipeline(signal, i)] = consumers
+			}
+		}
+	}
+	return res
+}
+
+// Extensions implements connector.Arguments.
+func (args Arguments) Extensions() map[otelcomponent.ID]otelcomponent.Component {
+	return nil
+}
+
+// Exporters implements connector.Arguments.
+func (args Arguments) Exporters() map[pipeline.Signal]map[otelcomponent.ID]otelcomponent.Component {
+	return nil
+}
+
+// NextConsumers implements connector.Arguments. It returns the consumers of
+// every route, which determines the signals the connector outputs.
+func (args Arguments) NextConsumers() *otelcol.ConsumerArguments {
+	next := &otelcol.ConsumerArguments{}
+	for _, output := range args.outputs() {
+		next.Traces = append(next.Traces, output.Traces...)
+		next.Metrics = append(next.Metrics, output.Metrics...)
+		next.Logs = append(next.Logs, output.Logs...)
+	}
+	return next
+}
+
+// ConnectorType implements connector.Arguments.
+func (Arguments) ConnectorType() int {
+	return connector.ConnectorTracesToTraces | connector.ConnectorMetricsToMetrics | connector.ConnectorLogsToLogs
+}
+
+// DebugMetricsConfig implements connector.Arguments.
+func (args Arguments) DebugMetricsConfig() otelcolCfg.DebugMetricsArguments {
+	return args.DebugMetrics
+}
+
+// outputs returns the default output followed by the output of each route.
+func (args Arguments) outputs() []*otelcol.ConsumerArguments {
+	outputs := []*otelcol.ConsumerArguments{args.Output}
+	for _, route := range args.Routes {
+		outputs = append(outputs, route.Output)
+	}
+	return outputs
+}
+
+func outputsBySignal(output *otelcol.ConsumerArguments) map[pipeline.Signal][]otelcol.Consumer {
+	if output == nil {
+		return nil
+	}
+	return map[pipeline.Signal][]otelcol.Consumer{
+		pipeline.SignalTraces:  output.Traces,
+		pipeline.SignalMetrics: output.Metrics,
+		pipeline.SignalLogs:    output.Logs,
+	}
+}
+
+// pipelineIDs returns the pipeline ID for each signal which output has
+// consumers for.
+func pipelineIDs(output *otelcol.ConsumerArguments, id func(pipeline.Signal) pipeline.ID) []pipeline.ID {
+	var ids []pipeline.ID
+	for _, signal := range []pipeline.Signal{pipeline.SignalTraces, pipeline.SignalMetrics, pipeline.SignalLogs} {
+		if len(outputsBySignal(output)[signal]) > 0 {
+			ids = append(ids, id(signal))
+		}
+	}
+	return ids
+}
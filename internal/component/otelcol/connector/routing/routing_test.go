@@ -0,0 +1,315 @@
+package routing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/grafana/alloy/internal/component/otelcol"
+	"github.com/grafana/alloy/internal/component/otelcol/internal/fakeconsumer"
+	"github.com/grafana/alloy/internal/runtime/componenttest"
+	"github.com/grafana/alloy/internal/runtime/logging/level"
+	"github.com/grafana/alloy/internal/util"
+	"github.com/grafana/alloy/syntax"
+	"github.com/grafana/dskit/backoff"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/routingconnector"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+func TestArguments_UnmarshalAlloy(t *testing.T) {
+	in := `
+		error_mode = "ignore"
+
+		route {
+			attribute = "tenant"
+			value     = "acme"
+			output {}
+		}
+
+		route {
+			context   = "span"
+			condition = "name == \"health\""
+			output {}
+		}
+
+		output {}
+	`
+
+	var args Arguments
+	require.NoError(t, syntax.Unmarshal([]byte(in), &args))
+
+	// Give every route an output for the signals it's valid for.
+	args.Routes[0].Output = &otelcol.ConsumerArguments{
+		Traces: []otelcol.Consumer{&fakeconsumer.Consumer{}},
+		Logs:   []otelcol.Consumer{&fakeconsumer.Consumer{}},
+	}
+	args.Routes[1].Output = &otelcol.ConsumerArguments{
+		Traces: []otelcol.Consumer{&fakeconsumer.Consumer{}},
+	}
+	args.Output = &otelcol.ConsumerArguments{
+		Logs: []otelcol.Consumer{&fakeconsumer.Consumer{}},
+	}
+
+	cfg, err := args.Convert()
+	require.NoError(t, err)
+
+	expected := &routingconnector.Config{
+		ErrorMode:        ottl.IgnoreError,
+		DefaultPipelines: []pipeline.ID{pipeline.NewIDWithName(pipeline.SignalLogs, "default")},
+		Table: []routingconnector.RoutingTableItem{
+			{
+				Context:   "resource",
+				Condition: `attributes["tenant"] == "acme"`,
+				Pipelines: []pipeline.ID{
+					pipeline.NewIDWithName(pipeline.SignalTraces, "route_0"),
+					pipeline.NewIDWithName(pipeline.SignalLogs, "route_0"),
+				},
+			},
+			{
+				Context:   "span",
+				Condition: `name == "health"`,
+				Pipelines: []pipeline.ID{pipeline.NewIDWithName(pipeline.SignalTraces, "route_1")},
+			},
+		},
+	}
+	require.Equal(t, expected, cfg)
+	require.NoError(t, cfg.(*routingconnector.Config).Validate())
+
+	// The logs routing table only has the routes with logs consumers.
+	logsCfg := configForSignal(cfg, pipeline.SignalLogs)
+	require.Len(t, logsCfg.Table, 1)
+	require.Equal(t, []pipeline.ID{pipeline.NewIDWithName(pipeline.SignalLogs, "route_0")}, logsCfg.Table[0].Pipelines)
+	require.Equal(t, expected.DefaultPipelines, logsCfg.DefaultPipelines)
+
+	tracesCfg := configForSignal(cfg, pipeline.SignalTraces)
+	require.Len(t, tracesCfg.Table, 2)
+	require.Empty(t, tracesCfg.DefaultPipelines)
+
+	require.Len(t, args.Pipelines(), 4)
+}
+
+func TestArguments_Validate(t *testing.T) {
+	tests := []struct {
+		name        string
+		cfg         string
+		expectedErr string
+	}{
+		{
+			name: "no condition",
+			cfg: `
+				route {
+					output {}
+				}
+				output {}
+			`,
+			expectedErr: "exactly one of condition, statement, or attribute must be set",
+		},
+		{
+			name: "condition and attribute",
+			cfg: `
+				route {
+					condition = "true"
+					attribute = "tenant"
+					output {}
+				}
+				output {}
+			`,
+			expectedErr: "exactly one of condition, statement, or attribute must be set",
+		},
+		{
+			name: "value without attribute",
+			cfg: `
+				route {
+					condition = "true"
+					value     = "acme"
+					output {}
+				}
+				output {}
+			`,
+			expectedErr: "value can only be set along with attribute",
+		},
+		{
+			name: "attribute outside of resource context",
+			cfg: `
+				route {
+					context   = "log"
+					attribute = "tenant"
+					output {}
+				}
+				output {}
+			`,
+			expectedErr: `attribute can only be used in the "resource" context`,
+		},
+		{
+			name: "request context with statement",
+			cfg: `
+				route {
+					context   = "request"
+					statement = "route()"
+					output {}
+				}
+				output {}
+			`,
+			expectedErr: `the "request" context requires a condition`,
+		},
+		{
+			name: "unknown context",
+			cfg: `
+				route {
+					context   = "scope"
+					condition = "true"
+					output {}
+				}
+				output {}
+			`,
+			expectedErr: `unsupported context "scope"`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var args Arguments
+			require.ErrorContains(t, syntax.Unmarshal([]byte(tc.cfg), &args), tc.expectedErr)
+		})
+	}
+}
+
+func TestRoute_SignalContext(t *testing.T) {
+	r := Route{
+		Context:   "span",
+		Condition: "true",
+		Output: &otelcol.ConsumerArguments{
+			Logs: []otelcol.Consumer{&fakeconsumer.Consumer{}},
+		},
+	}
+	require.ErrorContains(t, r.Validate(), `the "span" context cannot route logs`)
+}
+
+func TestRouting(t *testing.T) {
+	ctx := componenttest.TestContext(t)
+	l := util.TestLogger(t)
+
+	ctrl, err := componenttest.NewControllerFromID(l, "otelcol.connector.routing")
+	require.NoError(t, err)
+
+	var args Arguments
+	require.NoError(t, syntax.Unmarshal([]byte(`
+		route {
+			attribute = "tenant"
+			value     = "acme"
+			output {}
+		}
+		output {}
+	`), &args))
+
+	var (
+		acmeTraces    = make(chan ptrace.Traces, 1)
+		defaultTraces = make(chan ptrace.Traces, 1)
+		defaultLogs   = make(chan plog.Logs, 1)
+	)
+	args.Routes[0].Output = &otelcol.ConsumerArguments{
+		Traces: []otelcol.Consumer{tracesConsumer(acmeTraces)},
+	}
+	args.Output = &otelcol.ConsumerArguments{
+		Traces: []otelcol.Consumer{tracesConsumer(defaultTraces)},
+		Logs: []otelcol.Consumer{&fakeconsumer.Consumer{
+			ConsumeLogsFunc: func(_ context.Context, ld plog.Logs) error {
+				defaultLogs <- ld
+				return nil
+			},
+		}},
+	}
+
+	go func() {
+		err := ctrl.Run(ctx, args)
+		require.NoError(t, err)
+	}()
+
+	require.NoError(t, ctrl.WaitRunning(time.Second), "component never started")
+	require.NoError(t, ctrl.WaitExports(time.Second), "component never exported anything")
+
+	exports := ctrl.Exports().(otelcol.ConsumerExports)
+	send(t, ctx, func() error {
+		return exports.Input.ConsumeTraces(ctx, createTraces("acme", "other"))
+	})
+	send(t, ctx, func() error {
+		return exports.Input.ConsumeLogs(ctx, createLogs("acme"))
+	})
+
+	// Each resource goes to exactly one route.
+	requireTenants(t, acmeTraces, "acme")
+	requireTenants(t, defaultTraces, "other")
+
+	// Only the default output has a logs consumer, so all logs go there.
+	select {
+	case <-time.After(10 * time.Second):
+		require.FailNow(t, "failed waiting for logs")
+	case ld := <-defaultLogs:
+		require.Equal(t, 1, ld.LogRecordCount())
+	}
+}
+
+// send calls fn until it succeeds, since the component drops data until it
+// has started its connectors.
+func send(t *testing.T, ctx context.Context, fn func() error) {
+	bo := backoff.New(ctx, backoff.Config{
+		MinBackoff: 10 * time.Millisecond,
+		MaxBackoff: 100 * time.Millisecond,
+	})
+	for bo.Ongoing() {
+		err := fn()
+		if err == nil {
+			return
+		}
+		level.Error(util.TestLogger(t)).Log("msg", "failed to send data", "err", err)
+		bo.Wait()
+	}
+	require.NoError(t, bo.Err())
+}
+
+func requireTenants(t *testing.T, ch chan ptrace.Traces, tenants ...string) {
+	t.Helper()
+
+	select {
+	case <-time.After(10 * time.Second):
+		require.FailNow(t, "failed waiting for traces")
+	case td := <-ch:
+		var actual []string
+		for i := 0; i < td.ResourceSpans().Len(); i++ {
+			tenant, _ := td.ResourceSpans().At(i).Resource().Attributes().Get("tenant")
+			actual = append(actual, tenant.Str())
+		}
+		require.Equal(t, tenants, actual)
+	}
+}
+
+func tracesConsumer(ch chan ptrace.Traces) otelcol.Consumer {
+	return &fakeconsumer.Consumer{
+		ConsumeTracesFunc: func(_ context.Context, td ptrace.Traces) error {
+			ch <- td
+			return nil
+		},
+	}
+}
+
+func createTraces(tenants ...string) ptrace.Traces {
+	td := ptrace.NewTraces()
+	for _, tenant := range tenants {
+		rs := td.ResourceSpans().AppendEmpty()
+		rs.Resource().Attributes().PutStr("tenant", tenant)
+		rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("span")
+	}
+	return td
+}
+
+func createLogs(tenant string) plog.Logs {
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("tenant", tenant)
+	rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr("hello")
+	return ld
+}
@@ -8,7 +8,11 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 	otelcomponent "go.opentelemetry.io/collector/component"
+	otelconsumer "go.opentelemetry.io/collector/consumer"
 	otelexporter "go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/pipeline"
 	sdkprometheus "go.opentelemetry.io/otel/exporters/prometheus"
 	"go.opentelemetry.io/otel/sdk/metric"
@@ -16,11 +20,14 @@ import (
 	"github.com/grafana/alloy/internal/component"
 	"github.com/grafana/alloy/internal/component/otelcol"
 	otelcolCfg "github.com/grafana/alloy/internal/component/otelcol/config"
+	"github.com/grafana/alloy/internal/component/otelcol/internal/interceptconsumer"
 	"github.com/grafana/alloy/internal/component/otelcol/internal/lazycollector"
 	"github.com/grafana/alloy/internal/component/otelcol/internal/lazyconsumer"
+	"github.com/grafana/alloy/internal/component/otelcol/internal/livedebuggingpublisher"
 	"github.com/grafana/alloy/internal/component/otelcol/internal/scheduler"
 	"github.com/grafana/alloy/internal/component/otelcol/internal/views"
 	otelcolutil "github.com/grafana/alloy/internal/component/otelcol/util"
+	"github.com/grafana/alloy/internal/service/livedebugging"
 	"github.com/grafana/alloy/internal/util/zapadapter"
 )
 
@@ -97,11 +104,14 @@ type Exporter struct {
 	// Can be logs, metrics, traces or any combination of them.
 	// This is a function because which signals are supported may depend on the component configuration.
 	supportedSignals TypeSignalFunc
+
+	debugDataPublisher livedebugging.DebugDataPublisher
 }
 
 var (
 	_ component.Component       = (*Exporter)(nil)
 	_ component.HealthComponent = (*Exporter)(nil)
+	_ component.LiveDebugging   = (*Exporter)(nil)
 )
 
 // New creates a new component which encapsulates an OpenTelemetry Collector
@@ -111,6 +121,11 @@ var (
 // The registered component must be registered to export the
 // otelcol.ConsumerExports type, otherwise New will panic.
 func New(opts component.Options, f otelexporter.Factory, args Arguments, supportedSignals TypeSignalFunc) (*Exporter, error) {
+	debugDataPublisher, err := opts.GetServiceData(livedebugging.ServiceName)
+	if err != nil {
+		return nil, err
+	}
+
 	ctx, cancel := context.WithCancel(context.Background())
 
 	consumer := lazyconsumer.NewPaused(ctx, opts.ID)
@@ -139,6 +154,8 @@ func New(opts component.Options, f otelexporter.Factory, args Arguments, support
 		collector: collector,
 
 		supportedSignals: supportedSignals,
+
+		debugDataPublisher: debugDataPublisher.(livedebugging.DebugDataPublisher),
 	}
 	if err := e.Update(args); err != nil {
 		return nil, err
@@ -238,8 +255,23 @@ func (e *Exporter) Update(args component.Arguments) error {
 		}
 	}
 
+	var (
+		tracesConsumer  otelconsumer.Traces
+		metricsConsumer otelconsumer.Metrics
+		logsConsumer    otelconsumer.Logs
+	)
+	if tracesExporter != nil {
+		tracesConsumer = e.interceptTraces(tracesExporter)
+	}
+	if metricsExporter != nil {
+		metricsConsumer = e.interceptMetrics(metricsExporter)
+	}
+	if logsExporter != nil {
+		logsConsumer = e.interceptLogs(logsExporter)
+	}
+
 	updateConsumersFunc := func() {
-		e.consumer.SetConsumers(tracesExporter, metricsExporter, logsExporter)
+		e.consumer.SetConsumers(tracesConsumer, metricsConsumer, logsConsumer)
 	}
 
 	// Schedule the components to run once our component is running.
@@ -251,3 +283,65 @@ func (e *Exporter) Update(args component.Arguments) error {
 func (e *Exporter) CurrentHealth() component.Health {
 	return e.sched.CurrentHealth()
 }
+
+// interceptTraces publishes the traces sent to the exporter and the outcome
+// of handing them over to it.
+func (e *Exporter) interceptTraces(next otelconsumer.Traces) otelconsumer.Traces {
+	intercept := interceptconsumer.Traces
+	if next.Capabilities().MutatesData {
+		intercept = interceptconsumer.TracesMutating
+	}
+	return intercept(next, func(ctx context.Context, td ptrace.Traces) error {
+		count := td.SpanCount()
+		livedebuggingpublisher.PublishTracesIfActive(e.debugDataPublisher, e.opts.ID, td, nil)
+		err := next.ConsumeTraces(ctx, td)
+		e.publishOutcome(uint64(count), err)
+		return err
+	})
+}
+
+// interceptMetrics publishes the metrics sent to the exporter and the outcome
+// of handing them over to it.
+func (e *Exporter) interceptMetrics(next otelconsumer.Metrics) otelconsumer.Metrics {
+	intercept := interceptconsumer.Metrics
+	if next.Capabilities().MutatesData {
+		intercept = interceptconsumer.MetricsMutating
+	}
+	return intercept(next, func(ctx context.Context, md pmetric.Metrics) error {
+		count := md.MetricCount()
+		livedebuggingpublisher.PublishMetricsIfActive(e.debugDataPublisher, e.opts.ID, md, nil)
+		err := next.ConsumeMetrics(ctx, md)
+		e.publishOutcome(uint64(count), err)
+		return err
+	})
+}
+
+// interceptLogs publishes the logs sent to the exporter and the outcome of
+// handing them over to it.
+func (e *Exporter) interceptLogs(next otelconsumer.Logs) otelconsumer.Logs {
+	intercept := interceptconsumer.Logs
+	if next.Capabilities().MutatesData {
+		intercept = interceptconsumer.LogsMutating
+	}
+	return intercept(next, func(ctx context.Context, ld plog.Logs) error {
+		count := ld.LogRecordCount()
+		livedebuggingpublisher.PublishLogsIfActive(e.debugDataPublisher, e.opts.ID, ld, nil)
+		err := next.ConsumeLogs(ctx, ld)
+		e.publishOutcome(uint64(count), err)
+		return err
+	})
+}
+
+// publishOutcome publishes whether the upstream exporter accepted the data.
+// Exporters with a sending queue accept data before it is sent, so failures
+// which happen afterwards are only reported through the component's logs
+// and metrics.
+func (e *Exporter) publishOutcome(count uint64, err error) {
+	outcome := livedebugging.Outcome{Result: livedebugging.OutcomeAccepted}
+	if err != nil {
+		outcome = livedebugging.Outcome{Result: livedebugging.OutcomeRejected, Err: err}
+	}
+	e.debugDataPublisher.PublishIfActive(livedebugging.NewOutcomeData(livedebugging.ComponentID(e.opts.ID), count, outcome))
+}
+
+func (e *Exporter) LiveDebugging() {}
@@ -89,7 +89,7 @@ func (args Arguments) Convert() (otelcomponent.Config, error) {
 
 // Extensions implements exporter.Arguments.
 func (args Arguments) Extensions() map[otelcomponent.ID]otelcomponent.Component {
-	return nil
+	return args.Queue.Extensions()
 }
 
 // Exporters implements exporter.Arguments.
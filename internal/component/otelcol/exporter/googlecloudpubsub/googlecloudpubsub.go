@@ -94,7 +94,7 @@ func (args Arguments) Convert() (otelcomponent.Config, error) {
 
 // Extensions implements exporter.Arguments.
 func (args Arguments) Extensions() map[otelcomponent.ID]otelcomponent.Component {
-	return nil
+	return args.Queue.Extensions()
 }
 
 // Exporters implements exporter.Arguments.
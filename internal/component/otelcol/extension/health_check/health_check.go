@@ -0,0 +1,295 @@
+// Package health_check provides an otelcol.extension.health_check component.
+package health_check
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/grafana/alloy/internal/component"
+	otelcolCfg "github.com/grafana/alloy/internal/component/otelcol/config"
+	"github.com/grafana/alloy/internal/component/otelcol/extension"
+	"github.com/grafana/alloy/internal/featuregate"
+	"github.com/grafana/alloy/internal/runtime/logging/level"
+	http_service "github.com/grafana/alloy/internal/service/http"
+	"github.com/grafana/alloy/syntax"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/healthcheckextension"
+	otelcomponent "go.opentelemetry.io/collector/component"
+	otelextension "go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+func init() {
+	component.Register(component.Registration{
+		Name:      "otelcol.extension.health_check",
+		Stability: featuregate.StabilityExperimental,
+		Args:      Arguments{},
+		Exports:   extension.Exports{},
+
+		Build: func(opts component.Options, args component.Arguments) (component.Component, error) {
+			return New(opts, args.(Arguments))
+		},
+	})
+}
+
+// Arguments configures the otelcol.extension.health_check component.
+type Arguments struct {
+	// Components lists the components whose health is aggregated, by their
+	// name in the module the health check runs in. If empty, every other
+	// otelcol component of the module is checked.
+	Components []string `alloy:"components,attr,optional"`
+
+	ResponseBody *ResponseBodyArguments `alloy:"response_body,block,optional"`
+
+	// DebugMetrics configures component internal metrics. Optional.
+	DebugMetrics otelcolCfg.DebugMetricsArguments `alloy:"debug_metrics,block,optional"`
+}
+
+// ResponseBodyArguments overrides the body of health check responses.
+type ResponseBodyArguments struct {
+	Healthy   string `alloy:"healthy,attr,optional"`
+	Unhealthy string `alloy:"unhealthy,attr,optional"`
+}
+
+var (
+	_ extension.Arguments = Arguments{}
+	_ syntax.Defaulter    = (*Arguments)(nil)
+)
+
+// SetToDefault implements syntax.Defaulter.
+func (args *Arguments) SetToDefault() {
+	*args = Arguments{}
+	args.DebugMetrics.SetToDefault()
+}
+
+// Convert implements extension.Arguments. The endpoint of the upstream
+// configuration is left to its default: it's never listened on, since the
+// health check is served by the Alloy HTTP server.
+func (args Arguments) Convert(_ component.Options) (otelcomponent.Config, error) {
+	cfg := healthcheckextension.NewFactory().CreateDefaultConfig().(*healthcheckextension.Config)
+	if args.ResponseBody != nil {
+		cfg.ResponseBody = &healthcheckextension.ResponseBodySettings{
+			Healthy:   args.ResponseBody.Healthy,
+			Unhealthy: args.ResponseBody.Unhealthy,
+		}
+	}
+	return cfg, nil
+}
+
+// Extensions implements extension.Arguments.
+func (args Arguments) Extensions() map[otelcomponent.ID]otelcomponent.Component {
+	return nil
+}
+
+// Exporters implements extension.Arguments.
+func (args Arguments) Exporters() map[pipeline.Signal]map[otelcomponent.ID]otelcomponent.Component {
+	return nil
+}
+
+// DebugMetricsConfig implements extension.Arguments.
+func (args Arguments) DebugMetricsConfig() otelcolCfg.DebugMetricsArguments {
+	return args.DebugMetrics
+}
+
+// ExportsHandler implements extension.Arguments.
+func (args Arguments) ExportsHandler() bool {
+	return true
+}
+
+// Component implements the otelcol.extension.health_check component. It wraps
+// the upstream health_check extension, but serves the health of the checked
+// components on the component's HTTP route of the Alloy HTTP server rather
+// than on a listener of its own.
+type Component struct {
+	*extension.Extension
+
+	opts      component.Options
+	id        component.ID
+	provider  component.Provider
+	startTime time.Time
+
+	mut  sync.RWMutex
+	args Arguments
+	// ext is the extension last created from the arguments.
+	ext *healthCheckExtension
+}
+
+var (
+	_ component.Component       = (*Component)(nil)
+	_ component.HealthComponent = (*Component)(nil)
+	_ http_service.Component    = (*Component)(nil)
+)
+
+// New creates a new otelcol.extension.health_check component.
+func New(opts component.Options, args Arguments) (*Component, error) {
+	data, err := opts.GetServiceData(http_service.ServiceName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get HTTP information: %w", err)
+	}
+
+	c := &Component{
+		opts:      opts,
+		id:        component.ParseID(opts.ID),
+		provider:  data.(http_service.Data).ComponentProvider,
+		startTime: time.Now().UTC(),
+		args:      args,
+	}
+
+	c.Extension, err = extension.New(opts, c.newFactory(), args)
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// newFactory returns a factory of the upstream health_check extension which
+// creates extensions served by c.
+func (c *Component) newFactory() otelextension.Factory {
+	upstream := healthcheckextension.NewFactory()
+	return otelextension.NewFactory(
+		upstream.Type(),
+		upstream.CreateDefaultConfig,
+		func(_ context.Context, _ otelextension.Settings, cfg otelcomponent.Config) (otelextension.Extension, error) {
+			ext := &healthCheckExtension{config: *cfg.(*healthcheckextension.Config)}
+
+			c.mut.Lock()
+			c.ext = ext
+			c.mut.Unlock()
+			return ext, nil
+		},
+		upstream.Stability(),
+	)
+}
+
+// Update implements component.Component.
+func (c *Component) Update(args component.Arguments) error {
+	c.mut.Lock()
+	c.args = args.(Arguments)
+	c.mut.Unlock()
+
+	return c.Extension.Update(args)
+}
+
+// healthCheckExtension is the upstream health_check extension as created by
+// the component. It has no listener to start or stop.
+type healthCheckExtension struct {
+	otelcomponent.StartFunc
+	otelcomponent.ShutdownFunc
+
+	config healthcheckextension.Config
+}
+
+// componentStatus is the health of a single checked component.
+type componentStatus struct {
+	Health  string `json:"health"`
+	Message string `json:"message,omitempty"`
+}
+
+// response mirrors the body of the upstream health_check extension, with the
+// health of each checked component added.
+type response struct {
+	Status     string                     `json:"status"`
+	UpSince    time.Time                  `json:"upSince"`
+	Uptime     string                     `json:"uptime"`
+	Components map[string]componentStatus `json:"components,omitempty"`
+}
+
+// Handler implements http_service.Component.
+func (c *Component) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		c.mut.RLock()
+		names, ext := c.args.Components, c.ext
+		c.mut.RUnlock()
+
+		healthy, components, err := c.checkHealth(names)
+		if err != nil {
+			level.Warn(c.opts.Logger).Log("msg", "failed to check component health", "err", err)
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+
+		status := http.StatusOK
+		if !healthy {
+			status = http.StatusServiceUnavailable
+		}
+
+		if rb := ext.config.ResponseBody; rb != nil {
+			body := rb.Healthy
+			if !healthy {
+				body = rb.Unhealthy
+			}
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(body))
+			return
+		}
+
+		resp := response{
+			Status:     "Server available",
+			UpSince:    c.startTime,
+			Uptime:     time.Since(c.startTime).String(),
+			Components: components,
+		}
+		if !healthy {
+			resp.Status = "Server not available"
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(resp)
+	})
+}
+
+// checkHealth returns whether all checked components are healthy, along with
+// the health of each of them. Components which are missing or whose health
+// is still unknown are reported as unhealthy.
+func (c *Component) checkHealth(names []string) (bool, map[string]componentStatus, error) {
+	infos, err := c.provider.ListComponents(c.id.ModuleID, component.InfoOptions{GetHealth: true})
+	if err != nil {
+		return false, nil, err
+	}
+
+	var (
+		healthy  = true
+		statuses = make(map[string]componentStatus)
+	)
+
+	for _, info := range infos {
+		if !c.isChecked(info, names) {
+			continue
+		}
+
+		statuses[info.ID.LocalID] = componentStatus{
+			Health:  info.Health.Health.String(),
+			Message: info.Health.Message,
+		}
+		if info.Health.Health != component.HealthTypeHealthy {
+			healthy = false
+		}
+	}
+
+	for _, name := range names {
+		if _, found := statuses[name]; !found {
+			statuses[name] = componentStatus{
+				Health:  "missing",
+				Message: "component not found",
+			}
+			healthy = false
+		}
+	}
+
+	return healthy, statuses, nil
+}
+
+func (c *Component) isChecked(info *component.Info, names []string) bool {
+	if len(names) > 0 {
+		return slices.Contains(names, info.ID.LocalID)
+	}
+	// By default, every otelcol component other than health checks is checked.
+	return strings.HasPrefix(info.ComponentName, "otelcol.") &&
+		info.ComponentName != "otelcol.extension.health_check"
+}
@@ -0,0 +1,187 @@
+package health_check
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/grafana/alloy/internal/component"
+	"github.com/grafana/alloy/internal/component/otelcol/extension"
+	http_service "github.com/grafana/alloy/internal/service/http"
+	"github.com/grafana/alloy/internal/util"
+	"github.com/grafana/alloy/syntax"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/healthcheckextension"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeProvider struct {
+	infos []*component.Info
+}
+
+func (p *fakeProvider) GetComponent(id component.ID, _ component.InfoOptions) (*component.Info, error) {
+	for _, info := range p.infos {
+		if info.ID == id {
+			return info, nil
+		}
+	}
+	return nil, component.ErrComponentNotFound
+}
+
+func (p *fakeProvider) ListComponents(moduleID string, _ component.InfoOptions) ([]*component.Info, error) {
+	var infos []*component.Info
+	for _, info := range p.infos {
+		if info.ID.ModuleID == moduleID {
+			infos = append(infos, info)
+		}
+	}
+	return infos, nil
+}
+
+func newInfo(name, label string, health component.HealthType) *component.Info {
+	return &component.Info{
+		ID:            component.ID{LocalID: name + "." + label},
+		ComponentName: name,
+		Label:         label,
+		Health:        component.Health{Health: health},
+	}
+}
+
+func newTestComponent(t *testing.T, provider component.Provider, cfg string) *Component {
+	t.Helper()
+	c, _ := newTestComponentWithExports(t, provider, cfg)
+	return c
+}
+
+func newTestComponentWithExports(t *testing.T, provider component.Provider, cfg string) (*Component, *extension.Exports) {
+	t.Helper()
+
+	var args Arguments
+	require.NoError(t, syntax.Unmarshal([]byte(cfg), &args))
+
+	var exports extension.Exports
+	c, err := New(component.Options{
+		ID:         "otelcol.extension.health_check.default",
+		Logger:     util.TestLogger(t),
+		Registerer: prometheus.NewRegistry(),
+		OnStateChange: func(e component.Exports) {
+			exports = e.(extension.Exports)
+		},
+		GetServiceData: func(name string) (interface{}, error) {
+			require.Equal(t, http_service.ServiceName, name)
+			return http_service.Data{ComponentProvider: provider}, nil
+		},
+	}, args)
+	require.NoError(t, err)
+	return c, &exports
+}
+
+func get(t *testing.T, c *Component) (int, string) {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	c.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	return rec.Code, rec.Body.String()
+}
+
+func TestHealthCheck_AllOtelcolComponents(t *testing.T) {
+	provider := &fakeProvider{infos: []*component.Info{
+		newInfo("otelcol.receiver.otlp", "default", component.HealthTypeHealthy),
+		newInfo("otelcol.exporter.otlp", "default", component.HealthTypeHealthy),
+		newInfo("otelcol.extension.health_check", "default", component.HealthTypeHealthy),
+		newInfo("prometheus.scrape", "default", component.HealthTypeUnhealthy),
+	}}
+	c := newTestComponent(t, provider, ``)
+
+	code, body := get(t, c)
+	require.Equal(t, http.StatusOK, code)
+
+	var resp response
+	require.NoError(t, json.Unmarshal([]byte(body), &resp))
+	require.Equal(t, "Server available", resp.Status)
+	require.Equal(t, map[string]componentStatus{
+		"otelcol.receiver.otlp.default": {Health: "healthy"},
+		"otelcol.exporter.otlp.default": {Health: "healthy"},
+	}, resp.Components)
+
+	// A single unhealthy otelcol component makes the whole check fail.
+	provider.infos[1].Health = component.Health{Health: component.HealthTypeUnhealthy, Message: "connection refused"}
+
+	code, body = get(t, c)
+	require.Equal(t, http.StatusServiceUnavailable, code)
+	require.NoError(t, json.Unmarshal([]byte(body), &resp))
+	require.Equal(t, "Server not available", resp.Status)
+	require.Equal(t, componentStatus{Health: "unhealthy", Message: "connection refused"}, resp.Components["otelcol.exporter.otlp.default"])
+}
+
+func TestHealthCheck_ListedComponents(t *testing.T) {
+	provider := &fakeProvider{infos: []*component.Info{
+		newInfo("otelcol.receiver.otlp", "default", component.HealthTypeHealthy),
+		newInfo("otelcol.exporter.otlp", "default", component.HealthTypeUnhealthy),
+		newInfo("prometheus.scrape", "default", component.HealthTypeHealthy),
+	}}
+	c := newTestComponent(t, provider, `
+		components = ["otelcol.receiver.otlp.default", "prometheus.scrape.default"]
+	`)
+
+	code, _ := get(t, c)
+	require.Equal(t, http.StatusOK, code)
+
+	// Components which don't exist are reported as unhealthy.
+	require.NoError(t, c.Update(Arguments{Components: []string{"otelcol.receiver.otlp.missing"}}))
+
+	code, body := get(t, c)
+	require.Equal(t, http.StatusServiceUnavailable, code)
+
+	var resp response
+	require.NoError(t, json.Unmarshal([]byte(body), &resp))
+	require.Equal(t, componentStatus{Health: "missing", Message: "component not found"}, resp.Components["otelcol.receiver.otlp.missing"])
+}
+
+func TestHealthCheck_UnknownHealth(t *testing.T) {
+	provider := &fakeProvider{infos: []*component.Info{
+		newInfo("otelcol.receiver.otlp", "default", component.HealthTypeUnknown),
+	}}
+	c := newTestComponent(t, provider, ``)
+
+	code, _ := get(t, c)
+	require.Equal(t, http.StatusServiceUnavailable, code)
+}
+
+func TestHealthCheck_ResponseBody(t *testing.T) {
+	provider := &fakeProvider{infos: []*component.Info{
+		newInfo("otelcol.receiver.otlp", "default", component.HealthTypeHealthy),
+	}}
+	c := newTestComponent(t, provider, `
+		response_body {
+			healthy   = "I'm OK"
+			unhealthy = "I'm not well"
+		}
+	`)
+
+	code, body := get(t, c)
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, "I'm OK", body)
+
+	provider.infos[0].Health.Health = component.HealthTypeExited
+
+	code, body = get(t, c)
+	require.Equal(t, http.StatusServiceUnavailable, code)
+	require.Equal(t, "I'm not well", body)
+}
+
+func TestHealthCheck_ExportsHandler(t *testing.T) {
+	_, exports := newTestComponentWithExports(t, &fakeProvider{}, `
+		response_body {
+			healthy = "I'm OK"
+		}
+	`)
+
+	require.NotNil(t, exports.Handler)
+	require.Equal(t, "health_check/otelcol.extension.health_check.default", exports.Handler.ID.String())
+
+	ext, ok := exports.Handler.Extension.(*healthCheckExtension)
+	require.True(t, ok)
+	require.Equal(t, &healthcheckextension.ResponseBodySettings{Healthy: "I'm OK"}, ext.config.ResponseBody)
+}
@@ -0,0 +1,122 @@
+// Package logdedup provides an otelcol.processor.logdedup component.
+package logdedup
+
+import (
+	"time"
+
+	"github.com/grafana/alloy/internal/component"
+	"github.com/grafana/alloy/internal/component/otelcol"
+	otelcolCfg "github.com/grafana/alloy/internal/component/otelcol/config"
+	"github.com/grafana/alloy/internal/component/otelcol/processor"
+	"github.com/grafana/alloy/internal/featuregate"
+	"github.com/grafana/alloy/syntax"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/logdedupprocessor"
+	otelcomponent "go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+func init() {
+	component.Register(component.Registration{
+		Name:      "otelcol.processor.logdedup",
+		Stability: featuregate.StabilityExperimental,
+		Args:      Arguments{},
+		Exports:   otelcol.ConsumerExports{},
+
+		Build: func(opts component.Options, args component.Arguments) (component.Component, error) {
+			fact := logdedupprocessor.NewFactory()
+			return processor.New(opts, fact, args.(Arguments))
+		},
+	})
+}
+
+// Arguments configures the otelcol.processor.logdedup component.
+type Arguments struct {
+	// LogCountAttribute is the name of the attribute holding the number of
+	// deduplicated log records.
+	LogCountAttribute string `alloy:"log_count_attribute,attr,optional"`
+
+	// Interval is how often deduplicated logs are emitted.
+	Interval time.Duration `alloy:"interval,attr,optional"`
+
+	// Timezone is the timezone of the first and last observed timestamps.
+	Timezone string `alloy:"timezone,attr,optional"`
+
+	// ExcludeFields are fields ignored when comparing log records.
+	ExcludeFields []string `alloy:"exclude_fields,attr,optional"`
+
+	// IncludeFields are the only fields compared between log records.
+	IncludeFields []string `alloy:"include_fields,attr,optional"`
+
+	// Conditions are OTTL conditions a log record must match to be
+	// deduplicated. Other log records are passed through unchanged.
+	Conditions []string `alloy:"conditions,attr,optional"`
+
+	// Output configures where to send processed data. Required.
+	Output *otelcol.ConsumerArguments `alloy:"output,block"`
+
+	// DebugMetrics configures component internal metrics. Optional.
+	DebugMetrics otelcolCfg.DebugMetricsArguments `alloy:"debug_metrics,block,optional"`
+}
+
+var (
+	_ processor.Arguments = Arguments{}
+	_ syntax.Defaulter    = (*Arguments)(nil)
+	_ syntax.Validator    = (*Arguments)(nil)
+)
+
+// DefaultArguments holds default settings for Arguments.
+var DefaultArguments = Arguments{
+	LogCountAttribute: "log_count",
+	Interval:          10 * time.Second,
+	Timezone:          "UTC",
+	ExcludeFields:     []string{},
+	IncludeFields:     []string{},
+	Conditions:        []string{},
+}
+
+// SetToDefault implements syntax.Defaulter.
+func (args *Arguments) SetToDefault() {
+	*args = DefaultArguments
+	args.DebugMetrics.SetToDefault()
+}
+
+// Validate implements syntax.Validator.
+func (args *Arguments) Validate() error {
+	cfg, err := args.Convert()
+	if err != nil {
+		return err
+	}
+	return cfg.(*logdedupprocessor.Config).Validate()
+}
+
+// Convert implements processor.Arguments.
+func (args Arguments) Convert() (otelcomponent.Config, error) {
+	return &logdedupprocessor.Config{
+		LogCountAttribute: args.LogCountAttribute,
+		Interval:          args.Interval,
+		Timezone:          args.Timezone,
+		ExcludeFields:     args.ExcludeFields,
+		IncludeFields:     args.IncludeFields,
+		Conditions:        args.Conditions,
+	}, nil
+}
+
+// Extensions implements processor.Arguments.
+func (args Arguments) Extensions() map[otelcomponent.ID]otelcomponent.Component {
+	return nil
+}
+
+// Exporters implements processor.Arguments.
+func (args Arguments) Exporters() map[pipeline.Signal]map[otelcomponent.ID]otelcomponent.Component {
+	return nil
+}
+
+// NextConsumers implements processor.Arguments.
+func (args Arguments) NextConsumers() *otelcol.ConsumerArguments {
+	return args.Output
+}
+
+// DebugMetricsConfig implements processor.Arguments.
+func (args Arguments) DebugMetricsConfig() otelcolCfg.DebugMetricsArguments {
+	return args.DebugMetrics
+}
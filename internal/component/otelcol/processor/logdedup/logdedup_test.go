@@ -0,0 +1,103 @@
+package logdedup_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/grafana/alloy/internal/component/otelcol/processor/logdedup"
+	"github.com/grafana/alloy/syntax"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/logdedupprocessor"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArguments_UnmarshalAlloy(t *testing.T) {
+	tests := []struct {
+		testName string
+		cfg      string
+		expected *logdedupprocessor.Config
+		errMsg   string
+	}{
+		{
+			testName: "Defaults",
+			cfg: `
+			output {}
+			`,
+			expected: &logdedupprocessor.Config{
+				LogCountAttribute: "log_count",
+				Interval:          10 * time.Second,
+				Timezone:          "UTC",
+				ExcludeFields:     []string{},
+				IncludeFields:     []string{},
+				Conditions:        []string{},
+			},
+		},
+		{
+			testName: "AllArguments",
+			cfg: `
+			log_count_attribute = "dedup_count"
+			interval            = "1m"
+			timezone            = "America/New_York"
+			exclude_fields      = ["body.timestamp", "attributes.host\\.name"]
+			conditions          = ["severity_number < SEVERITY_NUMBER_WARN"]
+			output {}
+			`,
+			expected: &logdedupprocessor.Config{
+				LogCountAttribute: "dedup_count",
+				Interval:          time.Minute,
+				Timezone:          "America/New_York",
+				ExcludeFields:     []string{"body.timestamp", "attributes.host\\.name"},
+				IncludeFields:     []string{},
+				Conditions:        []string{"severity_number < SEVERITY_NUMBER_WARN"},
+			},
+		},
+		{
+			testName: "InvalidInterval",
+			cfg: `
+			interval = "0s"
+			output {}
+			`,
+			errMsg: "interval must be greater than 0",
+		},
+		{
+			testName: "InvalidTimezone",
+			cfg: `
+			timezone = "Mars/Olympus_Mons"
+			output {}
+			`,
+			errMsg: "timezone is invalid",
+		},
+		{
+			testName: "ExcludeAndIncludeFields",
+			cfg: `
+			exclude_fields = ["attributes.id"]
+			include_fields = ["attributes.host"]
+			output {}
+			`,
+			errMsg: "cannot define both exclude_fields and include_fields",
+		},
+		{
+			testName: "ExcludeBody",
+			cfg: `
+			exclude_fields = ["body"]
+			output {}
+			`,
+			errMsg: "cannot exclude the entire body",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			var args logdedup.Arguments
+			err := syntax.Unmarshal([]byte(tt.cfg), &args)
+			if tt.errMsg != "" {
+				require.ErrorContains(t, err, tt.errMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			actual, err := args.Convert()
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, actual)
+		})
+	}
+}
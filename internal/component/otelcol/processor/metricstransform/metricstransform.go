@@ -0,0 +1,270 @@
+// Package metricstransform provides an otelcol.processor.metricstransform component.
+package metricstransform
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+
+	"github.com/grafana/alloy/internal/component"
+	"github.com/grafana/alloy/internal/component/otelcol"
+	otelcolCfg "github.com/grafana/alloy/internal/component/otelcol/config"
+	"github.com/grafana/alloy/internal/component/otelcol/processor"
+	"github.com/grafana/alloy/internal/featuregate"
+	"github.com/grafana/alloy/syntax"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/metricstransformprocessor"
+	otelcomponent "go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+func init() {
+	component.Register(component.Registration{
+		Name:      "otelcol.processor.metricstransform",
+		Stability: featuregate.StabilityExperimental,
+		Args:      Arguments{},
+		Exports:   otelcol.ConsumerExports{},
+
+		Build: func(opts component.Options, args component.Arguments) (component.Component, error) {
+			fact := metricstransformprocessor.NewFactory()
+			return processor.New(opts, fact, args.(Arguments))
+		},
+	})
+}
+
+// Supported values for Transform.MatchType.
+const (
+	MatchTypeStrict = "strict"
+	MatchTypeRegexp = "regexp"
+)
+
+var (
+	matchTypes       = []string{MatchTypeStrict, MatchTypeRegexp}
+	actions          = []string{"insert", "update", "combine", "group"}
+	operationActions = []string{
+		"add_label",
+		"update_label",
+		"delete_label_value",
+		"toggle_scalar_data_type",
+		"experimental_scale_value",
+		"aggregate_labels",
+		"aggregate_label_values",
+	}
+	aggregationTypes = []string{"sum", "mean", "min", "max", "median", "count"}
+	submatchCases    = []string{"lower", "upper"}
+)
+
+// Arguments configures the otelcol.processor.metricstransform component.
+type Arguments struct {
+	Transforms []Transform `alloy:"transform,block,optional"`
+
+	// Output configures where to send processed data. Required.
+	Output *otelcol.ConsumerArguments `alloy:"output,block"`
+
+	// DebugMetrics configures component internal metrics. Optional.
+	DebugMetrics otelcolCfg.DebugMetricsArguments `alloy:"debug_metrics,block,optional"`
+}
+
+// Transform is a transformation applied to the metrics matching a filter.
+type Transform struct {
+	Include     string            `alloy:"include,attr"`
+	MatchType   string            `alloy:"match_type,attr,optional"`
+	MatchLabels map[string]string `alloy:"experimental_match_labels,attr,optional"`
+
+	Action              string            `alloy:"action,attr"`
+	NewName             string            `alloy:"new_name,attr,optional"`
+	GroupResourceLabels map[string]string `alloy:"group_resource_labels,attr,optional"`
+	AggregationType     string            `alloy:"aggregation_type,attr,optional"`
+	SubmatchCase        string            `alloy:"submatch_case,attr,optional"`
+
+	Operations []Operation `alloy:"operation,block,optional"`
+}
+
+// SetToDefault implements syntax.Defaulter.
+func (t *Transform) SetToDefault() {
+	*t = Transform{MatchType: MatchTypeStrict}
+}
+
+// Operation is an operation performed on the metrics produced by a Transform.
+type Operation struct {
+	Action           string        `alloy:"action,attr"`
+	Label            string        `alloy:"label,attr,optional"`
+	NewLabel         string        `alloy:"new_label,attr,optional"`
+	LabelSet         []string      `alloy:"label_set,attr,optional"`
+	AggregationType  string        `alloy:"aggregation_type,attr,optional"`
+	AggregatedValues []string      `alloy:"aggregated_values,attr,optional"`
+	NewValue         string        `alloy:"new_value,attr,optional"`
+	LabelValue       string        `alloy:"label_value,attr,optional"`
+	Scale            float64       `alloy:"experimental_scale,attr,optional"`
+	ValueActions     []ValueAction `alloy:"value_action,block,optional"`
+}
+
+// ValueAction renames a label value.
+type ValueAction struct {
+	Value    string `alloy:"value,attr"`
+	NewValue string `alloy:"new_value,attr"`
+}
+
+var (
+	_ processor.Arguments = Arguments{}
+	_ syntax.Defaulter    = (*Arguments)(nil)
+	_ syntax.Validator    = (*Arguments)(nil)
+	_ syntax.Defaulter    = (*Transform)(nil)
+)
+
+// SetToDefault implements syntax.Defaulter.
+func (args *Arguments) SetToDefault() {
+	*args = Arguments{}
+	args.DebugMetrics.SetToDefault()
+}
+
+// Validate implements syntax.Validator. The upstream processor only checks
+// its configuration when it's built, so the same checks are made here to
+// report errors at load time.
+func (args *Arguments) Validate() error {
+	for i, t := range args.Transforms {
+		if err := t.validate(); err != nil {
+			return fmt.Errorf("transform %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+func (t Transform) validate() error {
+	if t.Include == "" {
+		return fmt.Errorf("missing required field %q", "include")
+	}
+	if !slices.Contains(matchTypes, t.MatchType) {
+		return fmt.Errorf("%q must be one of %q", "match_type", matchTypes)
+	}
+	if t.MatchType == MatchTypeRegexp {
+		if _, err := regexp.Compile(t.Include); err != nil {
+			return fmt.Errorf("%q: %w", "include", err)
+		}
+	}
+	if !slices.Contains(actions, t.Action) {
+		return fmt.Errorf("%q must be one of %q", "action", actions)
+	}
+	if t.Action == "insert" && t.NewName == "" {
+		return fmt.Errorf("missing required field %q while %q is %q", "new_name", "action", "insert")
+	}
+	if t.Action == "group" && t.GroupResourceLabels == nil {
+		return fmt.Errorf("missing required field %q while %q is %q", "group_resource_labels", "action", "group")
+	}
+	if t.AggregationType != "" && !slices.Contains(aggregationTypes, t.AggregationType) {
+		return fmt.Errorf("%q must be one of %q", "aggregation_type", aggregationTypes)
+	}
+	if t.SubmatchCase != "" && !slices.Contains(submatchCases, t.SubmatchCase) {
+		return fmt.Errorf("%q must be one of %q", "submatch_case", submatchCases)
+	}
+
+	for i, op := range t.Operations {
+		if err := op.validate(); err != nil {
+			return fmt.Errorf("operation %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+func (op Operation) validate() error {
+	if !slices.Contains(operationActions, op.Action) {
+		return fmt.Errorf("%q must be one of %q", "action", operationActions)
+	}
+	switch op.Action {
+	case "update_label":
+		if op.Label == "" {
+			return fmt.Errorf("missing required field %q while %q is %q", "label", "action", op.Action)
+		}
+	case "add_label":
+		if op.NewLabel == "" {
+			return fmt.Errorf("missing required field %q while %q is %q", "new_label", "action", op.Action)
+		}
+		if op.NewValue == "" {
+			return fmt.Errorf("missing required field %q while %q is %q", "new_value", "action", op.Action)
+		}
+	case "experimental_scale_value":
+		if op.Scale == 0 {
+			return fmt.Errorf("missing required field %q while %q is %q", "experimental_scale", "action", op.Action)
+		}
+	}
+	if op.AggregationType != "" && !slices.Contains(aggregationTypes, op.AggregationType) {
+		return fmt.Errorf("%q must be one of %q", "aggregation_type", aggregationTypes)
+	}
+	return nil
+}
+
+// Convert implements processor.Arguments.
+func (args Arguments) Convert() (otelcomponent.Config, error) {
+	out := metricstransformprocessor.NewFactory().CreateDefaultConfig().(*metricstransformprocessor.Config)
+
+	// The transform configs are unexported upstream types, so they're
+	// unmarshaled through confmap.
+	conf := confmap.NewFromStringMap(map[string]any{
+		"transforms": args.transforms(),
+	})
+	if err := conf.Unmarshal(out); err != nil {
+		return nil, fmt.Errorf("decoding metricstransform config: %w", err)
+	}
+
+	return out, nil
+}
+
+func (args Arguments) transforms() []any {
+	transforms := make([]any, 0, len(args.Transforms))
+	for _, t := range args.Transforms {
+		operations := make([]any, 0, len(t.Operations))
+		for _, op := range t.Operations {
+			valueActions := make([]any, 0, len(op.ValueActions))
+			for _, va := range op.ValueActions {
+				valueActions = append(valueActions, map[string]any{
+					"value":     va.Value,
+					"new_value": va.NewValue,
+				})
+			}
+			operations = append(operations, map[string]any{
+				"action":             op.Action,
+				"label":              op.Label,
+				"new_label":          op.NewLabel,
+				"label_set":          op.LabelSet,
+				"aggregation_type":   op.AggregationType,
+				"aggregated_values":  op.AggregatedValues,
+				"new_value":          op.NewValue,
+				"label_value":        op.LabelValue,
+				"experimental_scale": op.Scale,
+				"value_actions":      valueActions,
+			})
+		}
+		transforms = append(transforms, map[string]any{
+			"include":                   t.Include,
+			"match_type":                t.MatchType,
+			"experimental_match_labels": t.MatchLabels,
+			"action":                    t.Action,
+			"new_name":                  t.NewName,
+			"group_resource_labels":     t.GroupResourceLabels,
+			"aggregation_type":          t.AggregationType,
+			"submatch_case":             t.SubmatchCase,
+			"operations":                operations,
+		})
+	}
+	return transforms
+}
+
+// Extensions implements processor.Arguments.
+func (args Arguments) Extensions() map[otelcomponent.ID]otelcomponent.Component {
+	return nil
+}
+
+// Exporters implements processor.Arguments.
+func (args Arguments) Exporters() map[pipeline.Signal]map[otelcomponent.ID]otelcomponent.Component {
+	return nil
+}
+
+// NextConsumers implements processor.Arguments.
+func (args Arguments) NextConsumers() *otelcol.ConsumerArguments {
+	return args.Output
+}
+
+// DebugMetricsConfig implements processor.Arguments.
+func (args Arguments) DebugMetricsConfig() otelcolCfg.DebugMetricsArguments {
+	return args.DebugMetrics
+}
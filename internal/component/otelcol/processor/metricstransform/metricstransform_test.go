@@ -0,0 +1,290 @@
+package metricstransform_test
+
+import (
+	"testing"
+
+	"github.com/grafana/alloy/internal/component/otelcol/processor/metricstransform"
+	"github.com/grafana/alloy/internal/component/otelcol/processor/processortest"
+	"github.com/grafana/alloy/internal/runtime/componenttest"
+	"github.com/grafana/alloy/internal/util"
+	"github.com/grafana/alloy/syntax"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/metricstransformprocessor"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArguments_UnmarshalAlloy(t *testing.T) {
+	cfg := `
+		transform {
+			include                   = "^system\\.cpu\\.(.*)$"
+			match_type                = "regexp"
+			experimental_match_labels = { "state" = "idle" }
+			action                    = "insert"
+			new_name                  = "host.cpu.${1}"
+			submatch_case             = "lower"
+
+			operation {
+				action   = "update_label"
+				label    = "state"
+				new_label = "cpu.state"
+
+				value_action {
+					value     = "idle"
+					new_value = "unused"
+				}
+			}
+
+			operation {
+				action           = "aggregate_labels"
+				label_set        = ["cpu.state"]
+				aggregation_type = "sum"
+			}
+		}
+
+		transform {
+			include = "requests"
+			action  = "update"
+
+			operation {
+				action             = "experimental_scale_value"
+				experimental_scale = 1000
+			}
+		}
+
+		output {}
+	`
+
+	var args metricstransform.Arguments
+	require.NoError(t, syntax.Unmarshal([]byte(cfg), &args))
+
+	actual, err := args.Convert()
+	require.NoError(t, err)
+	out := actual.(*metricstransformprocessor.Config)
+	require.Len(t, out.Transforms, 2)
+
+	insert := out.Transforms[0]
+	require.Equal(t, `^system\.cpu\.(.*)$`, insert.MetricIncludeFilter.Include)
+	require.EqualValues(t, "regexp", insert.MetricIncludeFilter.MatchType)
+	require.Equal(t, map[string]string{"state": "idle"}, insert.MetricIncludeFilter.MatchLabels)
+	require.EqualValues(t, "insert", insert.Action)
+	require.Equal(t, "host.cpu.${1}", insert.NewName)
+	require.EqualValues(t, "lower", insert.SubmatchCase)
+	require.Len(t, insert.Operations, 2)
+	require.EqualValues(t, "update_label", insert.Operations[0].Action)
+	require.Equal(t, "state", insert.Operations[0].Label)
+	require.Equal(t, "cpu.state", insert.Operations[0].NewLabel)
+	require.Len(t, insert.Operations[0].ValueActions, 1)
+	require.Equal(t, "idle", insert.Operations[0].ValueActions[0].Value)
+	require.Equal(t, "unused", insert.Operations[0].ValueActions[0].NewValue)
+	require.EqualValues(t, "aggregate_labels", insert.Operations[1].Action)
+	require.Equal(t, []string{"cpu.state"}, insert.Operations[1].LabelSet)
+	require.EqualValues(t, "sum", insert.Operations[1].AggregationType)
+
+	update := out.Transforms[1]
+	require.Equal(t, "requests", update.MetricIncludeFilter.Include)
+	require.EqualValues(t, "strict", update.MetricIncludeFilter.MatchType)
+	require.EqualValues(t, "update", update.Action)
+	require.Len(t, update.Operations, 1)
+	require.Equal(t, 1000.0, update.Operations[0].Scale)
+}
+
+func TestArguments_Validate(t *testing.T) {
+	tests := []struct {
+		testName string
+		cfg      string
+		errMsg   string
+	}{
+		{
+			testName: "InvalidMatchType",
+			cfg: `
+			transform {
+				include    = "requests"
+				match_type = "glob"
+				action     = "update"
+			}
+			output {}
+			`,
+			errMsg: `transform 1: "match_type" must be one of ["strict" "regexp"]`,
+		},
+		{
+			testName: "InvalidRegexp",
+			cfg: `
+			transform {
+				include    = "("
+				match_type = "regexp"
+				action     = "update"
+			}
+			output {}
+			`,
+			errMsg: `transform 1: "include": error parsing regexp`,
+		},
+		{
+			testName: "InsertWithoutNewName",
+			cfg: `
+			transform {
+				include = "requests"
+				action  = "insert"
+			}
+			output {}
+			`,
+			errMsg: `transform 1: missing required field "new_name" while "action" is "insert"`,
+		},
+		{
+			testName: "GroupWithoutResourceLabels",
+			cfg: `
+			transform {
+				include = "requests"
+				action  = "group"
+			}
+			output {}
+			`,
+			errMsg: `transform 1: missing required field "group_resource_labels" while "action" is "group"`,
+		},
+		{
+			testName: "InvalidOperationAction",
+			cfg: `
+			transform {
+				include = "requests"
+				action  = "update"
+			}
+			transform {
+				include = "errors"
+				action  = "update"
+				operation {
+					action = "drop_label"
+				}
+			}
+			output {}
+			`,
+			errMsg: `transform 2: operation 1: "action" must be one of`,
+		},
+		{
+			testName: "AddLabelWithoutNewValue",
+			cfg: `
+			transform {
+				include = "requests"
+				action  = "update"
+				operation {
+					action    = "add_label"
+					new_label = "env"
+				}
+			}
+			output {}
+			`,
+			errMsg: `transform 1: operation 1: missing required field "new_value" while "action" is "add_label"`,
+		},
+		{
+			testName: "InvalidAggregationType",
+			cfg: `
+			transform {
+				include          = "requests"
+				action           = "combine"
+				new_name         = "all_requests"
+				aggregation_type = "avg"
+			}
+			output {}
+			`,
+			errMsg: `transform 1: "aggregation_type" must be one of`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			var args metricstransform.Arguments
+			err := syntax.Unmarshal([]byte(tt.cfg), &args)
+			require.ErrorContains(t, err, tt.errMsg)
+		})
+	}
+}
+
+func Test_Metrics(t *testing.T) {
+	cfg := `
+		transform {
+			include  = "requests"
+			action   = "update"
+			new_name = "http.requests"
+
+			operation {
+				action    = "add_label"
+				new_label = "env"
+				new_value = "prod"
+			}
+		}
+
+		output {
+			// no-op: will be overridden by test code.
+		}
+	`
+	var args metricstransform.Arguments
+	require.NoError(t, syntax.Unmarshal([]byte(cfg), &args))
+
+	inputMetrics := `{
+		"resourceMetrics": [{
+			"scopeMetrics": [{
+				"metrics": [{
+					"name": "requests",
+					"gauge": {
+						"dataPoints": [{
+							"timeUnixNano": "1581452773000000789",
+							"asInt": "42"
+						}]
+					}
+				},
+				{
+					"name": "errors",
+					"gauge": {
+						"dataPoints": [{
+							"timeUnixNano": "1581452773000000789",
+							"asInt": "1"
+						}]
+					}
+				}]
+			}]
+		}]
+	}`
+	expectedMetrics := `{
+		"resourceMetrics": [{
+			"scopeMetrics": [{
+				"metrics": [{
+					"name": "http.requests",
+					"gauge": {
+						"dataPoints": [{
+							"attributes": [{
+								"key": "env",
+								"value": { "stringValue": "prod" }
+							}],
+							"timeUnixNano": "1581452773000000789",
+							"asInt": "42"
+						}]
+					}
+				},
+				{
+					"name": "errors",
+					"gauge": {
+						"dataPoints": [{
+							"timeUnixNano": "1581452773000000789",
+							"asInt": "1"
+						}]
+					}
+				}]
+			}]
+		}]
+	}`
+
+	ctx := componenttest.TestContext(t)
+	l := util.TestLogger(t)
+
+	ctrl, err := componenttest.NewControllerFromID(l, "otelcol.processor.metricstransform")
+	require.NoError(t, err)
+
+	testSignal := processortest.NewMetricSignal(inputMetrics, expectedMetrics)
+	args.Output = testSignal.MakeOutput()
+
+	processortest.TestRunProcessor(processortest.ProcessorRunConfig{
+		Ctx:        ctx,
+		T:          t,
+		Args:       args,
+		TestSignal: testSignal,
+		Ctrl:       ctrl,
+		L:          l,
+	})
+}
@@ -0,0 +1,217 @@
+// Package redaction provides an otelcol.processor.redaction component.
+package redaction
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/grafana/alloy/internal/component"
+	"github.com/grafana/alloy/internal/component/loki/secretfilter"
+	"github.com/grafana/alloy/internal/component/otelcol"
+	otelcolCfg "github.com/grafana/alloy/internal/component/otelcol/config"
+	"github.com/grafana/alloy/internal/component/otelcol/processor"
+	"github.com/grafana/alloy/internal/featuregate"
+	"github.com/grafana/alloy/syntax"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/redactionprocessor"
+	otelcomponent "go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+func init() {
+	component.Register(component.Registration{
+		Name:      "otelcol.processor.redaction",
+		Stability: featuregate.StabilityExperimental,
+		Args:      Arguments{},
+		Exports:   otelcol.ConsumerExports{},
+
+		Build: func(opts component.Options, args component.Arguments) (component.Component, error) {
+			fact := redactionprocessor.NewFactory()
+			return processor.New(opts, fact, args.(Arguments))
+		},
+	})
+}
+
+// The supported values of the summary argument.
+const (
+	summaryDebug  = "debug"
+	summaryInfo   = "info"
+	summarySilent = "silent"
+)
+
+// Arguments configures the otelcol.processor.redaction component.
+type Arguments struct {
+	AllowAllKeys       bool     `alloy:"allow_all_keys,attr,optional"`
+	AllowedKeys        []string `alloy:"allowed_keys,attr,optional"`
+	IgnoredKeys        []string `alloy:"ignored_keys,attr,optional"`
+	BlockedKeyPatterns []string `alloy:"blocked_key_patterns,attr,optional"`
+	BlockedValues      []string `alloy:"blocked_values,attr,optional"`
+	AllowedValues      []string `alloy:"allowed_values,attr,optional"`
+	HashFunction       string   `alloy:"hash_function,attr,optional"`
+	RedactAllTypes     bool     `alloy:"redact_all_types,attr,optional"`
+	Summary            string   `alloy:"summary,attr,optional"`
+
+	// Gitleaks configures masking values which match the rules of a gitleaks
+	// config. Optional.
+	Gitleaks *GitleaksArguments `alloy:"gitleaks,block,optional"`
+
+	// Output configures where to send processed data. Required.
+	Output *otelcol.ConsumerArguments `alloy:"output,block"`
+
+	// DebugMetrics configures component internal metrics. Optional.
+	DebugMetrics otelcolCfg.DebugMetricsArguments `alloy:"debug_metrics,block,optional"`
+}
+
+// GitleaksArguments configures the gitleaks rules used to detect secrets.
+// The rules are the same ones used by the loki.secretfilter component.
+type GitleaksArguments struct {
+	// Config is the path to a custom gitleaks.toml file. If empty, the file
+	// embedded in Alloy is used.
+	Config string `alloy:"config,attr,optional"`
+	// Types limits the rules to the ones whose ID starts with one of the
+	// types. If empty, all rules are used.
+	Types []string `alloy:"types,attr,optional"`
+	// IncludeGeneric includes the generic API key rule.
+	IncludeGeneric bool `alloy:"include_generic,attr,optional"`
+}
+
+var (
+	_ processor.Arguments = Arguments{}
+	_ syntax.Defaulter    = (*Arguments)(nil)
+	_ syntax.Validator    = (*Arguments)(nil)
+)
+
+// DefaultArguments holds default settings for Arguments.
+var DefaultArguments = Arguments{
+	Summary: summarySilent,
+}
+
+// SetToDefault implements syntax.Defaulter.
+func (args *Arguments) SetToDefault() {
+	*args = DefaultArguments
+	args.DebugMetrics.SetToDefault()
+}
+
+// Validate implements syntax.Validator.
+func (args *Arguments) Validate() error {
+	var errs error
+
+	switch args.Summary {
+	case summaryDebug, summaryInfo, summarySilent:
+	default:
+		errs = errors.Join(errs, fmt.Errorf("summary must be one of %q, %q, or %q", summaryDebug, summaryInfo, summarySilent))
+	}
+
+	var hash redactionprocessor.HashFunction
+	if err := hash.UnmarshalText([]byte(args.HashFunction)); err != nil {
+		errs = errors.Join(errs, err)
+	}
+
+	for name, patterns := range map[string][]string{
+		"blocked_key_patterns": args.BlockedKeyPatterns,
+		"blocked_values":       args.BlockedValues,
+		"allowed_values":       args.AllowedValues,
+	} {
+		for _, pattern := range patterns {
+			if _, err := regexp.Compile(pattern); err != nil {
+				errs = errors.Join(errs, fmt.Errorf("invalid regular expression in %s: %w", name, err))
+			}
+		}
+	}
+
+	return errs
+}
+
+// Convert implements processor.Arguments.
+func (args Arguments) Convert() (otelcomponent.Config, error) {
+	var hash redactionprocessor.HashFunction
+	if err := hash.UnmarshalText([]byte(args.HashFunction)); err != nil {
+		return nil, err
+	}
+
+	blockedValues := args.BlockedValues
+	if args.Gitleaks != nil {
+		patterns, err := args.Gitleaks.patterns()
+		if err != nil {
+			return nil, err
+		}
+		blockedValues = append(append([]string{}, blockedValues...), patterns...)
+	}
+
+	return &redactionprocessor.Config{
+		AllowAllKeys:       args.AllowAllKeys,
+		AllowedKeys:        args.AllowedKeys,
+		IgnoredKeys:        args.IgnoredKeys,
+		BlockedKeyPatterns: args.BlockedKeyPatterns,
+		BlockedValues:      blockedValues,
+		AllowedValues:      args.AllowedValues,
+		HashFunction:       hash,
+		RedactAllTypes:     args.RedactAllTypes,
+		Summary:            args.Summary,
+	}, nil
+}
+
+// patterns returns the regular expressions of the gitleaks rules to mask
+// values with.
+func (args *GitleaksArguments) patterns() ([]string, error) {
+	cfg, err := secretfilter.LoadGitleaksConfig(args.Config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load gitleaks config: %w", err)
+	}
+
+	var patterns []string
+	for _, rule := range cfg.Rules {
+		if rule.Regex == "" || !args.includesRule(rule.ID) {
+			continue
+		}
+
+		re, err := regexp.Compile(rule.Regex)
+		if err != nil {
+			return nil, fmt.Errorf("invalid regular expression in gitleaks rule %q: %w", rule.ID, err)
+		}
+		// A rule matching the empty string would mask every value.
+		if re.MatchString("") {
+			continue
+		}
+		patterns = append(patterns, rule.Regex)
+	}
+	return patterns, nil
+}
+
+// includesRule reports whether the gitleaks rule with the given ID is used.
+func (args *GitleaksArguments) includesRule(id string) bool {
+	id = strings.ToLower(id)
+	if id == "generic-api-key" && !args.IncludeGeneric {
+		return false
+	}
+	if len(args.Types) == 0 {
+		return true
+	}
+	for _, t := range args.Types {
+		if strings.HasPrefix(id, strings.ToLower(t)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Extensions implements processor.Arguments.
+func (args Arguments) Extensions() map[otelcomponent.ID]otelcomponent.Component {
+	return nil
+}
+
+// Exporters implements processor.Arguments.
+func (args Arguments) Exporters() map[pipeline.Signal]map[otelcomponent.ID]otelcomponent.Component {
+	return nil
+}
+
+// NextConsumers implements processor.Arguments.
+func (args Arguments) NextConsumers() *otelcol.ConsumerArguments {
+	return args.Output
+}
+
+// DebugMetricsConfig implements processor.Arguments.
+func (args Arguments) DebugMetricsConfig() otelcolCfg.DebugMetricsArguments {
+	return args.DebugMetrics
+}
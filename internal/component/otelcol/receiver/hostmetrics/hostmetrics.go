@@ -0,0 +1,157 @@
+// Package hostmetrics provides an otelcol.receiver.hostmetrics component.
+package hostmetrics
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/grafana/alloy/internal/component"
+	"github.com/grafana/alloy/internal/component/otelcol"
+	otelcolCfg "github.com/grafana/alloy/internal/component/otelcol/config"
+	"github.com/grafana/alloy/internal/component/otelcol/receiver"
+	"github.com/grafana/alloy/internal/featuregate"
+	"github.com/grafana/alloy/syntax"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver"
+	otelcomponent "go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+func init() {
+	component.Register(component.Registration{
+		Name:      "otelcol.receiver.hostmetrics",
+		Stability: featuregate.StabilityExperimental,
+		Args:      Arguments{},
+
+		Build: func(opts component.Options, args component.Arguments) (component.Component, error) {
+			fact := hostmetricsreceiver.NewFactory()
+			return receiver.New(opts, fact, args.(Arguments))
+		},
+	})
+}
+
+// Arguments configures the otelcol.receiver.hostmetrics component.
+type Arguments struct {
+	RootPath                   string        `alloy:"root_path,attr,optional"`
+	MetadataCollectionInterval time.Duration `alloy:"metadata_collection_interval,attr,optional"`
+
+	Controller otelcol.ControllerArguments `alloy:",squash"`
+
+	// Scrapers. A scraper is enabled when its block is set.
+	CPU        *CPUScraperArguments        `alloy:"cpu,block,optional"`
+	Disk       *DiskScraperArguments       `alloy:"disk,block,optional"`
+	Filesystem *FilesystemScraperArguments `alloy:"filesystem,block,optional"`
+	Load       *LoadScraperArguments       `alloy:"load,block,optional"`
+	Memory     *MemoryScraperArguments     `alloy:"memory,block,optional"`
+	Network    *NetworkScraperArguments    `alloy:"network,block,optional"`
+	Paging     *PagingScraperArguments     `alloy:"paging,block,optional"`
+	Processes  *ProcessesScraperArguments  `alloy:"processes,block,optional"`
+	Process    *ProcessScraperArguments    `alloy:"process,block,optional"`
+
+	// DebugMetrics configures component internal metrics. Optional.
+	DebugMetrics otelcolCfg.DebugMetricsArguments `alloy:"debug_metrics,block,optional"`
+
+	// Output configures where to send received data. Required.
+	Output *otelcol.ConsumerArguments `alloy:"output,block"`
+}
+
+var (
+	_ receiver.Arguments = Arguments{}
+	_ syntax.Defaulter   = (*Arguments)(nil)
+	_ syntax.Validator   = (*Arguments)(nil)
+)
+
+// SetToDefault implements syntax.Defaulter.
+func (args *Arguments) SetToDefault() {
+	*args = Arguments{
+		MetadataCollectionInterval: 5 * time.Minute,
+	}
+	args.Controller.SetToDefault()
+	args.DebugMetrics.SetToDefault()
+}
+
+// Validate implements syntax.Validator.
+func (args *Arguments) Validate() error {
+	if len(args.scrapers()) == 0 {
+		return errors.New("at least one scraper block must be set")
+	}
+	if args.MetadataCollectionInterval < 0 {
+		return errors.New("metadata_collection_interval must not be negative")
+	}
+	return nil
+}
+
+// scrapers returns the upstream configuration of every enabled scraper, keyed
+// by the scraper name.
+func (args *Arguments) scrapers() map[string]any {
+	scrapers := make(map[string]any)
+	if args.CPU != nil {
+		scrapers["cpu"] = args.CPU.toMap()
+	}
+	if args.Disk != nil {
+		scrapers["disk"] = args.Disk.toMap()
+	}
+	if args.Filesystem != nil {
+		scrapers["filesystem"] = args.Filesystem.toMap()
+	}
+	if args.Load != nil {
+		scrapers["load"] = args.Load.toMap()
+	}
+	if args.Memory != nil {
+		scrapers["memory"] = args.Memory.toMap()
+	}
+	if args.Network != nil {
+		scrapers["network"] = args.Network.toMap()
+	}
+	if args.Paging != nil {
+		scrapers["paging"] = args.Paging.toMap()
+	}
+	if args.Processes != nil {
+		scrapers["processes"] = args.Processes.toMap()
+	}
+	if args.Process != nil {
+		scrapers["process"] = args.Process.toMap()
+	}
+	return scrapers
+}
+
+// Convert implements receiver.Arguments.
+func (args Arguments) Convert() (otelcomponent.Config, error) {
+	out := hostmetricsreceiver.NewFactory().CreateDefaultConfig().(*hostmetricsreceiver.Config)
+
+	// The scraper configs are created by factories in an internal upstream
+	// package, so the whole config is unmarshaled through confmap, which in
+	// turn calls the upstream Unmarshal method.
+	conf := confmap.NewFromStringMap(map[string]any{
+		"root_path":                    args.RootPath,
+		"metadata_collection_interval": args.MetadataCollectionInterval,
+		"scrapers":                     args.scrapers(),
+	})
+	if err := conf.Unmarshal(out); err != nil {
+		return nil, fmt.Errorf("decoding hostmetrics config: %w", err)
+	}
+
+	out.ControllerConfig = *args.Controller.Convert()
+	return out, nil
+}
+
+// Extensions implements receiver.Arguments.
+func (args Arguments) Extensions() map[otelcomponent.ID]otelcomponent.Component {
+	return nil
+}
+
+// Exporters implements receiver.Arguments.
+func (args Arguments) Exporters() map[pipeline.Signal]map[otelcomponent.ID]otelcomponent.Component {
+	return nil
+}
+
+// NextConsumers implements receiver.Arguments.
+func (args Arguments) NextConsumers() *otelcol.ConsumerArguments {
+	return args.Output
+}
+
+// DebugMetricsConfig implements receiver.Arguments.
+func (args Arguments) DebugMetricsConfig() otelcolCfg.DebugMetricsArguments {
+	return args.DebugMetrics
+}
@@ -0,0 +1,133 @@
+package hostmetrics_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/grafana/alloy/internal/component/otelcol/receiver/hostmetrics"
+	"github.com/grafana/alloy/syntax"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver"
+	"github.com/stretchr/testify/require"
+	otelcomponent "go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+)
+
+func TestArguments(t *testing.T) {
+	in := `
+		collection_interval = "30s"
+
+		cpu {
+			metrics {
+				system.cpu.utilization {
+					enabled = true
+				}
+			}
+		}
+
+		load {
+			cpu_average = true
+		}
+
+		network {
+			exclude {
+				values = ["lo"]
+			}
+		}
+
+		process {
+			include {
+				values     = ["alloy.*"]
+				match_type = "regexp"
+			}
+			mute_process_name_error = true
+
+			resource_attributes {
+				process.cgroup {
+					enabled = true
+				}
+			}
+		}
+
+		output {}
+	`
+
+	var args hostmetrics.Arguments
+	require.NoError(t, syntax.Unmarshal([]byte(in), &args))
+
+	outAny, err := args.Convert()
+	require.NoError(t, err)
+	out := outAny.(*hostmetricsreceiver.Config)
+
+	require.Equal(t, 30*time.Second, out.CollectionInterval)
+	require.Equal(t, 5*time.Minute, out.MetadataCollectionInterval)
+	require.Len(t, out.Scrapers, 4)
+
+	// The scraper configs are in internal upstream packages, so they're
+	// compared through their confmap representation.
+	cpu := scraperConfig(t, out, "cpu")
+	require.Equal(t, true, cpu["metrics::system.cpu.utilization::enabled"])
+	require.Equal(t, true, cpu["metrics::system.cpu.time::enabled"])
+
+	load := scraperConfig(t, out, "load")
+	require.Equal(t, true, load["cpu_average"])
+
+	network := scraperConfig(t, out, "network")
+	require.Equal(t, []any{"lo"}, network["exclude::interfaces"])
+	require.EqualValues(t, "strict", network["exclude::match_type"])
+
+	process := scraperConfig(t, out, "process")
+	require.Equal(t, []any{"alloy.*"}, process["include::names"])
+	require.EqualValues(t, "regexp", process["include::match_type"])
+	require.Equal(t, true, process["mute_process_name_error"])
+	require.Equal(t, true, process["resource_attributes::process.cgroup::enabled"])
+}
+
+func TestArguments_Validate(t *testing.T) {
+	tests := []struct {
+		name        string
+		cfg         string
+		expectedErr string
+	}{
+		{
+			name:        "no scrapers",
+			cfg:         `output {}`,
+			expectedErr: "at least one scraper block must be set",
+		},
+		{
+			name: "invalid match type",
+			cfg: `
+				disk {
+					include {
+						values     = ["sda"]
+						match_type = "glob"
+					}
+				}
+				output {}
+			`,
+			expectedErr: `match_type must be "strict" or "regexp", got "glob"`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var args hostmetrics.Arguments
+			require.ErrorContains(t, syntax.Unmarshal([]byte(tc.cfg), &args), tc.expectedErr)
+		})
+	}
+}
+
+func scraperConfig(t *testing.T, cfg *hostmetricsreceiver.Config, name string) map[string]any {
+	t.Helper()
+
+	scraperCfg, ok := cfg.Scrapers[otelcomponent.MustNewType(name)]
+	require.True(t, ok, "scraper %s is not enabled", name)
+
+	conf := confmap.New()
+	require.NoError(t, conf.Marshal(scraperCfg))
+
+	out := make(map[string]any)
+	for _, key := range conf.AllKeys() {
+		out[key] = conf.Get(key)
+	}
+	return out
+}
@@ -0,0 +1,343 @@
+package hostmetrics
+
+// This file holds the metric and resource attribute toggles of each scraper.
+// Defaults match the upstream metadata of every scraper.
+
+// CPUMetricsArguments configures the metrics collected by the cpu scraper.
+type CPUMetricsArguments struct {
+	SystemCPUFrequency     MetricArguments `alloy:"system.cpu.frequency,block,optional"`
+	SystemCPULogicalCount  MetricArguments `alloy:"system.cpu.logical.count,block,optional"`
+	SystemCPUPhysicalCount MetricArguments `alloy:"system.cpu.physical.count,block,optional"`
+	SystemCPUTime          MetricArguments `alloy:"system.cpu.time,block,optional"`
+	SystemCPUUtilization   MetricArguments `alloy:"system.cpu.utilization,block,optional"`
+}
+
+// SetToDefault implements syntax.Defaulter.
+func (args *CPUMetricsArguments) SetToDefault() {
+	args.SystemCPUFrequency.Enabled = false
+	args.SystemCPULogicalCount.Enabled = false
+	args.SystemCPUPhysicalCount.Enabled = false
+	args.SystemCPUTime.Enabled = true
+	args.SystemCPUUtilization.Enabled = false
+}
+
+// toMap encodes args to a map for use with confmap.
+func (args *CPUMetricsArguments) toMap() map[string]any {
+	return map[string]any{
+		"system.cpu.frequency":      args.SystemCPUFrequency.toMap(),
+		"system.cpu.logical.count":  args.SystemCPULogicalCount.toMap(),
+		"system.cpu.physical.count": args.SystemCPUPhysicalCount.toMap(),
+		"system.cpu.time":           args.SystemCPUTime.toMap(),
+		"system.cpu.utilization":    args.SystemCPUUtilization.toMap(),
+	}
+}
+
+// DiskMetricsArguments configures the metrics collected by the disk scraper.
+type DiskMetricsArguments struct {
+	SystemDiskIo                MetricArguments `alloy:"system.disk.io,block,optional"`
+	SystemDiskIoTime            MetricArguments `alloy:"system.disk.io_time,block,optional"`
+	SystemDiskMerged            MetricArguments `alloy:"system.disk.merged,block,optional"`
+	SystemDiskOperationTime     MetricArguments `alloy:"system.disk.operation_time,block,optional"`
+	SystemDiskOperations        MetricArguments `alloy:"system.disk.operations,block,optional"`
+	SystemDiskPendingOperations MetricArguments `alloy:"system.disk.pending_operations,block,optional"`
+	SystemDiskWeightedIoTime    MetricArguments `alloy:"system.disk.weighted_io_time,block,optional"`
+}
+
+// SetToDefault implements syntax.Defaulter.
+func (args *DiskMetricsArguments) SetToDefault() {
+	args.SystemDiskIo.Enabled = true
+	args.SystemDiskIoTime.Enabled = true
+	args.SystemDiskMerged.Enabled = true
+	args.SystemDiskOperationTime.Enabled = true
+	args.SystemDiskOperations.Enabled = true
+	args.SystemDiskPendingOperations.Enabled = true
+	args.SystemDiskWeightedIoTime.Enabled = true
+}
+
+// toMap encodes args to a map for use with confmap.
+func (args *DiskMetricsArguments) toMap() map[string]any {
+	return map[string]any{
+		"system.disk.io":                 args.SystemDiskIo.toMap(),
+		"system.disk.io_time":            args.SystemDiskIoTime.toMap(),
+		"system.disk.merged":             args.SystemDiskMerged.toMap(),
+		"system.disk.operation_time":     args.SystemDiskOperationTime.toMap(),
+		"system.disk.operations":         args.SystemDiskOperations.toMap(),
+		"system.disk.pending_operations": args.SystemDiskPendingOperations.toMap(),
+		"system.disk.weighted_io_time":   args.SystemDiskWeightedIoTime.toMap(),
+	}
+}
+
+// FilesystemMetricsArguments configures the metrics collected by the filesystem scraper.
+type FilesystemMetricsArguments struct {
+	SystemFilesystemInodesUsage MetricArguments `alloy:"system.filesystem.inodes.usage,block,optional"`
+	SystemFilesystemUsage       MetricArguments `alloy:"system.filesystem.usage,block,optional"`
+	SystemFilesystemUtilization MetricArguments `alloy:"system.filesystem.utilization,block,optional"`
+}
+
+// SetToDefault implements syntax.Defaulter.
+func (args *FilesystemMetricsArguments) SetToDefault() {
+	args.SystemFilesystemInodesUsage.Enabled = true
+	args.SystemFilesystemUsage.Enabled = true
+	args.SystemFilesystemUtilization.Enabled = false
+}
+
+// toMap encodes args to a map for use with confmap.
+func (args *FilesystemMetricsArguments) toMap() map[string]any {
+	return map[string]any{
+		"system.filesystem.inodes.usage": args.SystemFilesystemInodesUsage.toMap(),
+		"system.filesystem.usage":        args.SystemFilesystemUsage.toMap(),
+		"system.filesystem.utilization":  args.SystemFilesystemUtilization.toMap(),
+	}
+}
+
+// LoadMetricsArguments configures the metrics collected by the load scraper.
+type LoadMetricsArguments struct {
+	SystemCPULoadAverage15m MetricArguments `alloy:"system.cpu.load_average.15m,block,optional"`
+	SystemCPULoadAverage1m  MetricArguments `alloy:"system.cpu.load_average.1m,block,optional"`
+	SystemCPULoadAverage5m  MetricArguments `alloy:"system.cpu.load_average.5m,block,optional"`
+}
+
+// SetToDefault implements syntax.Defaulter.
+func (args *LoadMetricsArguments) SetToDefault() {
+	args.SystemCPULoadAverage15m.Enabled = true
+	args.SystemCPULoadAverage1m.Enabled = true
+	args.SystemCPULoadAverage5m.Enabled = true
+}
+
+// toMap encodes args to a map for use with confmap.
+func (args *LoadMetricsArguments) toMap() map[string]any {
+	return map[string]any{
+		"system.cpu.load_average.15m": args.SystemCPULoadAverage15m.toMap(),
+		"system.cpu.load_average.1m":  args.SystemCPULoadAverage1m.toMap(),
+		"system.cpu.load_average.5m":  args.SystemCPULoadAverage5m.toMap(),
+	}
+}
+
+// MemoryMetricsArguments configures the metrics collected by the memory scraper.
+type MemoryMetricsArguments struct {
+	SystemLinuxMemoryAvailable MetricArguments `alloy:"system.linux.memory.available,block,optional"`
+	SystemLinuxMemoryDirty     MetricArguments `alloy:"system.linux.memory.dirty,block,optional"`
+	SystemMemoryLimit          MetricArguments `alloy:"system.memory.limit,block,optional"`
+	SystemMemoryPageSize       MetricArguments `alloy:"system.memory.page_size,block,optional"`
+	SystemMemoryUsage          MetricArguments `alloy:"system.memory.usage,block,optional"`
+	SystemMemoryUtilization    MetricArguments `alloy:"system.memory.utilization,block,optional"`
+}
+
+// SetToDefault implements syntax.Defaulter.
+func (args *MemoryMetricsArguments) SetToDefault() {
+	args.SystemLinuxMemoryAvailable.Enabled = false
+	args.SystemLinuxMemoryDirty.Enabled = false
+	args.SystemMemoryLimit.Enabled = false
+	args.SystemMemoryPageSize.Enabled = false
+	args.SystemMemoryUsage.Enabled = true
+	args.SystemMemoryUtilization.Enabled = false
+}
+
+// toMap encodes args to a map for use with confmap.
+func (args *MemoryMetricsArguments) toMap() map[string]any {
+	return map[string]any{
+		"system.linux.memory.available": args.SystemLinuxMemoryAvailable.toMap(),
+		"system.linux.memory.dirty":     args.SystemLinuxMemoryDirty.toMap(),
+		"system.memory.limit":           args.SystemMemoryLimit.toMap(),
+		"system.memory.page_size":       args.SystemMemoryPageSize.toMap(),
+		"system.memory.usage":           args.SystemMemoryUsage.toMap(),
+		"system.memory.utilization":     args.SystemMemoryUtilization.toMap(),
+	}
+}
+
+// NetworkMetricsArguments configures the metrics collected by the network scraper.
+type NetworkMetricsArguments struct {
+	SystemNetworkConnections    MetricArguments `alloy:"system.network.connections,block,optional"`
+	SystemNetworkConntrackCount MetricArguments `alloy:"system.network.conntrack.count,block,optional"`
+	SystemNetworkConntrackMax   MetricArguments `alloy:"system.network.conntrack.max,block,optional"`
+	SystemNetworkDropped        MetricArguments `alloy:"system.network.dropped,block,optional"`
+	SystemNetworkErrors         MetricArguments `alloy:"system.network.errors,block,optional"`
+	SystemNetworkIo             MetricArguments `alloy:"system.network.io,block,optional"`
+	SystemNetworkPackets        MetricArguments `alloy:"system.network.packets,block,optional"`
+}
+
+// SetToDefault implements syntax.Defaulter.
+func (args *NetworkMetricsArguments) SetToDefault() {
+	args.SystemNetworkConnections.Enabled = true
+	args.SystemNetworkConntrackCount.Enabled = false
+	args.SystemNetworkConntrackMax.Enabled = false
+	args.SystemNetworkDropped.Enabled = true
+	args.SystemNetworkErrors.Enabled = true
+	args.SystemNetworkIo.Enabled = true
+	args.SystemNetworkPackets.Enabled = true
+}
+
+// toMap encodes args to a map for use with confmap.
+func (args *NetworkMetricsArguments) toMap() map[string]any {
+	return map[string]any{
+		"system.network.connections":     args.SystemNetworkConnections.toMap(),
+		"system.network.conntrack.count": args.SystemNetworkConntrackCount.toMap(),
+		"system.network.conntrack.max":   args.SystemNetworkConntrackMax.toMap(),
+		"system.network.dropped":         args.SystemNetworkDropped.toMap(),
+		"system.network.errors":          args.SystemNetworkErrors.toMap(),
+		"system.network.io":              args.SystemNetworkIo.toMap(),
+		"system.network.packets":         args.SystemNetworkPackets.toMap(),
+	}
+}
+
+// PagingMetricsArguments configures the metrics collected by the paging scraper.
+type PagingMetricsArguments struct {
+	SystemPagingFaults      MetricArguments `alloy:"system.paging.faults,block,optional"`
+	SystemPagingOperations  MetricArguments `alloy:"system.paging.operations,block,optional"`
+	SystemPagingUsage       MetricArguments `alloy:"system.paging.usage,block,optional"`
+	SystemPagingUtilization MetricArguments `alloy:"system.paging.utilization,block,optional"`
+}
+
+// SetToDefault implements syntax.Defaulter.
+func (args *PagingMetricsArguments) SetToDefault() {
+	args.SystemPagingFaults.Enabled = true
+	args.SystemPagingOperations.Enabled = true
+	args.SystemPagingUsage.Enabled = true
+	args.SystemPagingUtilization.Enabled = false
+}
+
+// toMap encodes args to a map for use with confmap.
+func (args *PagingMetricsArguments) toMap() map[string]any {
+	return map[string]any{
+		"system.paging.faults":      args.SystemPagingFaults.toMap(),
+		"system.paging.operations":  args.SystemPagingOperations.toMap(),
+		"system.paging.usage":       args.SystemPagingUsage.toMap(),
+		"system.paging.utilization": args.SystemPagingUtilization.toMap(),
+	}
+}
+
+// ProcessesMetricsArguments configures the metrics collected by the processes scraper.
+type ProcessesMetricsArguments struct {
+	SystemProcessesCount   MetricArguments `alloy:"system.processes.count,block,optional"`
+	SystemProcessesCreated MetricArguments `alloy:"system.processes.created,block,optional"`
+}
+
+// SetToDefault implements syntax.Defaulter.
+func (args *ProcessesMetricsArguments) SetToDefault() {
+	args.SystemProcessesCount.Enabled = true
+	args.SystemProcessesCreated.Enabled = true
+}
+
+// toMap encodes args to a map for use with confmap.
+func (args *ProcessesMetricsArguments) toMap() map[string]any {
+	return map[string]any{
+		"system.processes.count":   args.SystemProcessesCount.toMap(),
+		"system.processes.created": args.SystemProcessesCreated.toMap(),
+	}
+}
+
+// ProcessMetricsArguments configures the metrics collected by the process scraper.
+type ProcessMetricsArguments struct {
+	ProcessContextSwitches     MetricArguments `alloy:"process.context_switches,block,optional"`
+	ProcessCPUTime             MetricArguments `alloy:"process.cpu.time,block,optional"`
+	ProcessCPUUtilization      MetricArguments `alloy:"process.cpu.utilization,block,optional"`
+	ProcessDiskIo              MetricArguments `alloy:"process.disk.io,block,optional"`
+	ProcessDiskOperations      MetricArguments `alloy:"process.disk.operations,block,optional"`
+	ProcessHandles             MetricArguments `alloy:"process.handles,block,optional"`
+	ProcessMemoryUsage         MetricArguments `alloy:"process.memory.usage,block,optional"`
+	ProcessMemoryUtilization   MetricArguments `alloy:"process.memory.utilization,block,optional"`
+	ProcessMemoryVirtual       MetricArguments `alloy:"process.memory.virtual,block,optional"`
+	ProcessOpenFileDescriptors MetricArguments `alloy:"process.open_file_descriptors,block,optional"`
+	ProcessPagingFaults        MetricArguments `alloy:"process.paging.faults,block,optional"`
+	ProcessSignalsPending      MetricArguments `alloy:"process.signals_pending,block,optional"`
+	ProcessThreads             MetricArguments `alloy:"process.threads,block,optional"`
+	ProcessUptime              MetricArguments `alloy:"process.uptime,block,optional"`
+}
+
+// SetToDefault implements syntax.Defaulter.
+func (args *ProcessMetricsArguments) SetToDefault() {
+	args.ProcessContextSwitches.Enabled = false
+	args.ProcessCPUTime.Enabled = true
+	args.ProcessCPUUtilization.Enabled = false
+	args.ProcessDiskIo.Enabled = true
+	args.ProcessDiskOperations.Enabled = false
+	args.ProcessHandles.Enabled = false
+	args.ProcessMemoryUsage.Enabled = true
+	args.ProcessMemoryUtilization.Enabled = false
+	args.ProcessMemoryVirtual.Enabled = true
+	args.ProcessOpenFileDescriptors.Enabled = false
+	args.ProcessPagingFaults.Enabled = false
+	args.ProcessSignalsPending.Enabled = false
+	args.ProcessThreads.Enabled = false
+	args.ProcessUptime.Enabled = false
+}
+
+// toMap encodes args to a map for use with confmap.
+func (args *ProcessMetricsArguments) toMap() map[string]any {
+	return map[string]any{
+		"process.context_switches":      args.ProcessContextSwitches.toMap(),
+		"process.cpu.time":              args.ProcessCPUTime.toMap(),
+		"process.cpu.utilization":       args.ProcessCPUUtilization.toMap(),
+		"process.disk.io":               args.ProcessDiskIo.toMap(),
+		"process.disk.operations":       args.ProcessDiskOperations.toMap(),
+		"process.handles":               args.ProcessHandles.toMap(),
+		"process.memory.usage":          args.ProcessMemoryUsage.toMap(),
+		"process.memory.utilization":    args.ProcessMemoryUtilization.toMap(),
+		"process.memory.virtual":        args.ProcessMemoryVirtual.toMap(),
+		"process.open_file_descriptors": args.ProcessOpenFileDescriptors.toMap(),
+		"process.paging.faults":         args.ProcessPagingFaults.toMap(),
+		"process.signals_pending":       args.ProcessSignalsPending.toMap(),
+		"process.threads":               args.ProcessThreads.toMap(),
+		"process.uptime":                args.ProcessUptime.toMap(),
+	}
+}
+
+// ProcessResourceAttributesArguments configures the resource attributes set by
+// the process scraper.
+type ProcessResourceAttributesArguments struct {
+	ProcessCgroup         ResourceAttributeArguments `alloy:"process.cgroup,block,optional"`
+	ProcessCommand        ResourceAttributeArguments `alloy:"process.command,block,optional"`
+	ProcessCommandLine    ResourceAttributeArguments `alloy:"process.command_line,block,optional"`
+	ProcessExecutableName ResourceAttributeArguments `alloy:"process.executable.name,block,optional"`
+	ProcessExecutablePath ResourceAttributeArguments `alloy:"process.executable.path,block,optional"`
+	ProcessOwner          ResourceAttributeArguments `alloy:"process.owner,block,optional"`
+	ProcessParentPid      ResourceAttributeArguments `alloy:"process.parent_pid,block,optional"`
+	ProcessPid            ResourceAttributeArguments `alloy:"process.pid,block,optional"`
+}
+
+// SetToDefault implements syntax.Defaulter.
+func (args *ProcessResourceAttributesArguments) SetToDefault() {
+	args.ProcessCgroup.Enabled = false
+	args.ProcessCommand.Enabled = true
+	args.ProcessCommandLine.Enabled = true
+	args.ProcessExecutableName.Enabled = true
+	args.ProcessExecutablePath.Enabled = true
+	args.ProcessOwner.Enabled = true
+	args.ProcessParentPid.Enabled = true
+	args.ProcessPid.Enabled = true
+}
+
+// toMap encodes args to a map for use with confmap.
+func (args *ProcessResourceAttributesArguments) toMap() map[string]any {
+	return map[string]any{
+		"process.cgroup":          args.ProcessCgroup.toMap(),
+		"process.command":         args.ProcessCommand.toMap(),
+		"process.command_line":    args.ProcessCommandLine.toMap(),
+		"process.executable.name": args.ProcessExecutableName.toMap(),
+		"process.executable.path": args.ProcessExecutablePath.toMap(),
+		"process.owner":           args.ProcessOwner.toMap(),
+		"process.parent_pid":      args.ProcessParentPid.toMap(),
+		"process.pid":             args.ProcessPid.toMap(),
+	}
+}
+
+// MetricArguments provides common config for a particular metric.
+type MetricArguments struct {
+	Enabled bool `alloy:"enabled,attr,optional"`
+}
+
+// toMap encodes args to a map for use with confmap.
+func (args *MetricArguments) toMap() map[string]any {
+	return map[string]any{"enabled": args.Enabled}
+}
+
+// ResourceAttributeArguments provides common config for a particular resource
+// attribute.
+type ResourceAttributeArguments struct {
+	Enabled bool `alloy:"enabled,attr,optional"`
+}
+
+// toMap encodes args to a map for use with confmap.
+func (args *ResourceAttributeArguments) toMap() map[string]any {
+	return map[string]any{"enabled": args.Enabled}
+}
@@ -0,0 +1,260 @@
+package hostmetrics
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/grafana/alloy/syntax"
+)
+
+// CPUScraperArguments configures the cpu scraper.
+type CPUScraperArguments struct {
+	Metrics CPUMetricsArguments `alloy:"metrics,block,optional"`
+}
+
+var _ syntax.Defaulter = (*CPUScraperArguments)(nil)
+
+// SetToDefault implements syntax.Defaulter.
+func (args *CPUScraperArguments) SetToDefault() {
+	*args = CPUScraperArguments{}
+	args.Metrics.SetToDefault()
+}
+
+func (args *CPUScraperArguments) toMap() map[string]any {
+	return map[string]any{"metrics": args.Metrics.toMap()}
+}
+
+// DiskScraperArguments configures the disk scraper.
+type DiskScraperArguments struct {
+	Include *MatchArguments      `alloy:"include,block,optional"`
+	Exclude *MatchArguments      `alloy:"exclude,block,optional"`
+	Metrics DiskMetricsArguments `alloy:"metrics,block,optional"`
+}
+
+var _ syntax.Defaulter = (*DiskScraperArguments)(nil)
+
+// SetToDefault implements syntax.Defaulter.
+func (args *DiskScraperArguments) SetToDefault() {
+	*args = DiskScraperArguments{}
+	args.Metrics.SetToDefault()
+}
+
+func (args *DiskScraperArguments) toMap() map[string]any {
+	return map[string]any{
+		"include": args.Include.toMap("devices"),
+		"exclude": args.Exclude.toMap("devices"),
+		"metrics": args.Metrics.toMap(),
+	}
+}
+
+// FilesystemScraperArguments configures the filesystem scraper.
+type FilesystemScraperArguments struct {
+	IncludeVirtualFilesystems bool                       `alloy:"include_virtual_filesystems,attr,optional"`
+	IncludeDevices            *MatchArguments            `alloy:"include_devices,block,optional"`
+	ExcludeDevices            *MatchArguments            `alloy:"exclude_devices,block,optional"`
+	IncludeFSTypes            *MatchArguments            `alloy:"include_fs_types,block,optional"`
+	ExcludeFSTypes            *MatchArguments            `alloy:"exclude_fs_types,block,optional"`
+	IncludeMountPoints        *MatchArguments            `alloy:"include_mount_points,block,optional"`
+	ExcludeMountPoints        *MatchArguments            `alloy:"exclude_mount_points,block,optional"`
+	Metrics                   FilesystemMetricsArguments `alloy:"metrics,block,optional"`
+}
+
+var _ syntax.Defaulter = (*FilesystemScraperArguments)(nil)
+
+// SetToDefault implements syntax.Defaulter.
+func (args *FilesystemScraperArguments) SetToDefault() {
+	*args = FilesystemScraperArguments{}
+	args.Metrics.SetToDefault()
+}
+
+func (args *FilesystemScraperArguments) toMap() map[string]any {
+	return map[string]any{
+		"include_virtual_filesystems": args.IncludeVirtualFilesystems,
+		"include_devices":             args.IncludeDevices.toMap("devices"),
+		"exclude_devices":             args.ExcludeDevices.toMap("devices"),
+		"include_fs_types":            args.IncludeFSTypes.toMap("fs_types"),
+		"exclude_fs_types":            args.ExcludeFSTypes.toMap("fs_types"),
+		"include_mount_points":        args.IncludeMountPoints.toMap("mount_points"),
+		"exclude_mount_points":        args.ExcludeMountPoints.toMap("mount_points"),
+		"metrics":                     args.Metrics.toMap(),
+	}
+}
+
+// LoadScraperArguments configures the load scraper.
+type LoadScraperArguments struct {
+	CPUAverage bool                 `alloy:"cpu_average,attr,optional"`
+	Metrics    LoadMetricsArguments `alloy:"metrics,block,optional"`
+}
+
+var _ syntax.Defaulter = (*LoadScraperArguments)(nil)
+
+// SetToDefault implements syntax.Defaulter.
+func (args *LoadScraperArguments) SetToDefault() {
+	*args = LoadScraperArguments{}
+	args.Metrics.SetToDefault()
+}
+
+func (args *LoadScraperArguments) toMap() map[string]any {
+	return map[string]any{
+		"cpu_average": args.CPUAverage,
+		"metrics":     args.Metrics.toMap(),
+	}
+}
+
+// MemoryScraperArguments configures the memory scraper.
+type MemoryScraperArguments struct {
+	Metrics MemoryMetricsArguments `alloy:"metrics,block,optional"`
+}
+
+var _ syntax.Defaulter = (*MemoryScraperArguments)(nil)
+
+// SetToDefault implements syntax.Defaulter.
+func (args *MemoryScraperArguments) SetToDefault() {
+	*args = MemoryScraperArguments{}
+	args.Metrics.SetToDefault()
+}
+
+func (args *MemoryScraperArguments) toMap() map[string]any {
+	return map[string]any{"metrics": args.Metrics.toMap()}
+}
+
+// NetworkScraperArguments configures the network scraper.
+type NetworkScraperArguments struct {
+	Include *MatchArguments         `alloy:"include,block,optional"`
+	Exclude *MatchArguments         `alloy:"exclude,block,optional"`
+	Metrics NetworkMetricsArguments `alloy:"metrics,block,optional"`
+}
+
+var _ syntax.Defaulter = (*NetworkScraperArguments)(nil)
+
+// SetToDefault implements syntax.Defaulter.
+func (args *NetworkScraperArguments) SetToDefault() {
+	*args = NetworkScraperArguments{}
+	args.Metrics.SetToDefault()
+}
+
+func (args *NetworkScraperArguments) toMap() map[string]any {
+	return map[string]any{
+		"include": args.Include.toMap("interfaces"),
+		"exclude": args.Exclude.toMap("interfaces"),
+		"metrics": args.Metrics.toMap(),
+	}
+}
+
+// PagingScraperArguments configures the paging scraper.
+type PagingScraperArguments struct {
+	Metrics PagingMetricsArguments `alloy:"metrics,block,optional"`
+}
+
+var _ syntax.Defaulter = (*PagingScraperArguments)(nil)
+
+// SetToDefault implements syntax.Defaulter.
+func (args *PagingScraperArguments) SetToDefault() {
+	*args = PagingScraperArguments{}
+	args.Metrics.SetToDefault()
+}
+
+func (args *PagingScraperArguments) toMap() map[string]any {
+	return map[string]any{"metrics": args.Metrics.toMap()}
+}
+
+// ProcessesScraperArguments configures the processes scraper.
+type ProcessesScraperArguments struct {
+	Metrics ProcessesMetricsArguments `alloy:"metrics,block,optional"`
+}
+
+var _ syntax.Defaulter = (*ProcessesScraperArguments)(nil)
+
+// SetToDefault implements syntax.Defaulter.
+func (args *ProcessesScraperArguments) SetToDefault() {
+	*args = ProcessesScraperArguments{}
+	args.Metrics.SetToDefault()
+}
+
+func (args *ProcessesScraperArguments) toMap() map[string]any {
+	return map[string]any{"metrics": args.Metrics.toMap()}
+}
+
+// ProcessScraperArguments configures the process scraper.
+type ProcessScraperArguments struct {
+	Include                *MatchArguments                    `alloy:"include,block,optional"`
+	Exclude                *MatchArguments                    `alloy:"exclude,block,optional"`
+	MuteProcessAllErrors   bool                               `alloy:"mute_process_all_errors,attr,optional"`
+	MuteProcessNameError   bool                               `alloy:"mute_process_name_error,attr,optional"`
+	MuteProcessIOError     bool                               `alloy:"mute_process_io_error,attr,optional"`
+	MuteProcessCgroupError bool                               `alloy:"mute_process_cgroup_error,attr,optional"`
+	MuteProcessExeError    bool                               `alloy:"mute_process_exe_error,attr,optional"`
+	MuteProcessUserError   bool                               `alloy:"mute_process_user_error,attr,optional"`
+	ScrapeProcessDelay     time.Duration                      `alloy:"scrape_process_delay,attr,optional"`
+	Metrics                ProcessMetricsArguments            `alloy:"metrics,block,optional"`
+	ResourceAttributes     ProcessResourceAttributesArguments `alloy:"resource_attributes,block,optional"`
+}
+
+var _ syntax.Defaulter = (*ProcessScraperArguments)(nil)
+
+// SetToDefault implements syntax.Defaulter.
+func (args *ProcessScraperArguments) SetToDefault() {
+	*args = ProcessScraperArguments{}
+	args.Metrics.SetToDefault()
+	args.ResourceAttributes.SetToDefault()
+}
+
+func (args *ProcessScraperArguments) toMap() map[string]any {
+	return map[string]any{
+		"include":                   args.Include.toMap("names"),
+		"exclude":                   args.Exclude.toMap("names"),
+		"mute_process_all_errors":   args.MuteProcessAllErrors,
+		"mute_process_name_error":   args.MuteProcessNameError,
+		"mute_process_io_error":     args.MuteProcessIOError,
+		"mute_process_cgroup_error": args.MuteProcessCgroupError,
+		"mute_process_exe_error":    args.MuteProcessExeError,
+		"mute_process_user_error":   args.MuteProcessUserError,
+		"scrape_process_delay":      args.ScrapeProcessDelay,
+		"metrics":                   args.Metrics.toMap(),
+		"resource_attributes":       args.ResourceAttributes.toMap(),
+	}
+}
+
+// MatchArguments filters the devices, interfaces, filesystem types, mount
+// points or process names a scraper reports on.
+type MatchArguments struct {
+	Values    []string `alloy:"values,attr"`
+	MatchType string   `alloy:"match_type,attr,optional"`
+}
+
+var (
+	_ syntax.Defaulter = (*MatchArguments)(nil)
+	_ syntax.Validator = (*MatchArguments)(nil)
+)
+
+const (
+	matchTypeStrict = "strict"
+	matchTypeRegexp = "regexp"
+)
+
+// SetToDefault implements syntax.Defaulter.
+func (args *MatchArguments) SetToDefault() {
+	*args = MatchArguments{MatchType: matchTypeStrict}
+}
+
+// Validate implements syntax.Validator.
+func (args *MatchArguments) Validate() error {
+	switch args.MatchType {
+	case matchTypeStrict, matchTypeRegexp:
+		return nil
+	default:
+		return fmt.Errorf("match_type must be %q or %q, got %q", matchTypeStrict, matchTypeRegexp, args.MatchType)
+	}
+}
+
+// toMap encodes args to a map for use with confmap. The upstream scrapers use
+// a different key for the list of values, which is passed as key.
+func (args *MatchArguments) toMap(key string) map[string]any {
+	if args == nil {
+		return map[string]any{}
+	}
+	return map[string]any{
+		"match_type": args.MatchType,
+		key:          args.Values,
+	}
+}
@@ -0,0 +1,133 @@
+// Package k8s_cluster provides an otelcol.receiver.k8s_cluster component.
+package k8s_cluster
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/grafana/alloy/internal/component"
+	"github.com/grafana/alloy/internal/component/otelcol"
+	otelcolCfg "github.com/grafana/alloy/internal/component/otelcol/config"
+	"github.com/grafana/alloy/internal/component/otelcol/receiver"
+	"github.com/grafana/alloy/internal/featuregate"
+	"github.com/grafana/alloy/syntax"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver"
+	otelcomponent "go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+func init() {
+	component.Register(component.Registration{
+		Name:      "otelcol.receiver.k8s_cluster",
+		Stability: featuregate.StabilityExperimental,
+		Args:      Arguments{},
+
+		Build: func(opts component.Options, args component.Arguments) (component.Component, error) {
+			fact := k8sclusterreceiver.NewFactory()
+			return receiver.New(opts, fact, args.(Arguments))
+		},
+	})
+}
+
+// Supported values for Arguments.Distribution.
+const (
+	DistributionKubernetes = "kubernetes"
+	DistributionOpenShift  = "openshift"
+)
+
+// Arguments configures the otelcol.receiver.k8s_cluster component.
+type Arguments struct {
+	KubernetesAPIConfig otelcol.KubernetesAPIConfig `alloy:",squash"`
+
+	CollectionInterval         time.Duration `alloy:"collection_interval,attr,optional"`
+	MetadataCollectionInterval time.Duration `alloy:"metadata_collection_interval,attr,optional"`
+	NodeConditionsToReport     []string      `alloy:"node_conditions_to_report,attr,optional"`
+	AllocatableTypesToReport   []string      `alloy:"allocatable_types_to_report,attr,optional"`
+	Distribution               string        `alloy:"distribution,attr,optional"`
+	Namespaces                 []string      `alloy:"namespaces,attr,optional"`
+
+	// DebugMetrics configures component internal metrics. Optional.
+	DebugMetrics otelcolCfg.DebugMetricsArguments `alloy:"debug_metrics,block,optional"`
+
+	// Output configures where to send received data. Required.
+	Output *otelcol.ConsumerArguments `alloy:"output,block"`
+}
+
+var (
+	_ receiver.Arguments = Arguments{}
+	_ syntax.Defaulter   = (*Arguments)(nil)
+	_ syntax.Validator   = (*Arguments)(nil)
+)
+
+// SetToDefault implements syntax.Defaulter.
+func (args *Arguments) SetToDefault() {
+	*args = Arguments{
+		KubernetesAPIConfig: otelcol.KubernetesAPIConfig{
+			AuthType: otelcol.KubernetesAPIConfig_AuthType_ServiceAccount,
+		},
+		CollectionInterval:         10 * time.Second,
+		MetadataCollectionInterval: 5 * time.Minute,
+		NodeConditionsToReport:     []string{"Ready"},
+		Distribution:               DistributionKubernetes,
+	}
+	args.DebugMetrics.SetToDefault()
+}
+
+// Validate implements syntax.Validator.
+func (args *Arguments) Validate() error {
+	if err := args.KubernetesAPIConfig.Validate(); err != nil {
+		return err
+	}
+
+	distributions := []string{DistributionKubernetes, DistributionOpenShift}
+	if !slices.Contains(distributions, args.Distribution) {
+		return fmt.Errorf("distribution must be one of %q", distributions)
+	}
+	return nil
+}
+
+// Convert implements receiver.Arguments.
+func (args Arguments) Convert() (otelcomponent.Config, error) {
+	out := k8sclusterreceiver.NewFactory().CreateDefaultConfig().(*k8sclusterreceiver.Config)
+
+	// The Kubernetes API config is a type from an internal upstream package,
+	// so it's unmarshaled through confmap.
+	conf := confmap.NewFromStringMap(map[string]any{
+		"auth_type": args.KubernetesAPIConfig.AuthType,
+		"context":   args.KubernetesAPIConfig.Context,
+	})
+	if err := conf.Unmarshal(out); err != nil {
+		return nil, fmt.Errorf("decoding k8s_cluster config: %w", err)
+	}
+
+	out.CollectionInterval = args.CollectionInterval
+	out.MetadataCollectionInterval = args.MetadataCollectionInterval
+	out.NodeConditionTypesToReport = args.NodeConditionsToReport
+	out.AllocatableTypesToReport = args.AllocatableTypesToReport
+	out.Distribution = args.Distribution
+	out.Namespaces = args.Namespaces
+
+	return out, nil
+}
+
+// Extensions implements receiver.Arguments.
+func (args Arguments) Extensions() map[otelcomponent.ID]otelcomponent.Component {
+	return nil
+}
+
+// Exporters implements receiver.Arguments.
+func (args Arguments) Exporters() map[pipeline.Signal]map[otelcomponent.ID]otelcomponent.Component {
+	return nil
+}
+
+// NextConsumers implements receiver.Arguments.
+func (args Arguments) NextConsumers() *otelcol.ConsumerArguments {
+	return args.Output
+}
+
+// DebugMetricsConfig implements receiver.Arguments.
+func (args Arguments) DebugMetricsConfig() otelcolCfg.DebugMetricsArguments {
+	return args.DebugMetrics
+}
@@ -0,0 +1,101 @@
+package k8s_cluster_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/grafana/alloy/internal/component/otelcol/receiver/k8s_cluster"
+	"github.com/grafana/alloy/internal/nodeconf/clustering"
+	"github.com/grafana/alloy/syntax"
+	"github.com/grafana/alloy/syntax/parser"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArguments(t *testing.T) {
+	in := `
+		auth_type                    = "kubeConfig"
+		context                      = "production"
+		collection_interval          = "30s"
+		metadata_collection_interval = "10m"
+		node_conditions_to_report    = ["Ready", "MemoryPressure"]
+		allocatable_types_to_report  = ["cpu", "memory"]
+		distribution                 = "openshift"
+		namespaces                   = ["default", "monitoring"]
+
+		output {}
+	`
+
+	var args k8s_cluster.Arguments
+	require.NoError(t, syntax.Unmarshal([]byte(in), &args))
+
+	outAny, err := args.Convert()
+	require.NoError(t, err)
+	out := outAny.(*k8sclusterreceiver.Config)
+	require.NoError(t, out.Validate())
+
+	require.EqualValues(t, "kubeConfig", out.AuthType)
+	require.Equal(t, "production", out.Context)
+	require.Equal(t, 30*time.Second, out.CollectionInterval)
+	require.Equal(t, 10*time.Minute, out.MetadataCollectionInterval)
+	require.Equal(t, []string{"Ready", "MemoryPressure"}, out.NodeConditionTypesToReport)
+	require.Equal(t, []string{"cpu", "memory"}, out.AllocatableTypesToReport)
+	require.Equal(t, "openshift", out.Distribution)
+	require.Equal(t, []string{"default", "monitoring"}, out.Namespaces)
+	require.Nil(t, out.K8sLeaderElector)
+}
+
+func TestArguments_Defaults(t *testing.T) {
+	var args k8s_cluster.Arguments
+	require.NoError(t, syntax.Unmarshal([]byte(`output {}`), &args))
+
+	outAny, err := args.Convert()
+	require.NoError(t, err)
+	out := outAny.(*k8sclusterreceiver.Config)
+
+	// The defaults must match the upstream ones.
+	expected := k8sclusterreceiver.NewFactory().CreateDefaultConfig().(*k8sclusterreceiver.Config)
+	require.Equal(t, expected, out)
+}
+
+func TestArguments_Validate(t *testing.T) {
+	tests := []struct {
+		name        string
+		cfg         string
+		expectedErr string
+	}{
+		{
+			name: "invalid auth type",
+			cfg: `
+				auth_type = "token"
+				output {}
+			`,
+			expectedErr: `invalid auth_type "token"`,
+		},
+		{
+			name: "invalid distribution",
+			cfg: `
+				distribution = "eks"
+				output {}
+			`,
+			expectedErr: `distribution must be one of ["kubernetes" "openshift"]`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var args k8s_cluster.Arguments
+			require.ErrorContains(t, syntax.Unmarshal([]byte(tc.cfg), &args), tc.expectedErr)
+		})
+	}
+}
+
+// The clustering block is handled by the component controller, which runs
+// the receiver on a single instance of the cluster in singleton mode.
+func TestArguments_Clustering(t *testing.T) {
+	file, err := parser.ParseFile("", []byte(`clustering { mode = "singleton" }`))
+	require.NoError(t, err)
+
+	_, block := clustering.Split(k8s_cluster.Arguments{}, file.Body)
+	require.NotNil(t, block)
+}
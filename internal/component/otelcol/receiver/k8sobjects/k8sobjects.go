@@ -0,0 +1,193 @@
+// Package k8sobjects provides an otelcol.receiver.k8sobjects component.
+package k8sobjects
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/grafana/alloy/internal/component"
+	"github.com/grafana/alloy/internal/component/otelcol"
+	otelcolCfg "github.com/grafana/alloy/internal/component/otelcol/config"
+	"github.com/grafana/alloy/internal/component/otelcol/receiver"
+	"github.com/grafana/alloy/internal/featuregate"
+	"github.com/grafana/alloy/syntax"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sobjectsreceiver"
+	otelcomponent "go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+func init() {
+	component.Register(component.Registration{
+		Name:      "otelcol.receiver.k8sobjects",
+		Stability: featuregate.StabilityExperimental,
+		Args:      Arguments{},
+
+		Build: func(opts component.Options, args component.Arguments) (component.Component, error) {
+			fact := k8sobjectsreceiver.NewFactory()
+			return receiver.New(opts, fact, args.(Arguments))
+		},
+	})
+}
+
+// Supported values for Object.Mode.
+const (
+	ModePull  = "pull"
+	ModeWatch = "watch"
+)
+
+var (
+	modes       = []string{ModePull, ModeWatch}
+	errorModes  = []string{"propagate", "ignore", "silent"}
+	watchTypes  = []string{"ADDED", "MODIFIED", "DELETED", "BOOKMARK", "ERROR"}
+	defaultMode = ModePull
+)
+
+// Arguments configures the otelcol.receiver.k8sobjects component.
+type Arguments struct {
+	KubernetesAPIConfig otelcol.KubernetesAPIConfig `alloy:",squash"`
+
+	Objects             []Object `alloy:"objects,block"`
+	ErrorMode           string   `alloy:"error_mode,attr,optional"`
+	IncludeInitialState bool     `alloy:"include_initial_state,attr,optional"`
+
+	// DebugMetrics configures component internal metrics. Optional.
+	DebugMetrics otelcolCfg.DebugMetricsArguments `alloy:"debug_metrics,block,optional"`
+
+	// Output configures where to send received data. Required.
+	Output *otelcol.ConsumerArguments `alloy:"output,block"`
+}
+
+// Object configures a kind of Kubernetes object to pull or watch.
+type Object struct {
+	Name             string        `alloy:"name,attr"`
+	Group            string        `alloy:"group,attr,optional"`
+	Namespaces       []string      `alloy:"namespaces,attr,optional"`
+	Mode             string        `alloy:"mode,attr,optional"`
+	LabelSelector    string        `alloy:"label_selector,attr,optional"`
+	FieldSelector    string        `alloy:"field_selector,attr,optional"`
+	Interval         time.Duration `alloy:"interval,attr,optional"`
+	ResourceVersion  string        `alloy:"resource_version,attr,optional"`
+	ExcludeWatchType []string      `alloy:"exclude_watch_type,attr,optional"`
+}
+
+var (
+	_ receiver.Arguments = Arguments{}
+	_ syntax.Defaulter   = (*Arguments)(nil)
+	_ syntax.Validator   = (*Arguments)(nil)
+	_ syntax.Defaulter   = (*Object)(nil)
+)
+
+// SetToDefault implements syntax.Defaulter.
+func (args *Arguments) SetToDefault() {
+	*args = Arguments{
+		KubernetesAPIConfig: otelcol.KubernetesAPIConfig{
+			AuthType: otelcol.KubernetesAPIConfig_AuthType_ServiceAccount,
+		},
+		ErrorMode: "propagate",
+	}
+	args.DebugMetrics.SetToDefault()
+}
+
+// SetToDefault implements syntax.Defaulter.
+func (o *Object) SetToDefault() {
+	*o = Object{Mode: defaultMode}
+}
+
+// Validate implements syntax.Validator.
+func (args *Arguments) Validate() error {
+	if err := args.KubernetesAPIConfig.Validate(); err != nil {
+		return err
+	}
+	if !slices.Contains(errorModes, args.ErrorMode) {
+		return fmt.Errorf("error_mode must be one of %q", errorModes)
+	}
+
+	for i, o := range args.Objects {
+		if err := o.validate(args.IncludeInitialState); err != nil {
+			return fmt.Errorf("objects %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+func (o Object) validate(includeInitialState bool) error {
+	if o.Name == "" {
+		return errors.New("name must not be empty")
+	}
+	if !slices.Contains(modes, o.Mode) {
+		return fmt.Errorf("mode must be one of %q", modes)
+	}
+	for _, t := range o.ExcludeWatchType {
+		if !slices.Contains(watchTypes, t) {
+			return fmt.Errorf("exclude_watch_type must only contain %q", watchTypes)
+		}
+	}
+
+	if o.Mode == ModePull {
+		if len(o.ExcludeWatchType) != 0 {
+			return errors.New("exclude_watch_type can only be used with watch mode")
+		}
+		if includeInitialState {
+			return errors.New("include_initial_state can only be used with watch mode")
+		}
+	}
+	return nil
+}
+
+// Convert implements receiver.Arguments.
+func (args Arguments) Convert() (otelcomponent.Config, error) {
+	out := k8sobjectsreceiver.NewFactory().CreateDefaultConfig().(*k8sobjectsreceiver.Config)
+
+	// The Kubernetes API config and the object mode are types from internal
+	// or unexported upstream packages, so they're unmarshaled through confmap.
+	objects := make([]any, 0, len(args.Objects))
+	for _, o := range args.Objects {
+		objects = append(objects, map[string]any{
+			"name":               o.Name,
+			"group":              o.Group,
+			"namespaces":         o.Namespaces,
+			"mode":               o.Mode,
+			"label_selector":     o.LabelSelector,
+			"field_selector":     o.FieldSelector,
+			"interval":           o.Interval,
+			"resource_version":   o.ResourceVersion,
+			"exclude_watch_type": o.ExcludeWatchType,
+		})
+	}
+
+	conf := confmap.NewFromStringMap(map[string]any{
+		"auth_type":             args.KubernetesAPIConfig.AuthType,
+		"context":               args.KubernetesAPIConfig.Context,
+		"objects":               objects,
+		"error_mode":            args.ErrorMode,
+		"include_initial_state": args.IncludeInitialState,
+	})
+	if err := conf.Unmarshal(out); err != nil {
+		return nil, fmt.Errorf("decoding k8sobjects config: %w", err)
+	}
+
+	return out, nil
+}
+
+// Extensions implements receiver.Arguments.
+func (args Arguments) Extensions() map[otelcomponent.ID]otelcomponent.Component {
+	return nil
+}
+
+// Exporters implements receiver.Arguments.
+func (args Arguments) Exporters() map[pipeline.Signal]map[otelcomponent.ID]otelcomponent.Component {
+	return nil
+}
+
+// NextConsumers implements receiver.Arguments.
+func (args Arguments) NextConsumers() *otelcol.ConsumerArguments {
+	return args.Output
+}
+
+// DebugMetricsConfig implements receiver.Arguments.
+func (args Arguments) DebugMetricsConfig() otelcolCfg.DebugMetricsArguments {
+	return args.DebugMetrics
+}
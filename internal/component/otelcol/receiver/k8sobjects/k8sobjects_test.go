@@ -0,0 +1,175 @@
+package k8sobjects_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/grafana/alloy/internal/component/otelcol/receiver/k8sobjects"
+	"github.com/grafana/alloy/internal/nodeconf/clustering"
+	"github.com/grafana/alloy/syntax"
+	"github.com/grafana/alloy/syntax/parser"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sobjectsreceiver"
+	"github.com/stretchr/testify/require"
+	apiWatch "k8s.io/apimachinery/pkg/watch"
+)
+
+func TestArguments(t *testing.T) {
+	in := `
+		auth_type             = "serviceAccount"
+		error_mode            = "ignore"
+		include_initial_state = true
+
+		objects {
+			name               = "events"
+			group              = "events.k8s.io"
+			namespaces         = ["default"]
+			mode               = "watch"
+			label_selector     = "app=web"
+			field_selector     = "type=Warning"
+			resource_version   = "12345"
+			exclude_watch_type = ["DELETED"]
+		}
+
+		objects {
+			name = "pods"
+			mode = "watch"
+		}
+
+		output {}
+	`
+
+	var args k8sobjects.Arguments
+	require.NoError(t, syntax.Unmarshal([]byte(in), &args))
+
+	outAny, err := args.Convert()
+	require.NoError(t, err)
+	out := outAny.(*k8sobjectsreceiver.Config)
+	require.NoError(t, out.Validate())
+
+	require.EqualValues(t, "serviceAccount", out.AuthType)
+	require.Equal(t, k8sobjectsreceiver.IgnoreError, out.ErrorMode)
+	require.True(t, out.IncludeInitialState)
+	require.Nil(t, out.K8sLeaderElector)
+
+	require.Len(t, out.Objects, 2)
+	events := out.Objects[0]
+	require.Equal(t, "events", events.Name)
+	require.Equal(t, "events.k8s.io", events.Group)
+	require.Equal(t, []string{"default"}, events.Namespaces)
+	require.Equal(t, k8sobjectsreceiver.WatchMode, events.Mode)
+	require.Equal(t, "app=web", events.LabelSelector)
+	require.Equal(t, "type=Warning", events.FieldSelector)
+	require.Equal(t, "12345", events.ResourceVersion)
+	require.Equal(t, []apiWatch.EventType{apiWatch.Deleted}, events.ExcludeWatchType)
+
+	require.Equal(t, "pods", out.Objects[1].Name)
+	require.Equal(t, k8sobjectsreceiver.WatchMode, out.Objects[1].Mode)
+}
+
+func TestArguments_Defaults(t *testing.T) {
+	in := `
+		objects {
+			name = "pods"
+		}
+
+		output {}
+	`
+
+	var args k8sobjects.Arguments
+	require.NoError(t, syntax.Unmarshal([]byte(in), &args))
+
+	outAny, err := args.Convert()
+	require.NoError(t, err)
+	out := outAny.(*k8sobjectsreceiver.Config)
+	require.NoError(t, out.Validate())
+
+	require.EqualValues(t, "serviceAccount", out.AuthType)
+	require.Equal(t, k8sobjectsreceiver.PropagateError, out.ErrorMode)
+	require.False(t, out.IncludeInitialState)
+
+	require.Len(t, out.Objects, 1)
+	require.Equal(t, k8sobjectsreceiver.PullMode, out.Objects[0].Mode)
+	require.Equal(t, time.Hour, out.Objects[0].Interval)
+}
+
+func TestArguments_Validate(t *testing.T) {
+	tests := []struct {
+		name        string
+		cfg         string
+		expectedErr string
+	}{
+		{
+			name: "invalid error mode",
+			cfg: `
+				error_mode = "panic"
+				objects {
+					name = "pods"
+				}
+				output {}
+			`,
+			expectedErr: `error_mode must be one of ["propagate" "ignore" "silent"]`,
+		},
+		{
+			name: "invalid mode",
+			cfg: `
+				objects {
+					name = "pods"
+					mode = "stream"
+				}
+				output {}
+			`,
+			expectedErr: `objects 1: mode must be one of ["pull" "watch"]`,
+		},
+		{
+			name: "exclude watch type in pull mode",
+			cfg: `
+				objects {
+					name               = "pods"
+					exclude_watch_type = ["DELETED"]
+				}
+				output {}
+			`,
+			expectedErr: "objects 1: exclude_watch_type can only be used with watch mode",
+		},
+		{
+			name: "invalid watch type",
+			cfg: `
+				objects {
+					name               = "pods"
+					mode               = "watch"
+					exclude_watch_type = ["REMOVED"]
+				}
+				output {}
+			`,
+			expectedErr: "objects 1: exclude_watch_type must only contain",
+		},
+		{
+			name: "initial state in pull mode",
+			cfg: `
+				include_initial_state = true
+				objects {
+					name = "pods"
+				}
+				output {}
+			`,
+			expectedErr: "objects 1: include_initial_state can only be used with watch mode",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var args k8sobjects.Arguments
+			require.ErrorContains(t, syntax.Unmarshal([]byte(tc.cfg), &args), tc.expectedErr)
+		})
+	}
+}
+
+// The clustering block is handled by the component controller, which runs
+// the receiver on a single instance of the cluster in singleton mode.
+func TestArguments_Clustering(t *testing.T) {
+	file, err := parser.ParseFile("", []byte(`clustering { mode = "singleton" }`))
+	require.NoError(t, err)
+
+	_, block := clustering.Split(k8sobjects.Arguments{}, file.Body)
+	require.NotNil(t, block)
+}
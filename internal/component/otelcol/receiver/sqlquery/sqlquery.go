@@ -0,0 +1,245 @@
+// Package sqlquery provides an otelcol.receiver.sqlquery component.
+package sqlquery
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/grafana/alloy/internal/component"
+	"github.com/grafana/alloy/internal/component/otelcol"
+	otelcolCfg "github.com/grafana/alloy/internal/component/otelcol/config"
+	"github.com/grafana/alloy/internal/component/otelcol/extension"
+	"github.com/grafana/alloy/internal/component/otelcol/receiver"
+	"github.com/grafana/alloy/internal/featuregate"
+	"github.com/grafana/alloy/syntax"
+	"github.com/grafana/alloy/syntax/alloytypes"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/sqlqueryreceiver"
+	otelcomponent "go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/pipeline"
+	_ "modernc.org/sqlite" // Register the sqlite driver.
+)
+
+func init() {
+	component.Register(component.Registration{
+		Name:      "otelcol.receiver.sqlquery",
+		Stability: featuregate.StabilityExperimental,
+		Args:      Arguments{},
+
+		Build: func(opts component.Options, args component.Arguments) (component.Component, error) {
+			fact := sqlqueryreceiver.NewFactory()
+			return receiver.New(opts, fact, args.(Arguments))
+		},
+	})
+}
+
+// driverSQLite is the name of the SQLite driver, which the component supports
+// on top of the drivers of the upstream receiver.
+const driverSQLite = "sqlite"
+
+// Arguments configures the otelcol.receiver.sqlquery component.
+type Arguments struct {
+	Driver           string            `alloy:"driver,attr"`
+	DataSource       alloytypes.Secret `alloy:"datasource,attr,optional"`
+	Host             string            `alloy:"host,attr,optional"`
+	Port             int               `alloy:"port,attr,optional"`
+	Database         string            `alloy:"database,attr,optional"`
+	Username         string            `alloy:"username,attr,optional"`
+	Password         alloytypes.Secret `alloy:"password,attr,optional"`
+	AdditionalParams map[string]any    `alloy:"additional_params,attr,optional"`
+	MaxOpenConn      int               `alloy:"max_open_conn,attr,optional"`
+
+	Controller otelcol.ControllerArguments `alloy:",squash"`
+
+	Queries   []Query            `alloy:"query,block"`
+	Telemetry TelemetryArguments `alloy:"telemetry,block,optional"`
+
+	// Storage is a binding to an otelcol.storage.* component extension which
+	// persists the last value of the tracking columns.
+	Storage *extension.ExtensionHandler `alloy:"storage,attr,optional"`
+
+	// DebugMetrics configures component internal metrics. Optional.
+	DebugMetrics otelcolCfg.DebugMetricsArguments `alloy:"debug_metrics,block,optional"`
+
+	// Output configures where to send received data. Required.
+	Output *otelcol.ConsumerArguments `alloy:"output,block"`
+}
+
+// Query is a SQL query and how to convert its rows into telemetry.
+type Query struct {
+	SQL                string   `alloy:"sql,attr"`
+	TrackingColumn     string   `alloy:"tracking_column,attr,optional"`
+	TrackingStartValue string   `alloy:"tracking_start_value,attr,optional"`
+	Metrics            []Metric `alloy:"metric,block,optional"`
+	Logs               []Log    `alloy:"log,block,optional"`
+}
+
+// Metric maps the rows of a query to a metric.
+type Metric struct {
+	MetricName       string            `alloy:"metric_name,attr"`
+	ValueColumn      string            `alloy:"value_column,attr"`
+	AttributeColumns []string          `alloy:"attribute_columns,attr,optional"`
+	Monotonic        bool              `alloy:"monotonic,attr,optional"`
+	ValueType        string            `alloy:"value_type,attr,optional"`
+	DataType         string            `alloy:"data_type,attr,optional"`
+	Aggregation      string            `alloy:"aggregation,attr,optional"`
+	Unit             string            `alloy:"unit,attr,optional"`
+	Description      string            `alloy:"description,attr,optional"`
+	StaticAttributes map[string]string `alloy:"static_attributes,attr,optional"`
+	StartTsColumn    string            `alloy:"start_ts_column,attr,optional"`
+	TsColumn         string            `alloy:"ts_column,attr,optional"`
+}
+
+// Log maps the rows of a query to log records.
+type Log struct {
+	BodyColumn       string   `alloy:"body_column,attr"`
+	AttributeColumns []string `alloy:"attribute_columns,attr,optional"`
+}
+
+// TelemetryArguments configures the telemetry emitted by the receiver itself.
+type TelemetryArguments struct {
+	Logs TelemetryLogsArguments `alloy:"logs,block,optional"`
+}
+
+// TelemetryLogsArguments configures the logs emitted by the receiver itself.
+type TelemetryLogsArguments struct {
+	// Query logs the SQL query when it fails.
+	Query bool `alloy:"query,attr,optional"`
+}
+
+var (
+	_ receiver.Arguments = Arguments{}
+	_ syntax.Defaulter   = (*Arguments)(nil)
+	_ syntax.Validator   = (*Arguments)(nil)
+)
+
+// SetToDefault implements syntax.Defaulter.
+func (args *Arguments) SetToDefault() {
+	*args = Arguments{}
+	args.Controller.SetToDefault()
+	args.Controller.CollectionInterval = 10 * time.Second
+	args.DebugMetrics.SetToDefault()
+}
+
+// Validate implements syntax.Validator.
+func (args *Arguments) Validate() error {
+	cfg, err := args.Convert()
+	if err != nil {
+		return err
+	}
+	otelCfg := cfg.(*sqlqueryreceiver.Config)
+
+	if args.Driver == driverSQLite {
+		// SQLite databases are local files which can only be opened with a
+		// datasource.
+		if args.DataSource == "" {
+			return fmt.Errorf("datasource must be set when driver is %q", driverSQLite)
+		}
+		// The upstream receiver only accepts the drivers it imports, so the
+		// rest of the configuration is validated as for one of them.
+		otelCfg.Driver = "postgres"
+	}
+	return otelCfg.Validate()
+}
+
+// Convert implements receiver.Arguments.
+func (args Arguments) Convert() (otelcomponent.Config, error) {
+	out := sqlqueryreceiver.NewFactory().CreateDefaultConfig().(*sqlqueryreceiver.Config)
+
+	// The query configs are types from an internal upstream package, so the
+	// whole config is unmarshaled through confmap.
+	conf := confmap.NewFromStringMap(map[string]any{
+		"driver":            args.Driver,
+		"datasource":        string(args.DataSource),
+		"host":              args.Host,
+		"port":              args.Port,
+		"database":          args.Database,
+		"username":          args.Username,
+		"password":          string(args.Password),
+		"additional_params": args.AdditionalParams,
+		"max_open_conn":     args.MaxOpenConn,
+		"queries":           args.queries(),
+		"telemetry": map[string]any{
+			"logs": map[string]any{
+				"query": args.Telemetry.Logs.Query,
+			},
+		},
+	})
+	if err := conf.Unmarshal(out); err != nil {
+		return nil, fmt.Errorf("decoding sqlquery config: %w", err)
+	}
+
+	out.ControllerConfig = *args.Controller.Convert()
+
+	if args.Storage != nil {
+		if args.Storage.Extension == nil {
+			return nil, errors.New("missing storage extension")
+		}
+		out.StorageID = &args.Storage.ID
+	}
+
+	return out, nil
+}
+
+func (args Arguments) queries() []any {
+	queries := make([]any, 0, len(args.Queries))
+	for _, q := range args.Queries {
+		metrics := make([]any, 0, len(q.Metrics))
+		for _, m := range q.Metrics {
+			metrics = append(metrics, map[string]any{
+				"metric_name":       m.MetricName,
+				"value_column":      m.ValueColumn,
+				"attribute_columns": m.AttributeColumns,
+				"monotonic":         m.Monotonic,
+				"value_type":        m.ValueType,
+				"data_type":         m.DataType,
+				"aggregation":       m.Aggregation,
+				"unit":              m.Unit,
+				"description":       m.Description,
+				"static_attributes": m.StaticAttributes,
+				"start_ts_column":   m.StartTsColumn,
+				"ts_column":         m.TsColumn,
+			})
+		}
+		logs := make([]any, 0, len(q.Logs))
+		for _, l := range q.Logs {
+			logs = append(logs, map[string]any{
+				"body_column":       l.BodyColumn,
+				"attribute_columns": l.AttributeColumns,
+			})
+		}
+		queries = append(queries, map[string]any{
+			"sql":                  q.SQL,
+			"tracking_column":      q.TrackingColumn,
+			"tracking_start_value": q.TrackingStartValue,
+			"metrics":              metrics,
+			"logs":                 logs,
+		})
+	}
+	return queries
+}
+
+// Extensions implements receiver.Arguments.
+func (args Arguments) Extensions() map[otelcomponent.ID]otelcomponent.Component {
+	m := make(map[otelcomponent.ID]otelcomponent.Component)
+	if args.Storage != nil {
+		m[args.Storage.ID] = args.Storage.Extension
+	}
+	return m
+}
+
+// Exporters implements receiver.Arguments.
+func (args Arguments) Exporters() map[pipeline.Signal]map[otelcomponent.ID]otelcomponent.Component {
+	return nil
+}
+
+// NextConsumers implements receiver.Arguments.
+func (args Arguments) NextConsumers() *otelcol.ConsumerArguments {
+	return args.Output
+}
+
+// DebugMetricsConfig implements receiver.Arguments.
+func (args Arguments) DebugMetricsConfig() otelcolCfg.DebugMetricsArguments {
+	return args.DebugMetrics
+}
@@ -0,0 +1,453 @@
+package sqlquery_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/grafana/alloy/internal/component/otelcol"
+	"github.com/grafana/alloy/internal/component/otelcol/extension"
+	"github.com/grafana/alloy/internal/component/otelcol/internal/fakeconsumer"
+	"github.com/grafana/alloy/internal/component/otelcol/receiver/sqlquery"
+	"github.com/grafana/alloy/internal/component/otelcol/storage/file"
+	"github.com/grafana/alloy/internal/runtime/componenttest"
+	"github.com/grafana/alloy/internal/util"
+	"github.com/grafana/alloy/syntax"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/sqlqueryreceiver"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func TestArguments(t *testing.T) {
+	in := `
+		driver     = "postgres"
+		datasource = "host=localhost port=5432 user=me password=s3cr3t sslmode=disable"
+		collection_interval = "30s"
+		max_open_conn       = 5
+
+		query {
+			sql = "select count(*) as count, status from jobs group by status"
+
+			metric {
+				metric_name       = "jobs.count"
+				value_column      = "count"
+				attribute_columns = ["status"]
+				value_type        = "int"
+				data_type         = "gauge"
+				static_attributes = {
+					table = "jobs",
+				}
+			}
+		}
+
+		query {
+			sql                  = "select id, body, actor from audit where id > $1 order by id"
+			tracking_column      = "id"
+			tracking_start_value = "0"
+
+			log {
+				body_column       = "body"
+				attribute_columns = ["actor"]
+			}
+		}
+
+		telemetry {
+			logs {
+				query = true
+			}
+		}
+
+		output {}
+	`
+
+	var args sqlquery.Arguments
+	require.NoError(t, syntax.Unmarshal([]byte(in), &args))
+
+	outAny, err := args.Convert()
+	require.NoError(t, err)
+	out := outAny.(*sqlqueryreceiver.Config)
+	require.NoError(t, out.Validate())
+
+	require.Equal(t, "postgres", out.Driver)
+	require.Equal(t, "host=localhost port=5432 user=me password=s3cr3t sslmode=disable", out.DataSource)
+	require.Equal(t, 30*time.Second, out.CollectionInterval)
+	require.Equal(t, 5, out.MaxOpenConn)
+	require.True(t, out.Telemetry.Logs.Query)
+	require.Nil(t, out.StorageID)
+
+	require.Len(t, out.Queries, 2)
+
+	metricsQuery := out.Queries[0]
+	require.Equal(t, "select count(*) as count, status from jobs group by status", metricsQuery.SQL)
+	require.Len(t, metricsQuery.Metrics, 1)
+	require.Equal(t, "jobs.count", metricsQuery.Metrics[0].MetricName)
+	require.Equal(t, "count", metricsQuery.Metrics[0].ValueColumn)
+	require.Equal(t, []string{"status"}, metricsQuery.Metrics[0].AttributeColumns)
+	require.EqualValues(t, "int", metricsQuery.Metrics[0].ValueType)
+	require.EqualValues(t, "gauge", metricsQuery.Metrics[0].DataType)
+	require.Equal(t, map[string]string{"table": "jobs"}, metricsQuery.Metrics[0].StaticAttributes)
+	require.Empty(t, metricsQuery.Logs)
+
+	logsQuery := out.Queries[1]
+	require.Equal(t, "id", logsQuery.TrackingColumn)
+	require.Equal(t, "0", logsQuery.TrackingStartValue)
+	require.Len(t, logsQuery.Logs, 1)
+	require.Equal(t, "body", logsQuery.Logs[0].BodyColumn)
+	require.Equal(t, []string{"actor"}, logsQuery.Logs[0].AttributeColumns)
+	require.Empty(t, logsQuery.Metrics)
+}
+
+func TestArguments_Defaults(t *testing.T) {
+	in := `
+		driver   = "mysql"
+		host     = "localhost"
+		port     = 3306
+		database = "app"
+		username = "alloy"
+		password = "secret"
+
+		query {
+			sql = "select 1 as one"
+
+			metric {
+				metric_name  = "one"
+				value_column = "one"
+			}
+		}
+
+		output {}
+	`
+
+	var args sqlquery.Arguments
+	require.NoError(t, syntax.Unmarshal([]byte(in), &args))
+
+	outAny, err := args.Convert()
+	require.NoError(t, err)
+	out := outAny.(*sqlqueryreceiver.Config)
+
+	require.Equal(t, 10*time.Second, out.CollectionInterval)
+	require.Equal(t, time.Second, out.InitialDelay)
+	require.Equal(t, "localhost", out.Host)
+	require.Equal(t, 3306, out.Port)
+	require.Equal(t, "app", out.Database)
+	require.Equal(t, "alloy", out.Username)
+	require.EqualValues(t, "secret", out.Password)
+	require.False(t, out.Telemetry.Logs.Query)
+}
+
+func TestArguments_Validate(t *testing.T) {
+	tests := []struct {
+		name        string
+		cfg         string
+		expectedErr string
+	}{
+		{
+			name: "unsupported driver",
+			cfg: `
+				driver     = "sqlite3"
+				datasource = "file::memory:"
+				query {
+					sql = "select 1 as one"
+					metric {
+						metric_name  = "one"
+						value_column = "one"
+					}
+				}
+				output {}
+			`,
+			expectedErr: "unsupported driver: sqlite3",
+		},
+		{
+			name: "sqlite without datasource",
+			cfg: `
+				driver   = "sqlite"
+				host     = "localhost"
+				database = "test"
+				query {
+					sql = "select 1 as one"
+					metric {
+						metric_name  = "one"
+						value_column = "one"
+					}
+				}
+				output {}
+			`,
+			expectedErr: `datasource must be set when driver is "sqlite"`,
+		},
+		{
+			name: "sqlite with host",
+			cfg: `
+				driver     = "sqlite"
+				datasource = "file::memory:"
+				host       = "localhost"
+				query {
+					sql = "select 1 as one"
+					metric {
+						metric_name  = "one"
+						value_column = "one"
+					}
+				}
+				output {}
+			`,
+			expectedErr: "'host' cannot be set when 'datasource' is specified",
+		},
+		{
+			name: "datasource and host",
+			cfg: `
+				driver     = "postgres"
+				datasource = "host=localhost"
+				host       = "localhost"
+				query {
+					sql = "select 1 as one"
+					metric {
+						metric_name  = "one"
+						value_column = "one"
+					}
+				}
+				output {}
+			`,
+			expectedErr: "'host' cannot be set when 'datasource' is specified",
+		},
+		{
+			name: "query without metrics or logs",
+			cfg: `
+				driver     = "postgres"
+				datasource = "host=localhost"
+				query {
+					sql = "select 1 as one"
+				}
+				output {}
+			`,
+			expectedErr: "at least one of 'query.logs' and 'query.metrics' must not be empty",
+		},
+		{
+			name: "invalid value type",
+			cfg: `
+				driver     = "postgres"
+				datasource = "host=localhost"
+				query {
+					sql = "select 1 as one"
+					metric {
+						metric_name  = "one"
+						value_column = "one"
+						value_type   = "string"
+					}
+				}
+				output {}
+			`,
+			expectedErr: "metric config has unsupported value_type: 'string'",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var args sqlquery.Arguments
+			err := syntax.Unmarshal([]byte(tc.cfg), &args)
+			require.ErrorContains(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestReceiver_Metrics(t *testing.T) {
+	db, datasource := newTestDB(t, `
+		create table jobs (id integer primary key, status text);
+		insert into jobs (status) values ('done'), ('done'), ('failed');
+	`)
+	defer db.Close()
+
+	args := newTestArguments(t, datasource, `
+		query {
+			sql = "select count(*) as count, status from jobs group by status order by status"
+
+			metric {
+				metric_name       = "jobs.count"
+				value_column      = "count"
+				attribute_columns = ["status"]
+				value_type        = "int"
+			}
+		}
+	`)
+
+	metricsCh := make(chan pmetric.Metrics)
+	args.Output = &otelcol.ConsumerArguments{
+		Metrics: []otelcol.Consumer{&fakeconsumer.Consumer{
+			ConsumeMetricsFunc: func(ctx context.Context, m pmetric.Metrics) error {
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case metricsCh <- m:
+					return nil
+				}
+			},
+		}},
+	}
+
+	ctx, cancel := context.WithCancel(componenttest.TestContext(t))
+	defer cancel()
+	runReceiver(t, ctx, args)
+
+	select {
+	case <-time.After(10 * time.Second):
+		require.FailNow(t, "failed waiting for metrics")
+	case m := <-metricsCh:
+		points := m.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+		require.Equal(t, 2, points.Len())
+
+		counts := make(map[string]int64)
+		for i := 0; i < points.Len(); i++ {
+			metric := points.At(i)
+			require.Equal(t, "jobs.count", metric.Name())
+			dp := metric.Gauge().DataPoints().At(0)
+			status, _ := dp.Attributes().Get("status")
+			counts[status.Str()] = dp.IntValue()
+		}
+		require.Equal(t, map[string]int64{"done": 2, "failed": 1}, counts)
+	}
+}
+
+func TestReceiver_LogsTrackingColumn(t *testing.T) {
+	db, datasource := newTestDB(t, `
+		create table audit (id integer primary key, body text, actor text);
+		insert into audit (body, actor) values ('created', 'alice'), ('updated', 'bob');
+	`)
+	defer db.Close()
+
+	ctx := componenttest.TestContext(t)
+	storage := runFileStorage(t, ctx)
+
+	args := newTestArguments(t, datasource, `
+		query {
+			sql                  = "select id, body, actor from audit where id > ? order by id"
+			tracking_column      = "id"
+			tracking_start_value = "0"
+
+			log {
+				body_column       = "body"
+				attribute_columns = ["actor"]
+			}
+		}
+	`)
+	args.Storage = storage
+
+	logsCh := make(chan plog.Logs, 10)
+	args.Output = &otelcol.ConsumerArguments{
+		Logs: []otelcol.Consumer{&fakeconsumer.Consumer{
+			ConsumeLogsFunc: func(ctx context.Context, l plog.Logs) error {
+				if l.LogRecordCount() == 0 {
+					return nil
+				}
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case logsCh <- l:
+					return nil
+				}
+			},
+		}},
+	}
+
+	receiverCtx, cancel := context.WithCancel(ctx)
+	done := runReceiver(t, receiverCtx, args)
+	require.Equal(t, []string{"created alice", "updated bob"}, receiveLogs(t, logsCh))
+
+	// Stop the receiver, and check that the tracking value it persisted is
+	// used when it starts again.
+	cancel()
+	<-done
+
+	_, err := db.Exec(`insert into audit (body, actor) values ('deleted', 'carol')`)
+	require.NoError(t, err)
+
+	receiverCtx, cancel = context.WithCancel(ctx)
+	defer cancel()
+	runReceiver(t, receiverCtx, args)
+	require.Equal(t, []string{"deleted carol"}, receiveLogs(t, logsCh))
+}
+
+// newTestDB creates a SQLite database initialized with the schema statements.
+func newTestDB(t *testing.T, schema string) (*sql.DB, string) {
+	t.Helper()
+
+	datasource := "file:" + filepath.Join(t.TempDir(), "test.db")
+	db, err := sql.Open("sqlite", datasource)
+	require.NoError(t, err)
+	_, err = db.Exec(schema)
+	require.NoError(t, err)
+	return db, datasource
+}
+
+// newTestArguments returns the arguments of a receiver running the queries
+// against the SQLite database at datasource.
+func newTestArguments(t *testing.T, datasource string, queries string) sqlquery.Arguments {
+	t.Helper()
+
+	var args sqlquery.Arguments
+	require.NoError(t, syntax.Unmarshal([]byte(fmt.Sprintf(`
+		driver              = "sqlite"
+		datasource          = %q
+		collection_interval = "100ms"
+		%s
+		output {}
+	`, datasource, queries)), &args))
+	return args
+}
+
+// runReceiver runs an otelcol.receiver.sqlquery component until ctx is
+// canceled. The returned channel is closed once the component exited.
+func runReceiver(t *testing.T, ctx context.Context, args sqlquery.Arguments) <-chan struct{} {
+	t.Helper()
+
+	ctrl, err := componenttest.NewControllerFromID(util.TestLogger(t), "otelcol.receiver.sqlquery")
+	require.NoError(t, err)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		require.NoError(t, ctrl.Run(ctx, args))
+	}()
+	require.NoError(t, ctrl.WaitRunning(3*time.Second))
+	return done
+}
+
+// runFileStorage runs an otelcol.storage.file component and returns its
+// handler.
+func runFileStorage(t *testing.T, ctx context.Context) *extension.ExtensionHandler {
+	t.Helper()
+
+	ctrl, err := componenttest.NewControllerFromID(util.TestLogger(t), "otelcol.storage.file")
+	require.NoError(t, err)
+
+	var args file.Arguments
+	args.SetToDefault()
+	args.Directory = t.TempDir()
+
+	go func() {
+		require.NoError(t, ctrl.Run(ctx, args))
+	}()
+	require.NoError(t, ctrl.WaitExports(3*time.Second))
+	return ctrl.Exports().(extension.Exports).Handler
+}
+
+// receiveLogs waits for a batch of logs and returns each record as its body
+// followed by its actor attribute.
+func receiveLogs(t *testing.T, logsCh <-chan plog.Logs) []string {
+	t.Helper()
+
+	select {
+	case <-time.After(10 * time.Second):
+		require.FailNow(t, "failed waiting for logs")
+		return nil
+	case l := <-logsCh:
+		var records []string
+		lrs := l.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+		for i := 0; i < lrs.Len(); i++ {
+			actor, _ := lrs.At(i).Attributes().Get("actor")
+			records = append(records, lrs.At(i).Body().Str()+" "+actor.Str())
+		}
+		return records
+	}
+}
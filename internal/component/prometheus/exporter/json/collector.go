@@ -0,0 +1,312 @@
+package json
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/ohler55/ojg/jp"
+	"github.com/ohler55/ojg/oj"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	prom_config "github.com/prometheus/common/config"
+
+	"github.com/grafana/alloy/internal/runtime/logging/level"
+	"github.com/grafana/alloy/internal/static/integrations"
+	"github.com/grafana/alloy/internal/static/integrations/config"
+	"github.com/grafana/alloy/internal/useragent"
+)
+
+var _ integrations.Config = (*Config)(nil)
+
+// Config is the integration configuration built from Arguments.
+type Config struct {
+	name             string
+	targets          []string
+	timeout          time.Duration
+	metrics          []Metric
+	httpClientConfig prom_config.HTTPClientConfig
+}
+
+// Name implements integrations.Config.
+func (c *Config) Name() string {
+	return "json"
+}
+
+// InstanceKey implements integrations.Config.
+func (c *Config) InstanceKey(key string) (string, error) {
+	return key, nil
+}
+
+// NewIntegration implements integrations.Config.
+func (c *Config) NewIntegration(l log.Logger) (integrations.Integration, error) {
+	client, err := prom_config.NewClientFromConfig(c.httpClientConfig, c.name, prom_config.WithUserAgent(useragent.Get()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP client: %w", err)
+	}
+
+	metrics := make([]compiledMetric, 0, len(c.metrics))
+	for _, m := range c.metrics {
+		cm, err := compileMetric(m)
+		if err != nil {
+			return nil, err
+		}
+		metrics = append(metrics, cm)
+	}
+
+	return &Integration{
+		cfg:     *c,
+		log:     l,
+		client:  client,
+		metrics: metrics,
+	}, nil
+}
+
+// Integration fetches JSON documents on every scrape and converts them into
+// metrics.
+type Integration struct {
+	cfg     Config
+	log     log.Logger
+	client  *http.Client
+	metrics []compiledMetric
+}
+
+// MetricsHandler implements integrations.Integration. The handler expects a
+// target query parameter which must match one of the configured targets.
+func (i *Integration) MetricsHandler() (http.Handler, error) {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		target := r.URL.Query().Get("target")
+		if target == "" {
+			http.Error(w, "target parameter is missing", http.StatusBadRequest)
+			return
+		}
+		if !slices.Contains(i.cfg.targets, target) {
+			http.Error(w, fmt.Sprintf("unknown target %q", target), http.StatusBadRequest)
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), i.cfg.timeout)
+		defer cancel()
+
+		doc, err := i.fetch(ctx, target)
+		if err != nil {
+			level.Error(i.log).Log("msg", "failed to fetch JSON document", "target", target, "err", err)
+			http.Error(w, fmt.Sprintf("failed to fetch JSON document: %s", err), http.StatusInternalServerError)
+			return
+		}
+
+		reg := prometheus.NewRegistry()
+		if err := reg.Register(&collector{log: i.log, target: target, doc: doc, metrics: i.metrics}); err != nil {
+			http.Error(w, fmt.Sprintf("failed to register collector: %s", err), http.StatusInternalServerError)
+			return
+		}
+		promhttp.HandlerFor(reg, promhttp.HandlerOpts{ErrorHandling: promhttp.ContinueOnError}).ServeHTTP(w, r)
+	}), nil
+}
+
+// fetch performs a GET request against target and parses the response body.
+func (i *Integration) fetch(ctx context.Context, target string) (any, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, fmt.Errorf("building request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := i.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("performing request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %s", resp.Status)
+	}
+
+	bb, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading response: %w", err)
+	}
+	return oj.Parse(bb)
+}
+
+// Run implements integrations.Integration.
+func (i *Integration) Run(ctx context.Context) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+// ScrapeConfigs implements integrations.Integration.
+func (i *Integration) ScrapeConfigs() []config.ScrapeConfig {
+	return []config.ScrapeConfig{{
+		JobName:     i.cfg.Name(),
+		MetricsPath: "/metrics",
+	}}
+}
+
+// compiledMetric is a Metric with all of its JSONPath expressions parsed.
+type compiledMetric struct {
+	desc       *prometheus.Desc
+	valueType  prometheus.ValueType
+	path       jp.Expr
+	value      jp.Expr
+	labelNames []string
+	labels     []jp.Expr
+}
+
+func compileMetric(m Metric) (compiledMetric, error) {
+	path, err := jp.ParseString(m.Path)
+	if err != nil {
+		return compiledMetric{}, fmt.Errorf("metric %q: invalid path: %w", m.Name, err)
+	}
+	value, err := jp.ParseString(m.Value)
+	if err != nil {
+		return compiledMetric{}, fmt.Errorf("metric %q: invalid value path: %w", m.Name, err)
+	}
+
+	labelNames := make([]string, 0, len(m.Labels))
+	for name := range m.Labels {
+		labelNames = append(labelNames, name)
+	}
+	slices.Sort(labelNames)
+
+	labels := make([]jp.Expr, 0, len(labelNames))
+	for _, name := range labelNames {
+		expr, err := jp.ParseString(m.Labels[name])
+		if err != nil {
+			return compiledMetric{}, fmt.Errorf("metric %q: invalid path for label %q: %w", m.Name, name, err)
+		}
+		labels = append(labels, expr)
+	}
+
+	valueType := prometheus.GaugeValue
+	switch m.Type {
+	case MetricTypeCounter:
+		valueType = prometheus.CounterValue
+	case MetricTypeUntyped:
+		valueType = prometheus.UntypedValue
+	}
+
+	help := m.Help
+	if help == "" {
+		help = fmt.Sprintf("Value extracted from %s", m.Path)
+	}
+
+	return compiledMetric{
+		desc:       prometheus.NewDesc(m.Name, help, labelNames, nil),
+		valueType:  valueType,
+		path:       path,
+		value:      value,
+		labelNames: labelNames,
+		labels:     labels,
+	}, nil
+}
+
+// collector is an unchecked prometheus.Collector which emits metrics for a
+// single parsed JSON document.
+type collector struct {
+	log     log.Logger
+	target  string
+	doc     any
+	metrics []compiledMetric
+}
+
+// Describe implements prometheus.Collector. Nothing is sent so the collector
+// is treated as unchecked.
+func (c *collector) Describe(chan<- *prometheus.Desc) {}
+
+// Collect implements prometheus.Collector.
+func (c *collector) Collect(ch chan<- prometheus.Metric) {
+	for _, m := range c.metrics {
+		for _, node := range m.path.Get(c.doc) {
+			value, ok := c.extractValue(m, node)
+			if !ok {
+				continue
+			}
+
+			labelValues := make([]string, len(m.labels))
+			for i, expr := range m.labels {
+				labelValues[i] = toLabelValue(first(evaluate(expr, c.doc, node)))
+			}
+
+			metric, err := prometheus.NewConstMetric(m.desc, m.valueType, value, labelValues...)
+			if err != nil {
+				level.Debug(c.log).Log("msg", "failed to create metric", "target", c.target, "metric", m.desc, "err", err)
+				continue
+			}
+			ch <- metric
+		}
+	}
+}
+
+func (c *collector) extractValue(m compiledMetric, node any) (float64, bool) {
+	raw := evaluate(m.value, c.doc, node)
+	if len(raw) == 0 {
+		level.Debug(c.log).Log("msg", "value path did not match", "target", c.target, "metric", m.desc)
+		return 0, false
+	}
+	value, err := toFloat(raw[0])
+	if err != nil {
+		level.Debug(c.log).Log("msg", "failed to convert value", "target", c.target, "metric", m.desc, "err", err)
+		return 0, false
+	}
+	return value, true
+}
+
+// evaluate runs expr against the document root if it starts with "$", or
+// against the currently selected node otherwise.
+func evaluate(expr jp.Expr, doc, node any) []any {
+	if len(expr) > 0 {
+		if _, ok := expr[0].(jp.Root); ok {
+			return expr.Get(doc)
+		}
+	}
+	return expr.Get(node)
+}
+
+func first(results []any) any {
+	if len(results) == 0 {
+		return nil
+	}
+	return results[0]
+}
+
+func toFloat(v any) (float64, error) {
+	switch v := v.(type) {
+	case int64:
+		return float64(v), nil
+	case float64:
+		return v, nil
+	case bool:
+		if v {
+			return 1, nil
+		}
+		return 0, nil
+	case string:
+		return strconv.ParseFloat(strings.TrimSpace(v), 64)
+	default:
+		return 0, fmt.Errorf("unsupported value type %T", v)
+	}
+}
+
+func toLabelValue(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		return oj.JSON(v, &oj.Options{Sort: true})
+	}
+}
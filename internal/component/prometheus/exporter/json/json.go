@@ -0,0 +1,203 @@
+// Package json implements the prometheus.exporter.json component.
+package json
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ohler55/ojg/jp"
+	"github.com/prometheus/common/model"
+
+	"github.com/grafana/alloy/internal/component"
+	common_config "github.com/grafana/alloy/internal/component/common/config"
+	"github.com/grafana/alloy/internal/component/discovery"
+	"github.com/grafana/alloy/internal/component/prometheus/exporter"
+	"github.com/grafana/alloy/internal/featuregate"
+	"github.com/grafana/alloy/internal/static/integrations"
+)
+
+func init() {
+	component.Register(component.Registration{
+		Name:      "prometheus.exporter.json",
+		Stability: featuregate.StabilityExperimental,
+		Args:      Arguments{},
+		Exports:   exporter.Exports{},
+
+		Build: exporter.NewWithTargetBuilder(createExporter, "json", buildJSONTargets),
+	})
+}
+
+func createExporter(opts component.Options, args component.Arguments) (integrations.Integration, string, error) {
+	a := args.(Arguments)
+	return integrations.NewIntegrationWithInstanceKey(opts.Logger, a.Convert(opts.ID), opts.ID)
+}
+
+// buildJSONTargets creates one discovery target per configured JSON target.
+func buildJSONTargets(baseTarget discovery.Target, args component.Arguments) []discovery.Target {
+	var targets []discovery.Target
+
+	for _, tgt := range args.(Arguments).Targets {
+		target := make(map[string]string, len(tgt)+baseTarget.Len())
+		// Set extra labels first, meaning that any other labels will override
+		for k, v := range tgt {
+			if k == "name" || k == "address" || k == model.AddressLabel {
+				continue
+			}
+			target[k] = v
+		}
+		baseTarget.ForEachLabel(func(key string, value string) bool {
+			target[key] = value
+			return true
+		})
+
+		address, _ := getAddress(tgt)
+		target["job"] = target["job"] + "/" + tgt["name"]
+		target["__param_target"] = address
+
+		targets = append(targets, discovery.NewTargetFromMap(target))
+	}
+
+	return targets
+}
+
+// Metric type names supported by the metric block.
+const (
+	MetricTypeGauge   = "gauge"
+	MetricTypeCounter = "counter"
+	MetricTypeUntyped = "untyped"
+)
+
+// DefaultArguments holds non-zero default options for Arguments when it is
+// unmarshaled from Alloy.
+var DefaultArguments = Arguments{
+	Timeout:          10 * time.Second,
+	HTTPClientConfig: common_config.DefaultHTTPClientConfig,
+}
+
+// DefaultMetric holds the default settings for a metric block.
+var DefaultMetric = Metric{
+	Type:  MetricTypeGauge,
+	Value: "@",
+}
+
+// Arguments configures the prometheus.exporter.json component.
+type Arguments struct {
+	Targets          TargetsList                    `alloy:"targets,attr"`
+	Timeout          time.Duration                  `alloy:"timeout,attr,optional"`
+	Metrics          []Metric                       `alloy:"metric,block"`
+	HTTPClientConfig common_config.HTTPClientConfig `alloy:",squash"`
+}
+
+// TargetsList is a list of JSON endpoints to fetch.
+type TargetsList []map[string]string
+
+// Metric describes how to extract one metric family from a JSON document.
+type Metric struct {
+	Name   string            `alloy:"name,attr"`
+	Help   string            `alloy:"help,attr,optional"`
+	Type   string            `alloy:"type,attr,optional"`
+	Path   string            `alloy:"path,attr"`
+	Value  string            `alloy:"value,attr,optional"`
+	Labels map[string]string `alloy:"labels,attr,optional"`
+}
+
+// SetToDefault implements syntax.Defaulter.
+func (m *Metric) SetToDefault() {
+	*m = DefaultMetric
+}
+
+// Validate implements syntax.Validator.
+func (m *Metric) Validate() error {
+	if !model.LegacyValidation.IsValidMetricName(m.Name) {
+		return fmt.Errorf("invalid metric name %q", m.Name)
+	}
+
+	switch m.Type {
+	case MetricTypeGauge, MetricTypeCounter, MetricTypeUntyped:
+	default:
+		return fmt.Errorf("metric %q: unsupported type %q, must be one of %q, %q or %q", m.Name, m.Type, MetricTypeGauge, MetricTypeCounter, MetricTypeUntyped)
+	}
+
+	if _, err := jp.ParseString(m.Path); err != nil {
+		return fmt.Errorf("metric %q: invalid path: %w", m.Name, err)
+	}
+	if _, err := jp.ParseString(m.Value); err != nil {
+		return fmt.Errorf("metric %q: invalid value path: %w", m.Name, err)
+	}
+	for name, path := range m.Labels {
+		if !model.LegacyValidation.IsValidLabelName(name) {
+			return fmt.Errorf("metric %q: invalid label name %q", m.Name, name)
+		}
+		if _, err := jp.ParseString(path); err != nil {
+			return fmt.Errorf("metric %q: invalid path for label %q: %w", m.Name, name, err)
+		}
+	}
+	return nil
+}
+
+// SetToDefault implements syntax.Defaulter.
+func (a *Arguments) SetToDefault() {
+	*a = DefaultArguments
+}
+
+// Validate implements syntax.Validator.
+func (a *Arguments) Validate() error {
+	if a.Timeout <= 0 {
+		return errors.New("timeout must be greater than 0")
+	}
+	if len(a.Metrics) == 0 {
+		return errors.New("at least one metric block must be defined")
+	}
+
+	names := make(map[string]struct{}, len(a.Targets))
+	for _, target := range a.Targets {
+		name, hasName := target["name"]
+		if !hasName {
+			return errors.New("all targets must have a `name`")
+		}
+		if _, hasAddress := getAddress(target); !hasAddress {
+			return errors.New("all targets must have an `address` or an `__address__` label")
+		}
+		if _, ok := names[name]; ok {
+			return fmt.Errorf("duplicate target name %q", name)
+		}
+		names[name] = struct{}{}
+	}
+
+	metrics := make(map[string]struct{}, len(a.Metrics))
+	for _, m := range a.Metrics {
+		if _, ok := metrics[m.Name]; ok {
+			return fmt.Errorf("duplicate metric name %q", m.Name)
+		}
+		metrics[m.Name] = struct{}{}
+	}
+
+	return a.HTTPClientConfig.Validate()
+}
+
+// Convert converts the component's Arguments to the integration's Config.
+func (a *Arguments) Convert(name string) *Config {
+	addresses := make([]string, 0, len(a.Targets))
+	for _, target := range a.Targets {
+		address, _ := getAddress(target)
+		addresses = append(addresses, address)
+	}
+	return &Config{
+		name:             name,
+		targets:          addresses,
+		timeout:          a.Timeout,
+		metrics:          a.Metrics,
+		httpClientConfig: *a.HTTPClientConfig.Convert(),
+	}
+}
+
+func getAddress(data map[string]string) (string, bool) {
+	if value, ok := data["address"]; ok {
+		return value, true
+	}
+	if value, ok := data[model.AddressLabel]; ok {
+		return value, true
+	}
+	return "", false
+}
@@ -0,0 +1,219 @@
+package json
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/alloy/internal/component"
+	"github.com/grafana/alloy/internal/component/discovery"
+	"github.com/grafana/alloy/syntax"
+)
+
+const statusDocument = `{
+	"version": "1.2.3",
+	"healthy": true,
+	"queues": [
+		{"name": "emails", "depth": 12, "processed": "1500"},
+		{"name": "reports", "depth": 3, "processed": "42"}
+	]
+}`
+
+func TestUnmarshalAlloy(t *testing.T) {
+	alloyCfg := `
+		targets = [
+			{"name" = "svc_a", "address" = "http://svc-a:8080/status", "env" = "dev"},
+			{"name" = "svc_b", "__address__" = "http://svc-b:8080/status"},
+		]
+		timeout = "5s"
+		bearer_token = "token"
+
+		metric {
+			name   = "queue_depth"
+			path   = "$.queues[*]"
+			value  = "@.depth"
+			labels = { "queue" = "@.name" }
+		}
+
+		metric {
+			name = "service_healthy"
+			path = "$.healthy"
+		}
+`
+	var args Arguments
+	err := syntax.Unmarshal([]byte(alloyCfg), &args)
+	require.NoError(t, err)
+
+	require.Len(t, args.Targets, 2)
+	require.Equal(t, 5*time.Second, args.Timeout)
+	require.Len(t, args.Metrics, 2)
+	require.Equal(t, Metric{
+		Name:   "queue_depth",
+		Type:   MetricTypeGauge,
+		Path:   "$.queues[*]",
+		Value:  "@.depth",
+		Labels: map[string]string{"queue": "@.name"},
+	}, args.Metrics[0])
+	require.Equal(t, "@", args.Metrics[1].Value)
+	require.NotNil(t, args.HTTPClientConfig.Authorization)
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		cfg    string
+		errMsg string
+	}{
+		{
+			name: "missing target name",
+			cfg: `
+				targets = [{"address" = "http://localhost"}]
+				metric {
+					name = "up"
+					path = "$.up"
+				}`,
+			errMsg: "all targets must have a `name`",
+		},
+		{
+			name: "missing target address",
+			cfg: `
+				targets = [{"name" = "a"}]
+				metric {
+					name = "up"
+					path = "$.up"
+				}`,
+			errMsg: "all targets must have an `address` or an `__address__` label",
+		},
+		{
+			name: "invalid metric type",
+			cfg: `
+				targets = [{"name" = "a", "address" = "http://localhost"}]
+				metric {
+					name = "up"
+					type = "histogram"
+					path = "$.up"
+				}`,
+			errMsg: `metric "up": unsupported type "histogram"`,
+		},
+		{
+			name: "invalid path",
+			cfg: `
+				targets = [{"name" = "a", "address" = "http://localhost"}]
+				metric {
+					name = "up"
+					path = "$.[up"
+				}`,
+			errMsg: `metric "up": invalid path`,
+		},
+		{
+			name: "duplicate metric",
+			cfg: `
+				targets = [{"name" = "a", "address" = "http://localhost"}]
+				metric {
+					name = "up"
+					path = "$.up"
+				}
+				metric {
+					name = "up"
+					path = "$.down"
+				}`,
+			errMsg: `duplicate metric name "up"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var args Arguments
+			err := syntax.Unmarshal([]byte(tt.cfg), &args)
+			require.ErrorContains(t, err, tt.errMsg)
+		})
+	}
+}
+
+func TestBuildTargets(t *testing.T) {
+	baseTarget := discovery.NewTargetFromMap(map[string]string{
+		"job":      "integrations/json",
+		"instance": "prometheus.exporter.json.default",
+	})
+	args := Arguments{
+		Targets: TargetsList{
+			{"name": "svc_a", "address": "http://svc-a/status", "env": "dev"},
+		},
+	}
+
+	targets := buildJSONTargets(baseTarget, args)
+	require.Len(t, targets, 1)
+
+	labels := targets[0].AsMap()
+	require.Equal(t, "integrations/json/svc_a", labels["job"])
+	require.Equal(t, "http://svc-a/status", labels["__param_target"])
+	require.Equal(t, "dev", labels["env"])
+	require.NotContains(t, labels, "name")
+	require.NotContains(t, labels, "address")
+}
+
+func TestMetricsHandler(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, statusDocument)
+	}))
+	defer srv.Close()
+
+	alloyCfg := `
+		targets = [{"name" = "svc", "address" = "` + srv.URL + `"}]
+
+		metric {
+			name   = "queue_depth"
+			path   = "$.queues[*]"
+			value  = "@.depth"
+			labels = { "queue" = "@.name", "version" = "$.version" }
+		}
+
+		metric {
+			name   = "queue_processed_total"
+			type   = "counter"
+			path   = "$.queues[*]"
+			value  = "@.processed"
+			labels = { "queue" = "@.name" }
+		}
+
+		metric {
+			name = "service_healthy"
+			path = "$.healthy"
+		}
+`
+	var args Arguments
+	require.NoError(t, syntax.Unmarshal([]byte(alloyCfg), &args))
+
+	opts := component.Options{ID: "prometheus.exporter.json.test", Logger: log.NewNopLogger()}
+	integration, _, err := createExporter(opts, args)
+	require.NoError(t, err)
+	handler, err := integration.MetricsHandler()
+	require.NoError(t, err)
+
+	t.Run("known target", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics?target="+url.QueryEscape(srv.URL), nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		body := rec.Body.String()
+		require.Contains(t, body, "# TYPE queue_depth gauge")
+		require.Contains(t, body, `queue_depth{queue="emails",version="1.2.3"} 12`)
+		require.Contains(t, body, `queue_depth{queue="reports",version="1.2.3"} 3`)
+		require.Contains(t, body, "# TYPE queue_processed_total counter")
+		require.Contains(t, body, `queue_processed_total{queue="emails"} 1500`)
+		require.Contains(t, body, `service_healthy 1`)
+	})
+
+	t.Run("unknown target", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics?target=http://example.com", nil))
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
@@ -0,0 +1,295 @@
+package receive_pushgateway
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/natefinch/atomic"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/labels"
+)
+
+// pushTimeMetricName is the name of the metric which records the time of the
+// last successful push for each group, like the Pushgateway does.
+const pushTimeMetricName = "push_time_seconds"
+
+// group is the set of metrics pushed for a single grouping key.
+type group struct {
+	labels   map[string]string
+	families map[string]*dto.MetricFamily
+	pushTime time.Time
+}
+
+// sample is a single float sample materialized from a group.
+type sample struct {
+	labels labels.Labels
+	value  float64
+}
+
+// groupStore holds the last pushed metrics for each grouping key.
+type groupStore struct {
+	mut    sync.Mutex
+	groups map[string]*group
+	dirty  bool
+}
+
+func newGroupStore() *groupStore {
+	return &groupStore{groups: make(map[string]*group)}
+}
+
+// groupKey returns a stable identifier for a set of grouping labels.
+func groupKey(groupingLabels map[string]string) string {
+	return labels.FromMap(groupingLabels).String()
+}
+
+// Replace stores families for the group identified by groupingLabels. If
+// replaceAll is true, all previously pushed metrics of the group are dropped,
+// otherwise only metrics with the same name are replaced. Replace returns
+// the series that no longer exist after the push.
+func (s *groupStore) Replace(groupingLabels map[string]string, families map[string]*dto.MetricFamily, replaceAll bool, now time.Time) []labels.Labels {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	key := groupKey(groupingLabels)
+	old, ok := s.groups[key]
+
+	g := &group{
+		labels:   groupingLabels,
+		families: make(map[string]*dto.MetricFamily, len(families)),
+		pushTime: now,
+	}
+	if ok && !replaceAll {
+		maps.Copy(g.families, old.families)
+	}
+	maps.Copy(g.families, families)
+	s.groups[key] = g
+	s.dirty = true
+
+	if !ok {
+		return nil
+	}
+	return removedSeries(old.samples(), g.samples())
+}
+
+// Delete removes the group identified by groupingLabels and returns the
+// series which belonged to it.
+func (s *groupStore) Delete(groupingLabels map[string]string) []labels.Labels {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	key := groupKey(groupingLabels)
+	old, ok := s.groups[key]
+	if !ok {
+		return nil
+	}
+	delete(s.groups, key)
+	s.dirty = true
+	return removedSeries(old.samples(), nil)
+}
+
+// Samples returns the samples of every stored group.
+func (s *groupStore) Samples() []sample {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	var res []sample
+	for _, key := range slices.Sorted(maps.Keys(s.groups)) {
+		res = append(res, s.groups[key].samples()...)
+	}
+	return res
+}
+
+// Len returns the number of stored groups.
+func (s *groupStore) Len() int {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	return len(s.groups)
+}
+
+// persistedGroup is the on-disk representation of a group. Metrics are
+// stored in the Prometheus text exposition format.
+type persistedGroup struct {
+	Labels   map[string]string `json:"labels"`
+	PushTime time.Time         `json:"push_time"`
+	Metrics  string            `json:"metrics"`
+}
+
+// Save writes all groups to path if they changed since the last call to Save
+// or Load.
+func (s *groupStore) Save(path string) error {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	if !s.dirty {
+		return nil
+	}
+
+	persisted := make([]persistedGroup, 0, len(s.groups))
+	for _, key := range slices.Sorted(maps.Keys(s.groups)) {
+		g := s.groups[key]
+
+		var buf bytes.Buffer
+		for _, name := range slices.Sorted(maps.Keys(g.families)) {
+			if _, err := expfmt.MetricFamilyToText(&buf, g.families[name]); err != nil {
+				return fmt.Errorf("encoding metrics of group %s: %w", key, err)
+			}
+		}
+		persisted = append(persisted, persistedGroup{
+			Labels:   g.labels,
+			PushTime: g.pushTime,
+			Metrics:  buf.String(),
+		})
+	}
+
+	bb, err := json.Marshal(persisted)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+	if err := atomic.WriteFile(path, bytes.NewReader(bb)); err != nil {
+		return err
+	}
+	s.dirty = false
+	return nil
+}
+
+// Load replaces the stored groups with the ones persisted at path. A missing
+// file isn't an error.
+func (s *groupStore) Load(path string) error {
+	bb, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	var persisted []persistedGroup
+	if err := json.Unmarshal(bb, &persisted); err != nil {
+		return fmt.Errorf("decoding %s: %w", path, err)
+	}
+
+	groups := make(map[string]*group, len(persisted))
+	for _, pg := range persisted {
+		parser := expfmt.NewTextParser(model.LegacyValidation)
+		families, err := parser.TextToMetricFamilies(strings.NewReader(pg.Metrics))
+		if err != nil {
+			return fmt.Errorf("decoding metrics of group %v: %w", pg.Labels, err)
+		}
+		groups[groupKey(pg.Labels)] = &group{
+			labels:   pg.Labels,
+			families: families,
+			pushTime: pg.PushTime,
+		}
+	}
+
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	s.groups = groups
+	s.dirty = false
+	return nil
+}
+
+// samples converts the metric families of g into float samples. Grouping
+// labels override labels of the same name set on the pushed metrics.
+func (g *group) samples() []sample {
+	var res []sample
+
+	add := func(name string, metricLabels []*dto.LabelPair, value float64, extra ...string) {
+		b := labels.NewScratchBuilder(len(metricLabels) + len(g.labels) + len(extra)/2 + 1)
+		for _, lp := range metricLabels {
+			if _, ok := g.labels[lp.GetName()]; ok {
+				continue
+			}
+			b.Add(lp.GetName(), lp.GetValue())
+		}
+		for k, v := range g.labels {
+			b.Add(k, v)
+		}
+		for i := 0; i+1 < len(extra); i += 2 {
+			b.Add(extra[i], extra[i+1])
+		}
+		b.Add(model.MetricNameLabel, name)
+		b.Sort()
+		res = append(res, sample{labels: b.Labels(), value: value})
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(g.families)) {
+		mf := g.families[name]
+		for _, m := range mf.GetMetric() {
+			switch mf.GetType() {
+			case dto.MetricType_COUNTER:
+				add(name, m.GetLabel(), m.GetCounter().GetValue())
+			case dto.MetricType_GAUGE:
+				add(name, m.GetLabel(), m.GetGauge().GetValue())
+			case dto.MetricType_UNTYPED:
+				add(name, m.GetLabel(), m.GetUntyped().GetValue())
+			case dto.MetricType_SUMMARY:
+				s := m.GetSummary()
+				for _, q := range s.GetQuantile() {
+					add(name, m.GetLabel(), q.GetValue(), model.QuantileLabel, formatFloat(q.GetQuantile()))
+				}
+				add(name+"_sum", m.GetLabel(), s.GetSampleSum())
+				add(name+"_count", m.GetLabel(), float64(s.GetSampleCount()))
+			case dto.MetricType_HISTOGRAM, dto.MetricType_GAUGE_HISTOGRAM:
+				h := m.GetHistogram()
+				hasInf := false
+				for _, b := range h.GetBucket() {
+					if math.IsInf(b.GetUpperBound(), +1) {
+						hasInf = true
+					}
+					add(name+"_bucket", m.GetLabel(), float64(b.GetCumulativeCount()), model.BucketLabel, formatFloat(b.GetUpperBound()))
+				}
+				if !hasInf {
+					add(name+"_bucket", m.GetLabel(), float64(h.GetSampleCount()), model.BucketLabel, "+Inf")
+				}
+				add(name+"_sum", m.GetLabel(), h.GetSampleSum())
+				add(name+"_count", m.GetLabel(), float64(h.GetSampleCount()))
+			}
+		}
+	}
+
+	add(pushTimeMetricName, nil, float64(g.pushTime.UnixNano())/1e9)
+	return res
+}
+
+// removedSeries returns the labels of samples in before which aren't present
+// in after.
+func removedSeries(before, after []sample) []labels.Labels {
+	current := make(map[uint64]struct{}, len(after))
+	for _, s := range after {
+		current[s.labels.Hash()] = struct{}{}
+	}
+
+	var res []labels.Labels
+	for _, s := range before {
+		if _, ok := current[s.labels.Hash()]; !ok {
+			res = append(res, s.labels)
+		}
+	}
+	return res
+}
+
+func formatFloat(f float64) string {
+	switch {
+	case math.IsInf(f, +1):
+		return "+Inf"
+	case math.IsInf(f, -1):
+		return "-Inf"
+	default:
+		return strconv.FormatFloat(f, 'g', -1, 64)
+	}
+}
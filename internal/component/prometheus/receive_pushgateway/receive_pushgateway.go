@@ -0,0 +1,425 @@
+// Package receive_pushgateway implements the prometheus.receive_pushgateway
+// component.
+package receive_pushgateway
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/model/value"
+	"github.com/prometheus/prometheus/storage"
+
+	"github.com/grafana/alloy/internal/component"
+	fnet "github.com/grafana/alloy/internal/component/common/net"
+	alloyprom "github.com/grafana/alloy/internal/component/prometheus"
+	"github.com/grafana/alloy/internal/featuregate"
+	"github.com/grafana/alloy/internal/runtime/logging/level"
+	"github.com/grafana/alloy/internal/service/labelstore"
+	"github.com/grafana/alloy/internal/util"
+)
+
+func init() {
+	component.Register(component.Registration{
+		Name:      "prometheus.receive_pushgateway",
+		Stability: featuregate.StabilityExperimental,
+		Args:      Arguments{},
+
+		Build: func(opts component.Options, args component.Arguments) (component.Component, error) {
+			return New(opts, args.(Arguments))
+		},
+	})
+}
+
+// groupsFile is the name of the file used to persist groups in the
+// component's data directory.
+const groupsFile = "groups.json"
+
+// Arguments holds values which are used to configure the
+// prometheus.receive_pushgateway component.
+type Arguments struct {
+	Server          *fnet.ServerConfig   `alloy:",squash"`
+	ForwardTo       []storage.Appendable `alloy:"forward_to,attr"`
+	ForwardInterval time.Duration        `alloy:"forward_interval,attr,optional"`
+	Persist         bool                 `alloy:"persist,attr,optional"`
+}
+
+// SetToDefault implements syntax.Defaulter.
+func (args *Arguments) SetToDefault() {
+	*args = Arguments{
+		Server:          fnet.DefaultServerConfig(),
+		ForwardInterval: 15 * time.Second,
+	}
+}
+
+// Validate implements syntax.Validator.
+func (args *Arguments) Validate() error {
+	if args.ForwardInterval <= 0 {
+		return errors.New("forward_interval must be greater than 0")
+	}
+	return nil
+}
+
+// Component implements the prometheus.receive_pushgateway component.
+type Component struct {
+	opts               component.Options
+	fanout             *alloyprom.Fanout
+	groups             *groupStore
+	uncheckedCollector *util.UncheckedCollector
+
+	pushesTotal *prometheus.CounterVec
+	groupsGauge prometheus.GaugeFunc
+
+	updateMut sync.RWMutex
+	args      Arguments
+	server    *fnet.TargetServer
+
+	// updated is written to whenever args updates.
+	updated chan struct{}
+}
+
+var _ component.Component = (*Component)(nil)
+
+// New creates a new prometheus.receive_pushgateway component.
+func New(opts component.Options, args Arguments) (*Component, error) {
+	service, err := opts.GetServiceData(labelstore.ServiceName)
+	if err != nil {
+		return nil, err
+	}
+	ls := service.(labelstore.LabelStore)
+
+	uncheckedCollector := util.NewUncheckedCollector(nil)
+	opts.Registerer.MustRegister(uncheckedCollector)
+
+	c := &Component{
+		opts:               opts,
+		fanout:             alloyprom.NewFanout(args.ForwardTo, opts.ID, opts.Registerer, ls),
+		groups:             newGroupStore(),
+		uncheckedCollector: uncheckedCollector,
+		updated:            make(chan struct{}, 1),
+
+		pushesTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "prometheus_receive_pushgateway_pushes_total",
+			Help: "Total number of push requests received, by HTTP method and outcome.",
+		}, []string{"method", "outcome"}),
+	}
+	c.groupsGauge = prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "prometheus_receive_pushgateway_groups",
+		Help: "Number of metric groups currently held in memory.",
+	}, func() float64 { return float64(c.groups.Len()) })
+
+	if err := opts.Registerer.Register(c.pushesTotal); err != nil {
+		return nil, err
+	}
+	if err := opts.Registerer.Register(c.groupsGauge); err != nil {
+		return nil, err
+	}
+
+	if args.Persist {
+		if err := c.groups.Load(c.groupsPath()); err != nil {
+			level.Warn(opts.Logger).Log("msg", "failed to load persisted groups", "err", err)
+		}
+	}
+
+	if err := c.Update(args); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Run satisfies the Component interface.
+func (c *Component) Run(ctx context.Context) error {
+	defer func() {
+		c.updateMut.Lock()
+		defer c.updateMut.Unlock()
+		c.shutdownServer()
+		c.persist()
+	}()
+
+	c.updateMut.RLock()
+	ticker := time.NewTicker(c.args.ForwardInterval)
+	c.updateMut.RUnlock()
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			level.Info(c.opts.Logger).Log("msg", "terminating due to context done")
+			return nil
+		case <-c.updated:
+			c.updateMut.RLock()
+			ticker.Reset(c.args.ForwardInterval)
+			c.updateMut.RUnlock()
+		case <-ticker.C:
+			c.forward(ctx)
+
+			c.updateMut.RLock()
+			c.persist()
+			c.updateMut.RUnlock()
+		}
+	}
+}
+
+// forward appends the current value of every series held by the component.
+func (c *Component) forward(ctx context.Context) {
+	samples := c.groups.Samples()
+	if len(samples) == 0 {
+		return
+	}
+
+	ts := time.Now().UnixMilli()
+	app := c.fanout.Appender(ctx)
+	for _, s := range samples {
+		if _, err := app.Append(0, s.labels, ts, s.value); err != nil {
+			level.Debug(c.opts.Logger).Log("msg", "failed to append sample", "series", s.labels, "err", err)
+		}
+	}
+	if err := app.Commit(); err != nil {
+		level.Error(c.opts.Logger).Log("msg", "failed to forward samples", "err", err)
+	}
+}
+
+// markStale appends staleness markers for series which were deleted or
+// replaced.
+func (c *Component) markStale(ctx context.Context, series []labels.Labels) {
+	if len(series) == 0 {
+		return
+	}
+
+	ts := time.Now().UnixMilli()
+	app := c.fanout.Appender(ctx)
+	for _, l := range series {
+		if _, err := app.Append(0, l, ts, math.Float64frombits(value.StaleNaN)); err != nil {
+			level.Debug(c.opts.Logger).Log("msg", "failed to append staleness marker", "series", l, "err", err)
+		}
+	}
+	if err := app.Commit(); err != nil {
+		level.Error(c.opts.Logger).Log("msg", "failed to forward staleness markers", "err", err)
+	}
+}
+
+// persist saves groups to disk if persistence is enabled. updateMut must be
+// held when calling.
+func (c *Component) persist() {
+	if !c.args.Persist {
+		return
+	}
+	if err := c.groups.Save(c.groupsPath()); err != nil {
+		level.Error(c.opts.Logger).Log("msg", "failed to persist groups", "err", err)
+	}
+}
+
+func (c *Component) groupsPath() string {
+	return filepath.Join(c.opts.DataPath, groupsFile)
+}
+
+// Update satisfies the Component interface.
+func (c *Component) Update(args component.Arguments) error {
+	newArgs := args.(Arguments)
+	c.fanout.UpdateChildren(newArgs.ForwardTo)
+
+	c.updateMut.Lock()
+	defer c.updateMut.Unlock()
+
+	select {
+	case c.updated <- struct{}{}:
+	default:
+	}
+
+	serverNeedsUpdate := !reflect.DeepEqual(c.args.Server, newArgs.Server)
+	if !serverNeedsUpdate {
+		c.args = newArgs
+		return nil
+	}
+	c.shutdownServer()
+
+	s, err := c.createNewServer(newArgs)
+	if err != nil {
+		return err
+	}
+	c.server = s
+
+	err = c.server.MountAndRun(func(router *mux.Router) {
+		router.PathPrefix("/metrics/").Methods(http.MethodPut).HandlerFunc(c.handlePush(true))
+		router.PathPrefix("/metrics/").Methods(http.MethodPost).HandlerFunc(c.handlePush(false))
+		router.PathPrefix("/metrics/").Methods(http.MethodDelete).HandlerFunc(c.handleDelete)
+	})
+	if err != nil {
+		return err
+	}
+
+	c.args = newArgs
+	return nil
+}
+
+// handlePush returns a handler which stores pushed metrics. If replaceAll is
+// true, all metrics of the group are replaced, otherwise only metrics with
+// the same name are.
+func (c *Component) handlePush(replaceAll bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		groupingLabels, err := parseGroupingKey(r.URL.Path)
+		if err != nil {
+			c.pushesTotal.WithLabelValues(r.Method, "invalid").Inc()
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		families, err := decodeFamilies(r)
+		if err != nil {
+			c.pushesTotal.WithLabelValues(r.Method, "invalid").Inc()
+			level.Debug(c.opts.Logger).Log("msg", "failed to decode pushed metrics", "group", groupKey(groupingLabels), "err", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		removed := c.groups.Replace(groupingLabels, families, replaceAll, time.Now())
+		c.markStale(r.Context(), removed)
+
+		c.pushesTotal.WithLabelValues(r.Method, "success").Inc()
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// handleDelete removes a group and marks all of its series stale.
+func (c *Component) handleDelete(w http.ResponseWriter, r *http.Request) {
+	groupingLabels, err := parseGroupingKey(r.URL.Path)
+	if err != nil {
+		c.pushesTotal.WithLabelValues(r.Method, "invalid").Inc()
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	removed := c.groups.Delete(groupingLabels)
+	c.markStale(r.Context(), removed)
+
+	c.pushesTotal.WithLabelValues(r.Method, "success").Inc()
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// parseGroupingKey parses the grouping labels of a request path of the form
+// /metrics/job/<JOB>{/<LABEL_NAME>/<LABEL_VALUE>}. Label names with the
+// @base64 suffix have their values decoded from URL-safe base64.
+func parseGroupingKey(path string) (map[string]string, error) {
+	rest, ok := strings.CutPrefix(path, "/metrics/")
+	if !ok {
+		return nil, fmt.Errorf("invalid path %q", path)
+	}
+
+	parts := strings.Split(strings.TrimSuffix(rest, "/"), "/")
+	if len(parts)%2 != 0 {
+		return nil, fmt.Errorf("invalid grouping key in path %q: odd number of segments", path)
+	}
+
+	groupingLabels := make(map[string]string, len(parts)/2)
+	for i := 0; i < len(parts); i += 2 {
+		name, labelValue := parts[i], parts[i+1]
+		if n, ok := strings.CutSuffix(name, "@base64"); ok {
+			decoded, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(labelValue, "="))
+			if err != nil {
+				return nil, fmt.Errorf("invalid base64 value for label %q: %w", n, err)
+			}
+			name, labelValue = n, string(decoded)
+		}
+
+		if i == 0 && name != "job" {
+			return nil, fmt.Errorf("grouping key must start with job, got %q", name)
+		}
+		if !model.LegacyValidation.IsValidLabelName(name) || strings.HasPrefix(name, model.ReservedLabelPrefix) {
+			return nil, fmt.Errorf("invalid label name %q", name)
+		}
+		if _, ok := groupingLabels[name]; ok {
+			return nil, fmt.Errorf("duplicate label name %q", name)
+		}
+		groupingLabels[name] = labelValue
+	}
+
+	if groupingLabels["job"] == "" {
+		return nil, errors.New("job name is required")
+	}
+	return groupingLabels, nil
+}
+
+// decodeFamilies decodes the metric families of a push request body in
+// either the text or the delimited protobuf format.
+func decodeFamilies(r *http.Request) (map[string]*dto.MetricFamily, error) {
+	var families map[string]*dto.MetricFamily
+
+	if expfmt.ResponseFormat(r.Header) == expfmt.NewFormat(expfmt.TypeProtoDelim) {
+		families = make(map[string]*dto.MetricFamily)
+		dec := expfmt.NewDecoder(r.Body, expfmt.NewFormat(expfmt.TypeProtoDelim))
+		for {
+			mf := &dto.MetricFamily{}
+			if err := dec.Decode(mf); err != nil {
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				return nil, err
+			}
+			families[mf.GetName()] = mf
+		}
+	} else {
+		var err error
+		parser := expfmt.NewTextParser(model.LegacyValidation)
+		families, err = parser.TextToMetricFamilies(r.Body)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for name, mf := range families {
+		if name == pushTimeMetricName {
+			return nil, fmt.Errorf("pushed metrics must not contain %s", pushTimeMetricName)
+		}
+		for _, m := range mf.GetMetric() {
+			if m.TimestampMs != nil {
+				return nil, fmt.Errorf("pushed metric %s must not have a timestamp", name)
+			}
+		}
+	}
+	return families, nil
+}
+
+func (c *Component) createNewServer(args Arguments) (*fnet.TargetServer, error) {
+	// [server.Server] registers new metrics every time it is created. To
+	// avoid issues with re-registering metrics with the same name, we create a
+	// new registry for the server every time we create one, and pass it to an
+	// unchecked collector to bypass uniqueness checking.
+	serverRegistry := prometheus.NewRegistry()
+	c.uncheckedCollector.SetCollector(serverRegistry)
+
+	s, err := fnet.NewTargetServer(
+		c.opts.Logger,
+		"prometheus_receive_pushgateway",
+		serverRegistry,
+		args.Server,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create server: %v", err)
+	}
+
+	return s, nil
+}
+
+// shutdownServer will shut down the currently used server.
+// It is not goroutine-safe and an updateMut write lock must be held when it's called.
+func (c *Component) shutdownServer() {
+	if c.server != nil {
+		c.server.StopAndShutdown()
+		c.server = nil
+	}
+}
@@ -0,0 +1,260 @@
+package receive_pushgateway
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/phayes/freeport"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/model/value"
+	"github.com/prometheus/prometheus/storage"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/alloy/internal/component"
+	fnet "github.com/grafana/alloy/internal/component/common/net"
+	alloyprom "github.com/grafana/alloy/internal/component/prometheus"
+	"github.com/grafana/alloy/internal/service/labelstore"
+	"github.com/grafana/alloy/internal/util"
+	"github.com/grafana/alloy/syntax"
+)
+
+func TestUnmarshalAlloy(t *testing.T) {
+	alloyCfg := `
+		http {
+			listen_address = "localhost"
+			listen_port    = 9091
+		}
+		forward_to       = []
+		forward_interval = "30s"
+		persist          = true
+`
+	var args Arguments
+	require.NoError(t, syntax.Unmarshal([]byte(alloyCfg), &args))
+	require.Equal(t, 9091, args.Server.HTTP.ListenPort)
+	require.Equal(t, 30*time.Second, args.ForwardInterval)
+	require.True(t, args.Persist)
+}
+
+func TestParseGroupingKey(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected map[string]string
+		errMsg   string
+	}{
+		{
+			path:     "/metrics/job/backup",
+			expected: map[string]string{"job": "backup"},
+		},
+		{
+			path:     "/metrics/job/backup/instance/db-1/",
+			expected: map[string]string{"job": "backup", "instance": "db-1"},
+		},
+		{
+			// "/var/tmp" encoded as URL-safe base64.
+			path:     "/metrics/job/backup/path@base64/L3Zhci90bXA",
+			expected: map[string]string{"job": "backup", "path": "/var/tmp"},
+		},
+		{
+			path:   "/metrics/instance/db-1",
+			errMsg: "grouping key must start with job",
+		},
+		{
+			path:   "/metrics/job/backup/instance",
+			errMsg: "odd number of segments",
+		},
+		{
+			path:   "/metrics/job/backup/__name__/foo",
+			errMsg: `invalid label name "__name__"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			actual, err := parseGroupingKey(tt.path)
+			if tt.errMsg != "" {
+				require.ErrorContains(t, err, tt.errMsg)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestPushAndDelete(t *testing.T) {
+	appendable := newCollectingAppendable()
+	c, baseURL := startComponent(t, Arguments{
+		ForwardTo:       []storage.Appendable{appendable},
+		ForwardInterval: 50 * time.Millisecond,
+	})
+
+	push(t, http.MethodPut, baseURL+"/metrics/job/backup/instance/db-1", `
+# TYPE backup_last_success_timestamp_seconds gauge
+backup_last_success_timestamp_seconds 1700000000
+# TYPE backup_files_total counter
+backup_files_total{kind="full"} 42
+`)
+
+	series := labels.FromStrings("__name__", "backup_files_total", "instance", "db-1", "job", "backup", "kind", "full")
+	require.Eventually(t, func() bool {
+		v, ok := appendable.Latest(series)
+		return ok && v == 42
+	}, 5*time.Second, 10*time.Millisecond)
+
+	pushTime := labels.FromStrings("__name__", "push_time_seconds", "instance", "db-1", "job", "backup")
+	_, ok := appendable.Latest(pushTime)
+	require.True(t, ok)
+
+	// A POST only replaces metrics with the same name.
+	push(t, http.MethodPost, baseURL+"/metrics/job/backup/instance/db-1", `
+# TYPE backup_last_success_timestamp_seconds gauge
+backup_last_success_timestamp_seconds 1700000100
+`)
+	require.Eventually(t, func() bool {
+		v, ok := appendable.Latest(labels.FromStrings("__name__", "backup_last_success_timestamp_seconds", "instance", "db-1", "job", "backup"))
+		return ok && v == 1700000100
+	}, 5*time.Second, 10*time.Millisecond)
+	v, ok := appendable.Latest(series)
+	require.True(t, ok)
+	require.Equal(t, 42.0, v)
+
+	// A PUT replaces the whole group and marks removed series stale.
+	push(t, http.MethodPut, baseURL+"/metrics/job/backup/instance/db-1", `
+# TYPE backup_last_success_timestamp_seconds gauge
+backup_last_success_timestamp_seconds 1700000200
+`)
+	require.True(t, appendable.IsStale(series))
+
+	// Deleting the group marks all of its series stale.
+	push(t, http.MethodDelete, baseURL+"/metrics/job/backup/instance/db-1", "")
+	require.True(t, appendable.IsStale(pushTime))
+	require.Equal(t, 0, c.groups.Len())
+}
+
+func TestPushRejectsTimestamps(t *testing.T) {
+	_, baseURL := startComponent(t, Arguments{
+		ForwardTo:       []storage.Appendable{},
+		ForwardInterval: time.Minute,
+	})
+
+	req, err := http.NewRequest(http.MethodPut, baseURL+"/metrics/job/backup", strings.NewReader("backup_files_total 42 1700000000000\n"))
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestGroupStorePersistence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), groupsFile)
+
+	s := newGroupStore()
+	families, err := decodeFamilies(newTextRequest(t, `
+# TYPE job_duration_seconds summary
+job_duration_seconds{quantile="0.5"} 1.5
+job_duration_seconds_sum 10
+job_duration_seconds_count 4
+`))
+	require.NoError(t, err)
+	s.Replace(map[string]string{"job": "batch"}, families, true, time.Unix(1700000000, 0))
+	require.NoError(t, s.Save(path))
+
+	loaded := newGroupStore()
+	require.NoError(t, loaded.Load(path))
+	require.Equal(t, s.Samples(), loaded.Samples())
+}
+
+func startComponent(t *testing.T, args Arguments) (*Component, string) {
+	port, err := freeport.GetFreePort()
+	require.NoError(t, err)
+	grpcPort, err := freeport.GetFreePort()
+	require.NoError(t, err)
+
+	args.Server = &fnet.ServerConfig{
+		HTTP: &fnet.HTTPConfig{ListenAddress: "127.0.0.1", ListenPort: port},
+		GRPC: &fnet.GRPCConfig{ListenAddress: "127.0.0.1", ListenPort: grpcPort},
+	}
+
+	c, err := New(component.Options{
+		ID:         "prometheus.receive_pushgateway.test",
+		Logger:     util.TestAlloyLogger(t),
+		Registerer: prometheus.NewRegistry(),
+		DataPath:   t.TempDir(),
+		GetServiceData: func(name string) (interface{}, error) {
+			return labelstore.New(nil, prometheus.DefaultRegisterer), nil
+		},
+	}, args)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	t.Cleanup(cancel)
+	go func() {
+		require.NoError(t, c.Run(ctx))
+	}()
+
+	baseURL := fmt.Sprintf("http://127.0.0.1:%d", port)
+	require.Eventually(t, func() bool {
+		resp, err := http.Get(baseURL + "/metrics/job/x")
+		if err != nil {
+			return false
+		}
+		resp.Body.Close()
+		return true
+	}, 5*time.Second, 10*time.Millisecond)
+	return c, baseURL
+}
+
+func push(t *testing.T, method, url, body string) {
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Less(t, resp.StatusCode, 300)
+}
+
+func newTextRequest(t *testing.T, body string) *http.Request {
+	req, err := http.NewRequest(http.MethodPut, "/metrics/job/batch", strings.NewReader(body))
+	require.NoError(t, err)
+	return req
+}
+
+// collectingAppendable records the most recent value appended for each
+// series.
+type collectingAppendable struct {
+	mut    sync.Mutex
+	latest map[uint64]float64
+}
+
+func newCollectingAppendable() *collectingAppendable {
+	return &collectingAppendable{latest: make(map[uint64]float64)}
+}
+
+func (a *collectingAppendable) Appender(context.Context) storage.Appender {
+	return alloyprom.NewInterceptor(nil, alloyprom.WithAppendHook(func(ref storage.SeriesRef, l labels.Labels, _ int64, v float64, _ storage.Appender) (storage.SeriesRef, error) {
+		a.mut.Lock()
+		defer a.mut.Unlock()
+		a.latest[l.Hash()] = v
+		return ref, nil
+	})).Appender(context.Background())
+}
+
+func (a *collectingAppendable) Latest(l labels.Labels) (float64, bool) {
+	a.mut.Lock()
+	defer a.mut.Unlock()
+	v, ok := a.latest[l.Hash()]
+	return v, ok
+}
+
+func (a *collectingAppendable) IsStale(l labels.Labels) bool {
+	v, ok := a.Latest(l)
+	return ok && math.IsNaN(v) && value.IsStaleNaN(v)
+}
@@ -0,0 +1,77 @@
+package remoteread
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/natefinch/atomic"
+)
+
+// progressFile is the name of the file used to track progress in the
+// component's data directory.
+const progressFile = "progress.json"
+
+// progress records how far the component has read. It's persisted after every
+// query window so reads resume where they stopped after a restart.
+type progress struct {
+	// Hash identifies the query that the progress belongs to. Progress for a
+	// different query is discarded.
+	Hash string `json:"hash"`
+	// Last is the timestamp in milliseconds up to which (inclusive) samples
+	// have been forwarded.
+	Last int64 `json:"last"`
+	// End is the fixed end timestamp in milliseconds of a one-off read.
+	End int64 `json:"end,omitempty"`
+	// Completed is set once a one-off read has reached End.
+	Completed bool `json:"completed,omitempty"`
+}
+
+// queryHash returns an identifier for the parts of args which determine
+// which samples are read.
+func queryHash(args Arguments) string {
+	bb, _ := json.Marshal(struct {
+		URL       string
+		Selectors []string
+		Start     string
+		End       string
+		Once      bool
+	}{args.URL, args.Selectors, args.Start, args.End, args.Interval == 0})
+
+	sum := sha256.Sum256(bb)
+	return hex.EncodeToString(sum[:])
+}
+
+// loadProgress reads progress from path. An empty progress is returned if
+// the file doesn't exist.
+func loadProgress(path string) (progress, error) {
+	var p progress
+
+	bb, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return p, nil
+	} else if err != nil {
+		return p, err
+	}
+
+	if err := json.Unmarshal(bb, &p); err != nil {
+		return progress{}, fmt.Errorf("decoding %s: %w", path, err)
+	}
+	return p, nil
+}
+
+// saveProgress atomically writes p to path.
+func saveProgress(path string, p progress) error {
+	bb, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+	return atomic.WriteFile(path, bytes.NewReader(bb))
+}
@@ -0,0 +1,347 @@
+// Package remoteread implements the prometheus.remote_read component.
+package remoteread
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/prometheus/client_golang/prometheus"
+	common "github.com/prometheus/common/config"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/storage"
+	"github.com/prometheus/prometheus/storage/remote"
+	"github.com/prometheus/prometheus/tsdb/chunkenc"
+
+	"github.com/grafana/alloy/internal/component"
+	alloyprom "github.com/grafana/alloy/internal/component/prometheus"
+	"github.com/grafana/alloy/internal/featuregate"
+	"github.com/grafana/alloy/internal/runtime/logging/level"
+	"github.com/grafana/alloy/internal/service/labelstore"
+	"github.com/grafana/alloy/internal/useragent"
+)
+
+func init() {
+	component.Register(component.Registration{
+		Name:      "prometheus.remote_read",
+		Stability: featuregate.StabilityExperimental,
+		Args:      Arguments{},
+
+		Build: func(opts component.Options, args component.Arguments) (component.Component, error) {
+			return New(opts, args.(Arguments))
+		},
+	})
+}
+
+// maxSamplesPerCommit bounds the number of samples buffered by downstream
+// appenders before they're committed.
+const maxSamplesPerCommit = 5000
+
+// retryInterval is how long to wait before retrying a failed read when no
+// shorter interval is configured.
+const retryInterval = 30 * time.Second
+
+// Component implements the prometheus.remote_read component.
+type Component struct {
+	log    log.Logger
+	opts   component.Options
+	fanout *alloyprom.Fanout
+
+	samplesTotal  prometheus.Counter
+	lastTimestamp prometheus.Gauge
+
+	mut    sync.Mutex
+	args   Arguments
+	client remote.ReadClient
+
+	// updated is written to whenever args updates.
+	updated chan struct{}
+
+	healthMut sync.RWMutex
+	health    component.Health
+}
+
+var (
+	_ component.Component       = (*Component)(nil)
+	_ component.HealthComponent = (*Component)(nil)
+)
+
+// New creates a new prometheus.remote_read component.
+func New(opts component.Options, args Arguments) (*Component, error) {
+	service, err := opts.GetServiceData(labelstore.ServiceName)
+	if err != nil {
+		return nil, err
+	}
+	ls := service.(labelstore.LabelStore)
+
+	c := &Component{
+		log:     opts.Logger,
+		opts:    opts,
+		fanout:  alloyprom.NewFanout(args.ForwardTo, opts.ID, opts.Registerer, ls),
+		updated: make(chan struct{}, 1),
+
+		samplesTotal: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "prometheus_remote_read_samples_total",
+			Help: "Total number of samples read from the remote endpoint.",
+		}),
+		lastTimestamp: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "prometheus_remote_read_last_timestamp_seconds",
+			Help: "Timestamp up to which samples have been read from the remote endpoint.",
+		}),
+
+		health: component.Health{
+			Health:     component.HealthTypeUnknown,
+			Message:    "component started",
+			UpdateTime: time.Now(),
+		},
+	}
+
+	for _, m := range []prometheus.Collector{c.samplesTotal, c.lastTimestamp} {
+		if err := opts.Registerer.Register(m); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := c.Update(args); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Run starts the prometheus.remote_read component.
+func (c *Component) Run(ctx context.Context) error {
+	for {
+		done, err := c.read(ctx)
+		if ctx.Err() != nil {
+			return nil
+		}
+		c.updateHealth(done, err)
+
+		c.mut.Lock()
+		wait := c.args.Interval
+		c.mut.Unlock()
+
+		var waitCh <-chan time.Time
+		switch {
+		case err != nil:
+			if wait == 0 || wait > retryInterval {
+				wait = retryInterval
+			}
+			waitCh = time.After(wait)
+		case wait > 0:
+			waitCh = time.After(wait)
+		}
+		// A completed one-off read waits for a configuration update.
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-waitCh:
+		case <-c.updated:
+		}
+	}
+}
+
+// read queries the remote endpoint until the configured range has been
+// forwarded. It returns true if a one-off read has completed.
+func (c *Component) read(ctx context.Context) (bool, error) {
+	c.mut.Lock()
+	args, client := c.args, c.client
+	c.mut.Unlock()
+
+	path := filepath.Join(c.opts.DataPath, progressFile)
+	p, err := loadProgress(path)
+	if err != nil {
+		level.Warn(c.log).Log("msg", "failed to load progress, starting from the beginning", "err", err)
+	}
+	if hash := queryHash(args); p.Hash != hash {
+		p = progress{Hash: hash}
+	}
+	if p.Completed {
+		return true, nil
+	}
+
+	start, end, err := args.timeRange()
+	if err != nil {
+		return false, err
+	}
+	now := time.Now()
+	switch {
+	case !end.IsZero():
+		p.End = end.UnixMilli()
+	case args.Interval == 0 && p.End == 0:
+		// Fix the end of a one-off read when it first starts so that it stays
+		// the same across restarts.
+		p.End = now.UnixMilli()
+	case args.Interval > 0:
+		p.End = now.UnixMilli()
+	}
+	if start.IsZero() {
+		start = time.UnixMilli(p.End).Add(-args.Lookback)
+	}
+
+	matchers, err := args.matchers()
+	if err != nil {
+		return false, err
+	}
+
+	from := max(start.UnixMilli(), p.Last+1)
+	for from <= p.End {
+		to := min(from+args.MaxQueryRange.Milliseconds()-1, p.End)
+
+		if err := c.readWindow(ctx, client, matchers, from, to); err != nil {
+			return false, fmt.Errorf("reading range %s to %s: %w", time.UnixMilli(from).UTC().Format(time.RFC3339), time.UnixMilli(to).UTC().Format(time.RFC3339), err)
+		}
+
+		p.Last = to
+		c.lastTimestamp.Set(float64(to) / 1000)
+		if err := saveProgress(path, p); err != nil {
+			level.Warn(c.log).Log("msg", "failed to save progress", "err", err)
+		}
+		from = to + 1
+	}
+
+	if args.Interval == 0 {
+		p.Completed = true
+		if err := saveProgress(path, p); err != nil {
+			level.Warn(c.log).Log("msg", "failed to save progress", "err", err)
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+// readWindow reads all series matching matchers between from and to
+// (inclusive) and forwards their samples.
+func (c *Component) readWindow(ctx context.Context, client remote.ReadClient, matchers [][]*labels.Matcher, from, to int64) error {
+	for _, m := range matchers {
+		query, err := remote.ToQuery(from, to, m, nil)
+		if err != nil {
+			return err
+		}
+		ss, err := client.Read(ctx, query, false)
+		if err != nil {
+			return err
+		}
+		if err := c.forward(ctx, ss); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// forward appends every sample of ss to the fanout.
+func (c *Component) forward(ctx context.Context, ss storage.SeriesSet) error {
+	app := c.fanout.Appender(ctx)
+	pending := 0
+
+	var it chunkenc.Iterator
+	for ss.Next() {
+		series := ss.At()
+		lbls := series.Labels()
+
+		it = series.Iterator(it)
+		for vt := it.Next(); vt != chunkenc.ValNone; vt = it.Next() {
+			var err error
+			switch vt {
+			case chunkenc.ValFloat:
+				t, v := it.At()
+				_, err = app.Append(0, lbls, t, v)
+			case chunkenc.ValHistogram:
+				t, h := it.AtHistogram(nil)
+				_, err = app.AppendHistogram(0, lbls, t, h, nil)
+			case chunkenc.ValFloatHistogram:
+				t, fh := it.AtFloatHistogram(nil)
+				_, err = app.AppendHistogram(0, lbls, t, nil, fh)
+			}
+			if err != nil {
+				level.Debug(c.log).Log("msg", "failed to append sample", "series", lbls, "err", err)
+			}
+
+			pending++
+			if pending >= maxSamplesPerCommit {
+				if err := app.Commit(); err != nil {
+					return fmt.Errorf("committing samples: %w", err)
+				}
+				c.samplesTotal.Add(float64(pending))
+				app, pending = c.fanout.Appender(ctx), 0
+			}
+		}
+		if err := it.Err(); err != nil {
+			_ = app.Rollback()
+			return err
+		}
+	}
+	if err := ss.Err(); err != nil {
+		_ = app.Rollback()
+		return err
+	}
+
+	if err := app.Commit(); err != nil {
+		return fmt.Errorf("committing samples: %w", err)
+	}
+	c.samplesTotal.Add(float64(pending))
+	return nil
+}
+
+func (c *Component) updateHealth(done bool, err error) {
+	c.healthMut.Lock()
+	defer c.healthMut.Unlock()
+
+	switch {
+	case err != nil:
+		c.health = component.Health{
+			Health:     component.HealthTypeUnhealthy,
+			Message:    fmt.Sprintf("remote read failed: %s", err),
+			UpdateTime: time.Now(),
+		}
+	case done:
+		c.health = component.Health{
+			Health:     component.HealthTypeHealthy,
+			Message:    "read completed",
+			UpdateTime: time.Now(),
+		}
+	default:
+		c.health = component.Health{
+			Health:     component.HealthTypeHealthy,
+			Message:    "read remote endpoint",
+			UpdateTime: time.Now(),
+		}
+	}
+}
+
+// Update updates the prometheus.remote_read component.
+func (c *Component) Update(args component.Arguments) error {
+	newArgs := args.(Arguments)
+	c.fanout.UpdateChildren(newArgs.ForwardTo)
+
+	cfg, err := newArgs.clientConfig()
+	if err != nil {
+		return err
+	}
+	client, err := remote.NewReadClient(c.opts.ID, cfg, common.WithUserAgent(useragent.Get()))
+	if err != nil {
+		return err
+	}
+
+	c.mut.Lock()
+	c.args = newArgs
+	c.client = client
+	c.mut.Unlock()
+
+	select {
+	case c.updated <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// CurrentHealth returns the current health of the component.
+func (c *Component) CurrentHealth() component.Health {
+	c.healthMut.RLock()
+	defer c.healthMut.RUnlock()
+	return c.health
+}
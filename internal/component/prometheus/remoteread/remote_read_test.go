@@ -0,0 +1,228 @@
+package remoteread
+
+import (
+	"context"
+	"log/slog"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/prometheus/config"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/storage"
+	"github.com/prometheus/prometheus/storage/remote"
+	"github.com/prometheus/prometheus/util/teststorage"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/alloy/internal/component"
+	alloyprom "github.com/grafana/alloy/internal/component/prometheus"
+	"github.com/grafana/alloy/internal/service/labelstore"
+	"github.com/grafana/alloy/internal/util"
+	"github.com/grafana/alloy/syntax"
+)
+
+func TestUnmarshalAlloy(t *testing.T) {
+	alloyCfg := `
+		url        = "http://prometheus:9090/api/v1/read"
+		selectors  = ["{job=\"node\"}", "up"]
+		forward_to = []
+		start      = "2024-01-01T00:00:00Z"
+		end        = "2024-01-02T00:00:00Z"
+		headers    = { "X-Scope-OrgID" = "tenant" }
+		basic_auth {
+			username = "user"
+			password = "pass"
+		}
+`
+	var args Arguments
+	require.NoError(t, syntax.Unmarshal([]byte(alloyCfg), &args))
+	require.Equal(t, []string{`{job="node"}`, "up"}, args.Selectors)
+	require.Equal(t, time.Hour, args.MaxQueryRange)
+	require.True(t, args.PreferStreamedChunks)
+	require.Equal(t, "user", args.HTTPClientConfig.BasicAuth.Username)
+
+	matchers, err := args.matchers()
+	require.NoError(t, err)
+	require.Len(t, matchers, 2)
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		cfg    string
+		errMsg string
+	}{
+		{
+			name: "invalid selector",
+			cfg: `
+				url        = "http://prometheus:9090/api/v1/read"
+				selectors  = ["{job="]
+				forward_to = []`,
+			errMsg: "invalid selector",
+		},
+		{
+			name: "end before start",
+			cfg: `
+				url        = "http://prometheus:9090/api/v1/read"
+				selectors  = ["up"]
+				forward_to = []
+				start      = "2024-01-02T00:00:00Z"
+				end        = "2024-01-01T00:00:00Z"`,
+			errMsg: "start must be before end",
+		},
+		{
+			name: "end with interval",
+			cfg: `
+				url        = "http://prometheus:9090/api/v1/read"
+				selectors  = ["up"]
+				forward_to = []
+				end        = "2024-01-01T00:00:00Z"
+				interval   = "1m"`,
+			errMsg: "end can't be set when interval is set",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var args Arguments
+			require.ErrorContains(t, syntax.Unmarshal([]byte(tt.cfg), &args), tt.errMsg)
+		})
+	}
+}
+
+func TestReadOnce(t *testing.T) {
+	for _, streamed := range []bool{true, false} {
+		t.Run(map[bool]string{true: "streamed chunks", false: "samples"}[streamed], func(t *testing.T) {
+			end := time.Now().Truncate(time.Minute)
+			start := end.Add(-3 * time.Hour)
+
+			// Write one sample per minute for two series.
+			remoteStorage := teststorage.New(t)
+			t.Cleanup(func() { remoteStorage.Close() })
+			app := remoteStorage.Appender(t.Context())
+			for ts := start; !ts.After(end); ts = ts.Add(time.Minute) {
+				_, err := app.Append(0, labels.FromStrings("__name__", "up", "job", "node"), ts.UnixMilli(), 1)
+				require.NoError(t, err)
+				_, err = app.Append(0, labels.FromStrings("__name__", "up", "job", "other"), ts.UnixMilli(), 0)
+				require.NoError(t, err)
+			}
+			require.NoError(t, app.Commit())
+
+			srv := httptest.NewServer(remote.NewReadHandler(slog.New(slog.DiscardHandler), nil, remoteStorage, func() config.Config {
+				return config.DefaultConfig
+			}, 0, 1, 1024*1024))
+			t.Cleanup(srv.Close)
+
+			collected := newCollector()
+			args := DefaultArguments
+			args.URL = srv.URL
+			args.Selectors = []string{`up{job="node"}`}
+			args.Start = start.UTC().Format(time.RFC3339)
+			args.End = end.UTC().Format(time.RFC3339)
+			args.PreferStreamedChunks = streamed
+			args.ForwardTo = []storage.Appendable{collected.appendable()}
+
+			dataPath := t.TempDir()
+			c, err := New(testOptions(t, dataPath), args)
+			require.NoError(t, err)
+
+			ctx, cancel := context.WithCancel(t.Context())
+			defer cancel()
+			go func() { require.NoError(t, c.Run(ctx)) }()
+
+			require.Eventually(t, func() bool {
+				return c.CurrentHealth().Message == "read completed"
+			}, 10*time.Second, 10*time.Millisecond)
+
+			// 3 hours of one sample per minute, including both ends, for one series.
+			require.Equal(t, 181, collected.count(labels.FromStrings("__name__", "up", "job", "node")))
+			require.Zero(t, collected.count(labels.FromStrings("__name__", "up", "job", "other")))
+
+			p, err := loadProgress(filepath.Join(dataPath, progressFile))
+			require.NoError(t, err)
+			require.True(t, p.Completed)
+			require.Equal(t, end.UnixMilli(), p.Last)
+		})
+	}
+}
+
+func TestReadResumesFromProgress(t *testing.T) {
+	end := time.Now().Truncate(time.Minute)
+	start := end.Add(-time.Hour)
+
+	remoteStorage := teststorage.New(t)
+	t.Cleanup(func() { remoteStorage.Close() })
+	app := remoteStorage.Appender(t.Context())
+	for ts := start; !ts.After(end); ts = ts.Add(time.Minute) {
+		_, err := app.Append(0, labels.FromStrings("__name__", "up"), ts.UnixMilli(), 1)
+		require.NoError(t, err)
+	}
+	require.NoError(t, app.Commit())
+
+	srv := httptest.NewServer(remote.NewReadHandler(slog.New(slog.DiscardHandler), nil, remoteStorage, func() config.Config {
+		return config.DefaultConfig
+	}, 0, 1, 1024*1024))
+	t.Cleanup(srv.Close)
+
+	collected := newCollector()
+	args := DefaultArguments
+	args.URL = srv.URL
+	args.Selectors = []string{"up"}
+	args.Start = start.UTC().Format(time.RFC3339)
+	args.End = end.UTC().Format(time.RFC3339)
+	args.ForwardTo = []storage.Appendable{collected.appendable()}
+
+	// Pretend the first half hour was already forwarded.
+	dataPath := t.TempDir()
+	require.NoError(t, saveProgress(filepath.Join(dataPath, progressFile), progress{
+		Hash: queryHash(args),
+		Last: start.Add(30 * time.Minute).UnixMilli(),
+	}))
+
+	c, err := New(testOptions(t, dataPath), args)
+	require.NoError(t, err)
+	done, err := c.read(t.Context())
+	require.NoError(t, err)
+	require.True(t, done)
+	require.Equal(t, 30, collected.count(labels.FromStrings("__name__", "up")))
+}
+
+func testOptions(t *testing.T, dataPath string) component.Options {
+	return component.Options{
+		ID:         "prometheus.remote_read.test",
+		Logger:     util.TestAlloyLogger(t),
+		Registerer: prometheus.NewRegistry(),
+		DataPath:   dataPath,
+		GetServiceData: func(name string) (interface{}, error) {
+			return labelstore.New(nil, prometheus.DefaultRegisterer), nil
+		},
+	}
+}
+
+// collector counts the samples appended for each series.
+type collector struct {
+	mut     sync.Mutex
+	samples map[uint64]int
+}
+
+func newCollector() *collector {
+	return &collector{samples: make(map[uint64]int)}
+}
+
+func (c *collector) appendable() storage.Appendable {
+	return alloyprom.NewInterceptor(nil, alloyprom.WithAppendHook(func(ref storage.SeriesRef, l labels.Labels, _ int64, _ float64, _ storage.Appender) (storage.SeriesRef, error) {
+		c.mut.Lock()
+		defer c.mut.Unlock()
+		c.samples[l.Hash()]++
+		return ref, nil
+	}))
+}
+
+func (c *collector) count(l labels.Labels) int {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	return c.samples[l.Hash()]
+}
@@ -0,0 +1,143 @@
+package remoteread
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	common "github.com/prometheus/common/config"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/config"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/prometheus/prometheus/promql/parser"
+	"github.com/prometheus/prometheus/storage"
+	"github.com/prometheus/prometheus/storage/remote"
+
+	types "github.com/grafana/alloy/internal/component/common/config"
+)
+
+// DefaultArguments holds default settings for Arguments.
+var DefaultArguments = Arguments{
+	RemoteTimeout:        time.Minute,
+	Lookback:             time.Hour,
+	MaxQueryRange:        time.Hour,
+	PreferStreamedChunks: true,
+	HTTPClientConfig:     types.DefaultHTTPClientConfig,
+}
+
+// Arguments represents the input state of the prometheus.remote_read
+// component.
+type Arguments struct {
+	URL                  string                 `alloy:"url,attr"`
+	Selectors            []string               `alloy:"selectors,attr"`
+	ForwardTo            []storage.Appendable   `alloy:"forward_to,attr"`
+	Start                string                 `alloy:"start,attr,optional"`
+	End                  string                 `alloy:"end,attr,optional"`
+	Lookback             time.Duration          `alloy:"lookback,attr,optional"`
+	Interval             time.Duration          `alloy:"interval,attr,optional"`
+	MaxQueryRange        time.Duration          `alloy:"max_query_range,attr,optional"`
+	RemoteTimeout        time.Duration          `alloy:"remote_timeout,attr,optional"`
+	Headers              map[string]string      `alloy:"headers,attr,optional"`
+	PreferStreamedChunks bool                   `alloy:"prefer_streamed_chunks,attr,optional"`
+	HTTPClientConfig     types.HTTPClientConfig `alloy:",squash"`
+}
+
+// SetToDefault implements syntax.Defaulter.
+func (args *Arguments) SetToDefault() {
+	*args = DefaultArguments
+}
+
+// Validate implements syntax.Validator.
+func (args *Arguments) Validate() error {
+	if _, err := url.ParseRequestURI(args.URL); err != nil {
+		return fmt.Errorf("invalid url: %w", err)
+	}
+	if len(args.Selectors) == 0 {
+		return errors.New("at least one selector must be provided")
+	}
+	if _, err := args.matchers(); err != nil {
+		return err
+	}
+
+	start, end, err := args.timeRange()
+	if err != nil {
+		return err
+	}
+	if !start.IsZero() && !end.IsZero() && !start.Before(end) {
+		return errors.New("start must be before end")
+	}
+	if args.Interval < 0 {
+		return errors.New("interval must not be negative")
+	}
+	if args.Interval > 0 && !end.IsZero() {
+		return errors.New("end can't be set when interval is set")
+	}
+	if args.Lookback <= 0 {
+		return errors.New("lookback must be greater than 0")
+	}
+	if args.MaxQueryRange <= 0 {
+		return errors.New("max_query_range must be greater than 0")
+	}
+	if args.RemoteTimeout <= 0 {
+		return errors.New("remote_timeout must be greater than 0")
+	}
+
+	return args.HTTPClientConfig.Validate()
+}
+
+// timeRange parses the start and end arguments. Unset values are returned as
+// the zero time.
+func (args *Arguments) timeRange() (start, end time.Time, err error) {
+	if args.Start != "" {
+		if start, err = time.Parse(time.RFC3339, args.Start); err != nil {
+			return start, end, fmt.Errorf("invalid start: %w", err)
+		}
+	}
+	if args.End != "" {
+		if end, err = time.Parse(time.RFC3339, args.End); err != nil {
+			return start, end, fmt.Errorf("invalid end: %w", err)
+		}
+	}
+	return start, end, nil
+}
+
+// matchers parses every selector into a set of label matchers.
+func (args *Arguments) matchers() ([][]*labels.Matcher, error) {
+	res := make([][]*labels.Matcher, 0, len(args.Selectors))
+	for _, selector := range args.Selectors {
+		m, err := parser.ParseMetricSelector(selector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid selector %q: %w", selector, err)
+		}
+		res = append(res, m)
+	}
+	return res, nil
+}
+
+// clientConfig converts args into the configuration of a Prometheus remote
+// read client.
+func (args *Arguments) clientConfig() (*remote.ClientConfig, error) {
+	u, err := url.Parse(args.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	responseTypes := []prompb.ReadRequest_ResponseType{prompb.ReadRequest_SAMPLES}
+	if args.PreferStreamedChunks {
+		responseTypes = []prompb.ReadRequest_ResponseType{
+			prompb.ReadRequest_STREAMED_XOR_CHUNKS,
+			prompb.ReadRequest_SAMPLES,
+		}
+	}
+
+	return &remote.ClientConfig{
+		URL:                   &common.URL{URL: u},
+		Timeout:               model.Duration(args.RemoteTimeout),
+		HTTPClientConfig:      *args.HTTPClientConfig.Convert(),
+		Headers:               args.Headers,
+		ChunkedReadLimit:      config.DefaultChunkedReadLimit,
+		AcceptedResponseTypes: responseTypes,
+	}, nil
+}
@@ -3,7 +3,6 @@ package remotewrite
 import (
 	"context"
 	"fmt"
-	"log/slog"
 	"math"
 	"os"
 	"path/filepath"
@@ -12,7 +11,6 @@ import (
 
 	"github.com/go-kit/log"
 	"github.com/prometheus/prometheus/model/timestamp"
-	"github.com/prometheus/prometheus/storage"
 	"github.com/prometheus/prometheus/storage/remote"
 	"go.uber.org/atomic"
 
@@ -20,7 +18,6 @@ import (
 	"github.com/grafana/alloy/internal/component"
 	"github.com/grafana/alloy/internal/component/prometheus"
 	"github.com/grafana/alloy/internal/featuregate"
-	"github.com/grafana/alloy/internal/runtime/logging"
 	"github.com/grafana/alloy/internal/runtime/logging/level"
 	"github.com/grafana/alloy/internal/service/labelstore"
 	"github.com/grafana/alloy/internal/service/livedebugging"
@@ -53,10 +50,9 @@ type Component struct {
 	log  log.Logger
 	opts component.Options
 
-	walStore    *wal.Storage
-	remoteStore *remote.Storage
-	storage     storage.Storage
-	exited      atomic.Bool
+	walStore *wal.Storage
+	storage  *routedStorage
+	exited   atomic.Bool
 
 	mut sync.RWMutex
 	cfg Arguments
@@ -83,15 +79,7 @@ func New(o component.Options, args Arguments) (*Component, error) {
 		return nil, err
 	}
 
-	remoteLogger := slog.New(
-		logging.NewSlogGoKitHandler(
-			log.With(o.Logger, "subcomponent", "rw"),
-		),
-	)
-	// TODO: Expose the option to enable type and unit labels: https://github.com/grafana/alloy/issues/4659
-	remoteStore := remote.NewStorage(remoteLogger, o.Registerer, startTime, o.DataPath, remoteFlushDeadline, nil, false)
-
-	walStorage.SetNotifier(remoteStore)
+	routedStore := newRoutedStorage(o.Logger, o.Registerer, o.DataPath, remoteFlushDeadline, walStorage)
 
 	service, err := o.GetServiceData(labelstore.ServiceName)
 	if err != nil {
@@ -108,17 +96,11 @@ func New(o component.Options, args Arguments) (*Component, error) {
 		return nil, err
 	}
 
-	fanoutLogger := slog.New(
-		logging.NewSlogGoKitHandler(
-			log.With(o.Logger, "subcomponent", "fanout"),
-		),
-	)
 	res := &Component{
 		log:                o.Logger,
 		opts:               o,
 		walStore:           walStorage,
-		remoteStore:        remoteStore,
-		storage:            storage.NewFanout(fanoutLogger, walStorage, remoteStore),
+		storage:            routedStore,
 		debugDataPublisher: debugDataPublisher.(livedebugging.DebugDataPublisher),
 	}
 
@@ -178,7 +160,7 @@ func (c *Component) Run(ctx context.Context) error {
 			//
 			// Subtracting a duration from ts will delay when it will be considered
 			// inactive and scheduled for deletion.
-			ts := c.remoteStore.LowestSentTimestamp() - minWALTime.Milliseconds()
+			ts := c.storage.LowestSentTimestamp() - minWALTime.Milliseconds()
 			if ts < 0 {
 				ts = 0
 			}
@@ -221,7 +203,7 @@ func (c *Component) Update(newConfig component.Arguments) error {
 	c.mut.Lock()
 	defer c.mut.Unlock()
 
-	convertedConfig, err := convertConfigs(cfg)
+	convertedConfigs, err := convertRoutes(cfg)
 	if err != nil {
 		return err
 	}
@@ -231,14 +213,16 @@ func (c *Component) Update(newConfig component.Arguments) error {
 	}
 
 	uid := alloyseed.Get().UID
-	for _, cfg := range convertedConfig.RemoteWriteConfigs {
-		if cfg.Headers == nil {
-			cfg.Headers = map[string]string{}
+	for _, convertedConfig := range convertedConfigs {
+		for _, cfg := range convertedConfig.RemoteWriteConfigs {
+			if cfg.Headers == nil {
+				cfg.Headers = map[string]string{}
+			}
+			cfg.Headers[alloyseed.LegacyHeaderName] = uid
+			cfg.Headers[alloyseed.HeaderName] = uid
 		}
-		cfg.Headers[alloyseed.LegacyHeaderName] = uid
-		cfg.Headers[alloyseed.HeaderName] = uid
 	}
-	err = c.remoteStore.ApplyConfig(convertedConfig)
+	err = c.storage.ApplyConfig(convertedConfigs)
 	if err != nil {
 		return err
 	}
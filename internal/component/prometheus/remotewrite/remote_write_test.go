@@ -529,6 +529,89 @@ func TestUpdate(t *testing.T) {
 	assertReceived(t, writeResult, expected)
 }
 
+// TestRoutes ensures that routes send the matching subset of series to their
+// endpoints with the tenant header of the route.
+func TestRoutes(t *testing.T) {
+	type request struct {
+		tenant string
+		series []string
+	}
+	received := make(chan request, 10)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req, err := remote.DecodeWriteRequest(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		var series []string
+		for _, ts := range req.Timeseries {
+			var parts []string
+			for _, l := range ts.Labels {
+				parts = append(parts, l.Name+"="+l.Value)
+			}
+			series = append(series, strings.Join(parts, ","))
+		}
+		received <- request{tenant: r.Header.Get("X-Scope-OrgID"), series: series}
+	}))
+	defer srv.Close()
+
+	args := testArgs(t, fmt.Sprintf(`
+	endpoint {
+		name           = "shared"
+		url            = "%s/api/v1/write"
+		remote_timeout = "100ms"
+
+		queue_config {
+			max_samples_per_send = 1
+			batch_send_deadline  = "1m"
+		}
+	}
+
+	route "team_a" {
+		endpoints         = ["shared"]
+		tenant_label      = "tenant"
+		tenant            = "team-a"
+		drop_tenant_label = true
+	}
+
+	route "infra" {
+		endpoints = ["shared"]
+		selector  = "{job=~\"node|kubelet\", tenant=\"\"}"
+		tenant    = "infra"
+	}
+`, srv.URL))
+	tc, err := componenttest.NewControllerFromID(util.TestLogger(t), "prometheus.remote_write")
+	require.NoError(t, err)
+	go func() {
+		err = tc.Run(componenttest.TestContext(t), args)
+		require.NoError(t, err)
+	}()
+	require.NoError(t, tc.WaitRunning(5*time.Second))
+
+	sampleTime := time.Now().Add(time.Minute).UnixMilli()
+	sendMetrics(t, tc, []Appendable{
+		&Sample{Labels: labels.FromStrings("job", "app", "tenant", "team-a"), Time: sampleTime, Value: 1},
+		&Sample{Labels: labels.FromStrings("job", "node"), Time: sampleTime, Value: 2},
+		&Sample{Labels: labels.FromStrings("job", "app", "tenant", "team-b"), Time: sampleTime, Value: 3},
+	})
+
+	got := map[string][]string{}
+	for range 2 {
+		select {
+		case <-time.After(time.Minute):
+			require.FailNow(t, "timed out waiting for metrics")
+		case req := <-received:
+			got[req.tenant] = append(got[req.tenant], req.series...)
+		}
+	}
+	require.Equal(t, map[string][]string{
+		"team-a": {"job=app"},
+		"infra":  {"job=node"},
+	}, got)
+}
+
 func assertReceived(t *testing.T, writeResult chan string, expect string) {
 	select {
 	case <-time.After(time.Minute):
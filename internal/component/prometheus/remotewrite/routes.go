@@ -0,0 +1,209 @@
+package remotewrite
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+
+	"github.com/grafana/regexp"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/config"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/model/relabel"
+	"github.com/prometheus/prometheus/promql/parser"
+)
+
+// DefaultTenantHeader is the header used to send the tenant of a route when
+// tenant_header isn't set.
+const DefaultTenantHeader = "X-Scope-OrgID"
+
+// RouteOptions describes a routing rule which sends the subset of series in
+// the WAL matching the rule to a set of endpoints.
+type RouteOptions struct {
+	Name            string   `alloy:",label"`
+	Endpoints       []string `alloy:"endpoints,attr"`
+	Selector        string   `alloy:"selector,attr,optional"`
+	TenantLabel     string   `alloy:"tenant_label,attr,optional"`
+	Tenant          string   `alloy:"tenant,attr,optional"`
+	TenantHeader    string   `alloy:"tenant_header,attr,optional"`
+	DropTenantLabel bool     `alloy:"drop_tenant_label,attr,optional"`
+}
+
+// SetToDefault implements syntax.Defaulter.
+func (r *RouteOptions) SetToDefault() {
+	*r = RouteOptions{
+		TenantHeader: DefaultTenantHeader,
+	}
+}
+
+// Validate implements syntax.Validator.
+func (r *RouteOptions) Validate() error {
+	if len(r.Endpoints) == 0 {
+		return fmt.Errorf("route %q must reference at least one endpoint", r.Name)
+	}
+	seen := make(map[string]struct{}, len(r.Endpoints))
+	for _, name := range r.Endpoints {
+		if _, ok := seen[name]; ok {
+			return fmt.Errorf("route %q references endpoint %q more than once", r.Name, name)
+		}
+		seen[name] = struct{}{}
+	}
+	if r.Selector != "" {
+		if _, err := parser.ParseMetricSelector(r.Selector); err != nil {
+			return fmt.Errorf("invalid selector %q for route %q: %w", r.Selector, r.Name, err)
+		}
+	}
+	if r.TenantLabel != "" {
+		if !model.LabelName(r.TenantLabel).IsValid() {
+			return fmt.Errorf("invalid tenant_label %q for route %q", r.TenantLabel, r.Name)
+		}
+		if r.Tenant == "" {
+			return fmt.Errorf("tenant must be set when tenant_label is set for route %q", r.Name)
+		}
+	}
+	if r.DropTenantLabel && r.TenantLabel == "" {
+		return fmt.Errorf("drop_tenant_label requires tenant_label to be set for route %q", r.Name)
+	}
+	if r.Tenant != "" && r.TenantHeader == "" {
+		return fmt.Errorf("tenant_header must not be empty for route %q", r.Name)
+	}
+	return nil
+}
+
+// relabelConfigs returns the relabeling rules which drop every series not
+// matching the route.
+func (r *RouteOptions) relabelConfigs() []*relabel.Config {
+	var res []*relabel.Config
+
+	// Validate ensures that the selector can be parsed.
+	matchers, _ := parser.ParseMetricSelector(r.Selector)
+	for _, m := range matchers {
+		switch m.Type {
+		case labels.MatchEqual:
+			res = append(res, matchRelabelConfig(relabel.Keep, m.Name, regexp.QuoteMeta(m.Value)))
+		case labels.MatchNotEqual:
+			res = append(res, matchRelabelConfig(relabel.Drop, m.Name, regexp.QuoteMeta(m.Value)))
+		case labels.MatchRegexp:
+			res = append(res, matchRelabelConfig(relabel.Keep, m.Name, m.Value))
+		case labels.MatchNotRegexp:
+			res = append(res, matchRelabelConfig(relabel.Drop, m.Name, m.Value))
+		}
+	}
+
+	if r.TenantLabel != "" {
+		res = append(res, matchRelabelConfig(relabel.Keep, r.TenantLabel, regexp.QuoteMeta(r.Tenant)))
+	}
+	if r.DropTenantLabel {
+		res = append(res, &relabel.Config{
+			Action:               relabel.LabelDrop,
+			Regex:                relabel.MustNewRegexp(regexp.QuoteMeta(r.TenantLabel)),
+			NameValidationScheme: model.LegacyValidation,
+		})
+	}
+	return res
+}
+
+// matchRelabelConfig returns a relabeling rule which performs action on
+// series where the value of label fully matches expr. Relabeling regular
+// expressions are anchored the same way as PromQL matchers are, so missing
+// labels are treated as having an empty value in both cases.
+func matchRelabelConfig(action relabel.Action, label, expr string) *relabel.Config {
+	return &relabel.Config{
+		SourceLabels:         model.LabelNames{model.LabelName(label)},
+		Separator:            ";",
+		Action:               action,
+		Regex:                relabel.MustNewRegexp(expr),
+		NameValidationScheme: model.LegacyValidation,
+	}
+}
+
+// validateRoutes checks that route names are unique and that routes only
+// reference existing endpoints.
+func validateRoutes(endpoints []*EndpointOptions, routes []*RouteOptions) error {
+	names := make(map[string]struct{}, len(endpoints))
+	for _, ep := range endpoints {
+		if ep.Name != "" {
+			names[ep.Name] = struct{}{}
+		}
+	}
+
+	seen := make(map[string]struct{}, len(routes))
+	for _, route := range routes {
+		if route.Name == "" {
+			return errors.New("route name must not be empty")
+		}
+		if _, ok := seen[route.Name]; ok {
+			return fmt.Errorf("found duplicate route name %q", route.Name)
+		}
+		seen[route.Name] = struct{}{}
+
+		for _, name := range route.Endpoints {
+			if _, ok := names[name]; !ok {
+				return fmt.Errorf("route %q references unknown endpoint %q; endpoints used by routes must set name", route.Name, name)
+			}
+		}
+	}
+	return nil
+}
+
+// convertRoutes converts cfg into one Prometheus configuration per route. The
+// configuration under the empty key holds every endpoint which isn't
+// referenced by a route, and receives all series.
+func convertRoutes(cfg Arguments) (map[string]*config.Config, error) {
+	global := config.GlobalConfig{
+		ExternalLabels: labels.FromMap(cfg.ExternalLabels),
+	}
+
+	byName := make(map[string]*EndpointOptions, len(cfg.Endpoints))
+	for _, ep := range cfg.Endpoints {
+		if ep.Name != "" {
+			byName[ep.Name] = ep
+		}
+	}
+
+	res := make(map[string]*config.Config, len(cfg.Routes)+1)
+	routed := make(map[*EndpointOptions]struct{})
+	for _, route := range cfg.Routes {
+		conf := &config.Config{GlobalConfig: global}
+		for _, name := range route.Endpoints {
+			ep, ok := byName[name]
+			if !ok {
+				return nil, fmt.Errorf("route %q references unknown endpoint %q", route.Name, name)
+			}
+			routed[ep] = struct{}{}
+
+			rwc, err := convertEndpoint(ep)
+			if err != nil {
+				return nil, err
+			}
+			// Route rules run before the endpoint's own write_relabel_config
+			// blocks so that routing decisions are made on the original labels.
+			rwc.WriteRelabelConfigs = append(route.relabelConfigs(), rwc.WriteRelabelConfigs...)
+			if route.Tenant != "" {
+				headers := maps.Clone(rwc.Headers)
+				if headers == nil {
+					headers = map[string]string{}
+				}
+				headers[route.TenantHeader] = route.Tenant
+				rwc.Headers = headers
+			}
+			conf.RemoteWriteConfigs = append(conf.RemoteWriteConfigs, rwc)
+		}
+		res[route.Name] = conf
+	}
+
+	unrouted := &config.Config{GlobalConfig: global}
+	for _, ep := range cfg.Endpoints {
+		if _, ok := routed[ep]; ok {
+			continue
+		}
+		rwc, err := convertEndpoint(ep)
+		if err != nil {
+			return nil, err
+		}
+		unrouted.RemoteWriteConfigs = append(unrouted.RemoteWriteConfigs, rwc)
+	}
+	res[""] = unrouted
+
+	return res, nil
+}
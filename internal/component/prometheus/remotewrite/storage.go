@@ -0,0 +1,198 @@
+package remotewrite
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/go-kit/log"
+	prom "github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/prometheus/config"
+	"github.com/prometheus/prometheus/storage"
+	"github.com/prometheus/prometheus/storage/remote"
+
+	"github.com/grafana/alloy/internal/runtime/logging"
+	"github.com/grafana/alloy/internal/static/metrics/wal"
+)
+
+// routedStorage is a storage.Storage which writes to a WAL shared by one
+// remote storage per route.
+//
+// Every remote storage reads the same WAL, and routes filter the series they
+// send with relabeling rules. Remote storages of routes are registered with a
+// route label so that their queue metrics can be told apart.
+type routedStorage struct {
+	logger        log.Logger
+	reg           prom.Registerer
+	dataPath      string
+	flushDeadline time.Duration
+
+	wal *wal.Storage
+
+	mut     sync.RWMutex
+	remotes map[string]*routeStorage
+	fanout  storage.Storage
+}
+
+type routeStorage struct {
+	*remote.Storage
+	labeled bool // Whether metrics are registered with a route label.
+	queues  int  // Number of endpoints in the route.
+}
+
+var _ storage.Storage = (*routedStorage)(nil)
+
+func newRoutedStorage(logger log.Logger, reg prom.Registerer, dataPath string, flushDeadline time.Duration, walStorage *wal.Storage) *routedStorage {
+	s := &routedStorage{
+		logger:        logger,
+		reg:           reg,
+		dataPath:      dataPath,
+		flushDeadline: flushDeadline,
+		wal:           walStorage,
+		remotes:       make(map[string]*routeStorage),
+	}
+	s.updateFanout()
+	walStorage.SetNotifier(s)
+	return s
+}
+
+// ApplyConfig applies the configuration of every route, creating and closing
+// remote storages as routes are added or removed. The configuration for
+// endpoints which aren't part of any route is stored under the empty key.
+func (s *routedStorage) ApplyConfig(configs map[string]*config.Config) error {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	// The metrics of the remote storage for unrouted endpoints only carry a
+	// route label when routes are in use, so that the metrics of a component
+	// without routes are unchanged. All metrics with the same name must have
+	// the same set of labels.
+	labeled := len(configs) > 1
+
+	var errs []error
+	for name, rs := range s.remotes {
+		if _, ok := configs[name]; ok && rs.labeled == labeled {
+			continue
+		}
+		if err := rs.Close(); err != nil {
+			errs = append(errs, err)
+		}
+		delete(s.remotes, name)
+	}
+
+	for name, conf := range configs {
+		rs, ok := s.remotes[name]
+		if !ok {
+			rs = s.newRouteStorage(name, labeled)
+			s.remotes[name] = rs
+		}
+		if err := rs.ApplyConfig(conf); err != nil {
+			errs = append(errs, err)
+		}
+		rs.queues = len(conf.RemoteWriteConfigs)
+	}
+
+	s.updateFanout()
+	return errors.Join(errs...)
+}
+
+func (s *routedStorage) newRouteStorage(route string, labeled bool) *routeStorage {
+	var (
+		logger = log.With(s.logger, "subcomponent", "rw")
+		reg    = s.reg
+	)
+	if labeled {
+		logger = log.With(logger, "route", route)
+		reg = prom.WrapRegistererWith(prom.Labels{"route": route}, reg)
+	}
+
+	remoteLogger := slog.New(logging.NewSlogGoKitHandler(logger))
+	// TODO: Expose the option to enable type and unit labels: https://github.com/grafana/alloy/issues/4659
+	return &routeStorage{
+		Storage: remote.NewStorage(remoteLogger, reg, startTime, s.dataPath, s.flushDeadline, nil, false),
+		labeled: labeled,
+	}
+}
+
+// updateFanout rebuilds the storage which appends to the WAL and the remote
+// storages. updateFanout must be called with s.mut held.
+func (s *routedStorage) updateFanout() {
+	secondaries := make([]storage.Storage, 0, len(s.remotes))
+	for _, rs := range s.remotes {
+		secondaries = append(secondaries, rs.Storage)
+	}
+
+	fanoutLogger := slog.New(
+		logging.NewSlogGoKitHandler(
+			log.With(s.logger, "subcomponent", "fanout"),
+		),
+	)
+	s.fanout = storage.NewFanout(fanoutLogger, s.wal, secondaries...)
+}
+
+// Notify implements wlog.WriteNotified.
+func (s *routedStorage) Notify() {
+	s.mut.RLock()
+	defer s.mut.RUnlock()
+
+	for _, rs := range s.remotes {
+		rs.Notify()
+	}
+}
+
+// LowestSentTimestamp returns the lowest timestamp sent by any endpoint of any
+// route.
+func (s *routedStorage) LowestSentTimestamp() int64 {
+	s.mut.RLock()
+	defer s.mut.RUnlock()
+
+	lowest := int64(math.MaxInt64)
+	for _, rs := range s.remotes {
+		if rs.queues == 0 {
+			continue
+		}
+		lowest = min(lowest, rs.LowestSentTimestamp())
+	}
+	if lowest == math.MaxInt64 {
+		return 0
+	}
+	return lowest
+}
+
+// Appender implements storage.Appendable.
+func (s *routedStorage) Appender(ctx context.Context) storage.Appender {
+	s.mut.RLock()
+	defer s.mut.RUnlock()
+	return s.fanout.Appender(ctx)
+}
+
+// Querier implements storage.Queryable.
+func (s *routedStorage) Querier(mint, maxt int64) (storage.Querier, error) {
+	s.mut.RLock()
+	defer s.mut.RUnlock()
+	return s.fanout.Querier(mint, maxt)
+}
+
+// ChunkQuerier implements storage.ChunkQueryable.
+func (s *routedStorage) ChunkQuerier(mint, maxt int64) (storage.ChunkQuerier, error) {
+	s.mut.RLock()
+	defer s.mut.RUnlock()
+	return s.fanout.ChunkQuerier(mint, maxt)
+}
+
+// StartTime implements storage.Storage.
+func (s *routedStorage) StartTime() (int64, error) {
+	s.mut.RLock()
+	defer s.mut.RUnlock()
+	return s.fanout.StartTime()
+}
+
+// Close closes the WAL and every remote storage.
+func (s *routedStorage) Close() error {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	return s.fanout.Close()
+}
@@ -63,6 +63,7 @@ var (
 type Arguments struct {
 	ExternalLabels map[string]string  `alloy:"external_labels,attr,optional"`
 	Endpoints      []*EndpointOptions `alloy:"endpoint,block,optional"`
+	Routes         []*RouteOptions    `alloy:"route,block,optional"`
 	WALOptions     WALOptions         `alloy:"wal,block,optional"`
 }
 
@@ -71,6 +72,11 @@ func (rc *Arguments) SetToDefault() {
 	*rc = DefaultArguments
 }
 
+// Validate implements syntax.Validator.
+func (rc *Arguments) Validate() error {
+	return validateRoutes(rc.Endpoints, rc.Routes)
+}
+
 // EndpointOptions describes an individual location for where metrics in the WAL
 // should be delivered to using the remote_write protocol.
 type EndpointOptions struct {
@@ -243,25 +249,11 @@ type Exports struct {
 func convertConfigs(cfg Arguments) (*config.Config, error) {
 	var rwConfigs []*config.RemoteWriteConfig
 	for _, rw := range cfg.Endpoints {
-		parsedURL, err := url.Parse(rw.URL)
+		rwConfig, err := convertEndpoint(rw)
 		if err != nil {
-			return nil, fmt.Errorf("cannot parse remote_write url %q: %w", rw.URL, err)
+			return nil, err
 		}
-		rwConfigs = append(rwConfigs, &config.RemoteWriteConfig{
-			URL:                  &common.URL{URL: parsedURL},
-			RemoteTimeout:        model.Duration(rw.RemoteTimeout),
-			Headers:              rw.Headers,
-			Name:                 rw.Name,
-			SendExemplars:        rw.SendExemplars,
-			SendNativeHistograms: rw.SendNativeHistograms,
-			ProtobufMessage:      remote.WriteMessageType(rw.ProtobufMessage),
-			WriteRelabelConfigs:  alloy_relabel.ComponentToPromRelabelConfigs(rw.WriteRelabelConfigs),
-			HTTPClientConfig:     *rw.HTTPClientConfig.Convert(),
-			QueueConfig:          rw.QueueOptions.toPrometheusType(),
-			MetadataConfig:       rw.MetadataOptions.toPrometheusType(),
-			SigV4Config:          rw.SigV4.toPrometheusType(),
-			AzureADConfig:        rw.AzureAD.toPrometheusType(),
-		})
+		rwConfigs = append(rwConfigs, rwConfig)
 	}
 
 	return &config.Config{
@@ -272,6 +264,28 @@ func convertConfigs(cfg Arguments) (*config.Config, error) {
 	}, nil
 }
 
+func convertEndpoint(rw *EndpointOptions) (*config.RemoteWriteConfig, error) {
+	parsedURL, err := url.Parse(rw.URL)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse remote_write url %q: %w", rw.URL, err)
+	}
+	return &config.RemoteWriteConfig{
+		URL:                  &common.URL{URL: parsedURL},
+		RemoteTimeout:        model.Duration(rw.RemoteTimeout),
+		Headers:              rw.Headers,
+		Name:                 rw.Name,
+		SendExemplars:        rw.SendExemplars,
+		SendNativeHistograms: rw.SendNativeHistograms,
+		ProtobufMessage:      remote.WriteMessageType(rw.ProtobufMessage),
+		WriteRelabelConfigs:  alloy_relabel.ComponentToPromRelabelConfigs(rw.WriteRelabelConfigs),
+		HTTPClientConfig:     *rw.HTTPClientConfig.Convert(),
+		QueueConfig:          rw.QueueOptions.toPrometheusType(),
+		MetadataConfig:       rw.MetadataOptions.toPrometheusType(),
+		SigV4Config:          rw.SigV4.toPrometheusType(),
+		AzureADConfig:        rw.AzureAD.toPrometheusType(),
+	}, nil
+}
+
 // ManagedIdentityConfig is used to store managed identity config values
 type ManagedIdentityConfig struct {
 	// ClientID is the clientId of the managed identity that is being used to authenticate.
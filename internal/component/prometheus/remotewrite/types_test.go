@@ -322,3 +322,146 @@ func TestAlloyConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestRoutes(t *testing.T) {
+	tests := []struct {
+		testName string
+		cfg      string
+		errorMsg string
+	}{
+		{
+			testName: "Valid",
+			cfg: `
+			endpoint {
+				name = "default"
+				url  = "http://0.0.0.0:11111/api/v1/write"
+			}
+			route "team_a" {
+				endpoints = ["default"]
+				selector  = "{namespace=~\"team-a-.*\"}"
+				tenant    = "team-a"
+			}`,
+		},
+		{
+			testName: "UnknownEndpoint",
+			cfg: `
+			endpoint {
+				url = "http://0.0.0.0:11111/api/v1/write"
+			}
+			route "team_a" {
+				endpoints = ["default"]
+			}`,
+			errorMsg: `route "team_a" references unknown endpoint "default"`,
+		},
+		{
+			testName: "DuplicateRoute",
+			cfg: `
+			endpoint {
+				name = "default"
+				url  = "http://0.0.0.0:11111/api/v1/write"
+			}
+			route "team_a" {
+				endpoints = ["default"]
+			}
+			route "team_a" {
+				endpoints = ["default"]
+			}`,
+			errorMsg: `found duplicate route name "team_a"`,
+		},
+		{
+			testName: "InvalidSelector",
+			cfg: `
+			endpoint {
+				name = "default"
+				url  = "http://0.0.0.0:11111/api/v1/write"
+			}
+			route "team_a" {
+				endpoints = ["default"]
+				selector  = "{namespace="
+			}`,
+			errorMsg: `invalid selector "{namespace=" for route "team_a"`,
+		},
+		{
+			testName: "TenantLabelWithoutTenant",
+			cfg: `
+			endpoint {
+				name = "default"
+				url  = "http://0.0.0.0:11111/api/v1/write"
+			}
+			route "team_a" {
+				endpoints    = ["default"]
+				tenant_label = "tenant"
+			}`,
+			errorMsg: `tenant must be set when tenant_label is set for route "team_a"`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.testName, func(t *testing.T) {
+			var args Arguments
+			err := syntax.Unmarshal([]byte(tc.cfg), &args)
+			if tc.errorMsg != "" {
+				require.ErrorContains(t, err, tc.errorMsg)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestConvertRoutes(t *testing.T) {
+	cfg := `
+	endpoint {
+		name    = "primary"
+		url     = "http://0.0.0.0:11111/api/v1/write"
+		headers = { "X-Custom" = "value" }
+	}
+	endpoint {
+		name = "secondary"
+		url  = "http://0.0.0.0:22222/api/v1/write"
+	}
+	endpoint {
+		name = "archive"
+		url  = "http://0.0.0.0:33333/api/v1/write"
+	}
+	route "team_a" {
+		endpoints         = ["primary", "secondary"]
+		selector          = "{job!=\"debug\"}"
+		tenant_label      = "tenant"
+		tenant            = "team-a"
+		drop_tenant_label = true
+	}`
+
+	var args Arguments
+	require.NoError(t, syntax.Unmarshal([]byte(cfg), &args))
+
+	configs, err := convertRoutes(args)
+	require.NoError(t, err)
+	require.Len(t, configs, 2)
+
+	unrouted := configs[""]
+	require.Len(t, unrouted.RemoteWriteConfigs, 1)
+	require.Equal(t, "archive", unrouted.RemoteWriteConfigs[0].Name)
+	require.Empty(t, unrouted.RemoteWriteConfigs[0].WriteRelabelConfigs)
+
+	routed := configs["team_a"]
+	require.Len(t, routed.RemoteWriteConfigs, 2)
+	for _, rwc := range routed.RemoteWriteConfigs {
+		require.Equal(t, "team-a", rwc.Headers["X-Scope-OrgID"])
+
+		process := func(lbls labels.Labels) labels.Labels {
+			res, keep := relabel.Process(lbls, rwc.WriteRelabelConfigs...)
+			if !keep {
+				return labels.EmptyLabels()
+			}
+			return res
+		}
+		require.Equal(t, labels.FromStrings("job", "app"), process(labels.FromStrings("job", "app", "tenant", "team-a")))
+		require.True(t, process(labels.FromStrings("job", "debug", "tenant", "team-a")).IsEmpty())
+		require.True(t, process(labels.FromStrings("job", "app", "tenant", "team-b")).IsEmpty())
+		require.True(t, process(labels.FromStrings("job", "app")).IsEmpty())
+	}
+
+	// The endpoint's own headers must not be modified by the route.
+	require.Equal(t, map[string]string{"X-Custom": "value"}, args.Endpoints[0].Headers)
+}
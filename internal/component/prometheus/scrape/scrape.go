@@ -435,6 +435,7 @@ func (c *Component) distributeTargets(
 		newDistTargets        = discovery.NewDistributedTargets(args.Clustering.Enabled, c.cluster, targets)
 		oldDistributedTargets *discovery.DistributedTargets
 	)
+	newDistTargets.ReportLoad(c.opts.ID)
 
 	c.dtMutex.Lock()
 	oldDistributedTargets, c.distributedTargets = c.distributedTargets, newDistTargets
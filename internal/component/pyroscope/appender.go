@@ -2,10 +2,12 @@ package pyroscope
 
 import (
 	"context"
+	"fmt"
 	"net/url"
 	"sync"
 	"time"
 
+	"github.com/grafana/alloy/internal/service/livedebugging"
 	"github.com/hashicorp/go-multierror"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/prometheus/model/labels"
@@ -55,20 +57,38 @@ type Fanout struct {
 	// ComponentID is what component this belongs to.
 	componentID  string
 	writeLatency prometheus.Histogram
+	// debugDataPublisher receives the profiles sent to the children when live
+	// debugging is active. It may be nil.
+	debugDataPublisher livedebugging.DebugDataPublisher
+}
+
+// FanoutOption configures optional behaviour of a Fanout.
+type FanoutOption func(*Fanout)
+
+// WithDebugDataPublisher publishes every profile going through the fanout to
+// live debugging on behalf of the owning component.
+func WithDebugDataPublisher(publisher livedebugging.DebugDataPublisher) FanoutOption {
+	return func(f *Fanout) {
+		f.debugDataPublisher = publisher
+	}
 }
 
 // NewFanout creates a fanout appendable.
-func NewFanout(children []Appendable, componentID string, register prometheus.Registerer) *Fanout {
+func NewFanout(children []Appendable, componentID string, register prometheus.Registerer, opts ...FanoutOption) *Fanout {
 	wl := prometheus.NewHistogram(prometheus.HistogramOpts{
 		Name: "pyroscope_fanout_latency",
 		Help: "Write latency for sending to pyroscope profiles",
 	})
 	_ = register.Register(wl)
-	return &Fanout{
+	f := &Fanout{
 		children:     children,
 		componentID:  componentID,
 		writeLatency: wl,
 	}
+	for _, opt := range opts {
+		opt(f)
+	}
+	return f
 }
 
 // UpdateChildren allows changing of the children of the fanout.
@@ -91,9 +111,10 @@ func (f *Fanout) Appender() Appender {
 	defer f.mut.RUnlock()
 
 	app := &appender{
-		children:     make([]Appender, 0),
-		componentID:  f.componentID,
-		writeLatency: f.writeLatency,
+		children:           make([]Appender, 0),
+		componentID:        f.componentID,
+		writeLatency:       f.writeLatency,
+		debugDataPublisher: f.debugDataPublisher,
 	}
 	for _, x := range f.children {
 		if x == nil {
@@ -111,9 +132,22 @@ func (f *Fanout) String() string {
 var _ Appender = (*appender)(nil)
 
 type appender struct {
-	children     []Appender
-	componentID  string
-	writeLatency prometheus.Histogram
+	children           []Appender
+	componentID        string
+	writeLatency       prometheus.Histogram
+	debugDataPublisher livedebugging.DebugDataPublisher
+}
+
+func (a *appender) publish(count uint64, dataFunc func() string) {
+	if a.debugDataPublisher == nil {
+		return
+	}
+	a.debugDataPublisher.PublishIfActive(livedebugging.NewData(
+		livedebugging.ComponentID(a.componentID),
+		livedebugging.PyroscopeProfile,
+		count,
+		dataFunc,
+	))
 }
 
 // Append satisfies the Appender interface.
@@ -122,6 +156,9 @@ func (a *appender) Append(ctx context.Context, labels labels.Labels, samples []*
 	defer func() {
 		a.writeLatency.Observe(time.Since(now).Seconds())
 	}()
+	a.publish(uint64(len(samples)), func() string {
+		return fmt.Sprintf("profile: labels=%s, samples=%d, size=%d bytes", labels, len(samples), rawSamplesSize(samples))
+	})
 	var multiErr error
 	for _, x := range a.children {
 		err := x.Append(ctx, labels, samples)
@@ -138,6 +175,9 @@ func (a *appender) AppendIngest(ctx context.Context, profile *IncomingProfile) e
 	defer func() {
 		a.writeLatency.Observe(time.Since(now).Seconds())
 	}()
+	a.publish(1, func() string {
+		return fmt.Sprintf("profile: labels=%s, content_type=%v, size=%d bytes", profile.Labels, profile.ContentType, len(profile.RawBody))
+	})
 	var multiErr error
 	for _, x := range a.children {
 		// Create a copy for each child
@@ -156,6 +196,14 @@ func (a *appender) AppendIngest(ctx context.Context, profile *IncomingProfile) e
 	return multiErr
 }
 
+func rawSamplesSize(samples []*RawSample) int {
+	size := 0
+	for _, s := range samples {
+		size += len(s.RawProfile)
+	}
+	return size
+}
+
 type AppendableFunc func(ctx context.Context, labels labels.Labels, samples []*RawSample) error
 
 func (f AppendableFunc) Appender() Appender {
@@ -5,6 +5,9 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/grafana/alloy/internal/component"
+	"github.com/grafana/alloy/internal/service/livedebugging"
+	"github.com/grafana/alloy/internal/util/testlivedebugging"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/prometheus/model/labels"
 	"github.com/stretchr/testify/require"
@@ -100,3 +103,34 @@ func Test_FanOut_AppendIngest(t *testing.T) {
 	require.Error(t, f.Appender().AppendIngest(t.Context(), profile))
 	require.Equal(t, int32(2), totalAppend.Load())
 }
+
+func Test_FanOut_LiveDebugging(t *testing.T) {
+	const componentID = "pyroscope.relabel.test"
+	ld := livedebugging.NewLiveDebugging()
+	ld.SetEnabled(true)
+	host := &testlivedebugging.FakeServiceHost{
+		ComponentsInfo: map[component.ID]testlivedebugging.FakeInfo{
+			component.ParseID(componentID): {ComponentName: "pyroscope.relabel", Component: &testlivedebugging.FakeComponentLiveDebugging{}},
+		},
+	}
+	log := testlivedebugging.NewLog()
+	require.NoError(t, ld.AddCallback(host, "callback1", livedebugging.ComponentID(componentID), func(data livedebugging.Data) {
+		require.Equal(t, livedebugging.PyroscopeProfile, data.Type)
+		log.Append(data.DataFunc())
+	}))
+
+	f := NewFanout([]Appendable{NoopAppendable}, componentID, prometheus.NewRegistry(), WithDebugDataPublisher(ld))
+	lbls := labels.New(labels.Label{Name: "service_name", Value: "foo"})
+
+	require.NoError(t, f.Appender().Append(t.Context(), lbls, []*RawSample{{RawProfile: []byte("abc")}}))
+	require.NoError(t, f.Appender().AppendIngest(t.Context(), &IncomingProfile{
+		RawBody:     []byte("test"),
+		ContentType: []string{"application/octet-stream"},
+		Labels:      lbls,
+	}))
+
+	require.Equal(t, []string{
+		`profile: labels={service_name="foo"}, samples=1, size=3 bytes`,
+		`profile: labels={service_name="foo"}, content_type=[application/octet-stream], size=4 bytes`,
+	}, log.Get())
+}
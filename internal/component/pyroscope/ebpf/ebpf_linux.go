@@ -17,6 +17,7 @@ import (
 	"github.com/grafana/alloy/internal/component/pyroscope"
 	"github.com/grafana/alloy/internal/component/pyroscope/ebpf/reporter"
 	"github.com/grafana/alloy/internal/featuregate"
+	"github.com/grafana/alloy/internal/service/livedebugging"
 	"github.com/grafana/pyroscope/lidia"
 	"github.com/oklog/run"
 	"github.com/prometheus/client_golang/prometheus"
@@ -38,7 +39,12 @@ func init() {
 
 		Build: func(opts component.Options, args component.Arguments) (component.Component, error) {
 			arguments := args.(Arguments)
-			return New(opts.Logger, opts.Registerer, opts.ID, arguments)
+			debugDataPublisher, err := opts.GetServiceData(livedebugging.ServiceName)
+			if err != nil {
+				return nil, err
+			}
+			return New(opts.Logger, opts.Registerer, opts.ID, arguments,
+				pyroscope.WithDebugDataPublisher(debugDataPublisher.(livedebugging.DebugDataPublisher)))
 		},
 	})
 	python.NoContinueWithNextUnwinder.Store(true)
@@ -46,7 +52,7 @@ func init() {
 	ebpfmetrics.Start(metricnoop.Meter{})
 }
 
-func New(logger log.Logger, reg prometheus.Registerer, id string, args Arguments) (*Component, error) {
+func New(logger log.Logger, reg prometheus.Registerer, id string, args Arguments, fanoutOpts ...pyroscope.FanoutOption) (*Component, error) {
 	cfg, err := args.Convert()
 	if err != nil {
 		return nil, err
@@ -55,7 +61,7 @@ func New(logger log.Logger, reg prometheus.Registerer, id string, args Arguments
 	discovery := discovery2.NewTargetProducer(args.targetsOptions(dynamicProfilingPolicy))
 	ms := newMetrics(reg)
 
-	appendable := pyroscope.NewFanout(args.ForwardTo, id, reg)
+	appendable := pyroscope.NewFanout(args.ForwardTo, id, reg, fanoutOpts...)
 
 	nfs, err := irsymcache.NewFSCache(irsymcache.TableTableFactory{
 		Options: []lidia.Option{
@@ -202,6 +208,9 @@ func (c *Component) Update(args component.Arguments) error {
 	return nil
 }
 
+// LiveDebugging implements component.LiveDebugging.
+func (c *Component) LiveDebugging() {}
+
 func (c *Component) reportUnhealthy(err error) {
 	_ = level.Error(c.logger).
 		Log("msg", "unhealthy", "err", err)
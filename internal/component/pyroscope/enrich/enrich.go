@@ -11,6 +11,7 @@ import (
 	"github.com/grafana/alloy/internal/component/discovery"
 	"github.com/grafana/alloy/internal/component/pyroscope"
 	"github.com/grafana/alloy/internal/featuregate"
+	"github.com/grafana/alloy/internal/service/livedebugging"
 )
 
 func init() {
@@ -60,12 +61,23 @@ type Component struct {
 	fanout *pyroscope.Fanout
 }
 
+var (
+	_ component.Component     = (*Component)(nil)
+	_ component.LiveDebugging = (*Component)(nil)
+)
+
 func New(opts component.Options, args Arguments) (*Component, error) {
+	debugDataPublisher, err := opts.GetServiceData(livedebugging.ServiceName)
+	if err != nil {
+		return nil, err
+	}
+
 	c := &Component{
 		opts:         opts,
 		args:         args,
 		targetsCache: make(map[string]labels.Labels),
-		fanout:       pyroscope.NewFanout(args.ForwardTo, opts.ID, opts.Registerer),
+		fanout: pyroscope.NewFanout(args.ForwardTo, opts.ID, opts.Registerer,
+			pyroscope.WithDebugDataPublisher(debugDataPublisher.(livedebugging.DebugDataPublisher))),
 	}
 
 	// Initialize the cache with provided targets
@@ -197,6 +209,9 @@ func (c *Component) Update(args component.Arguments) error {
 	return nil
 }
 
+// LiveDebugging implements component.LiveDebugging.
+func (c *Component) LiveDebugging() {}
+
 func (c *Component) Exports() component.Exports {
 	return &c.exports
 }
@@ -18,6 +18,7 @@ import (
 	"github.com/grafana/alloy/internal/component/pyroscope/java/asprof"
 	"github.com/grafana/alloy/internal/featuregate"
 	"github.com/grafana/alloy/internal/runtime/logging/level"
+	"github.com/grafana/alloy/internal/service/livedebugging"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
@@ -32,12 +33,17 @@ func init() {
 		Args:      Arguments{},
 
 		Build: func(opts component.Options, args component.Arguments) (component.Component, error) {
-			return New(opts.Logger, opts.Registerer, opts.ID, args.(Arguments))
+			debugDataPublisher, err := opts.GetServiceData(livedebugging.ServiceName)
+			if err != nil {
+				return nil, err
+			}
+			return New(opts.Logger, opts.Registerer, opts.ID, args.(Arguments),
+				pyroscope.WithDebugDataPublisher(debugDataPublisher.(livedebugging.DebugDataPublisher)))
 		},
 	})
 }
 
-func New(logger log.Logger, reg prometheus.Registerer, id string, a Arguments) (*Component, error) {
+func New(logger log.Logger, reg prometheus.Registerer, id string, a Arguments, fanoutOpts ...pyroscope.FanoutOption) (*Component, error) {
 	if os.Getuid() != 0 {
 		return nil, fmt.Errorf("java profiler: must be run as root")
 	}
@@ -58,7 +64,7 @@ func New(logger log.Logger, reg prometheus.Registerer, id string, a Arguments) (
 		}
 		_ = logger.Log("msg", "using embedded asprof dist")
 	}
-	forwardTo := pyroscope.NewFanout(a.ForwardTo, id, reg)
+	forwardTo := pyroscope.NewFanout(a.ForwardTo, id, reg, fanoutOpts...)
 	c := &Component{
 		logger:      logger,
 		args:        a,
@@ -93,6 +99,7 @@ type debugInfoProfiledTarget struct {
 var (
 	_ component.DebugComponent = (*Component)(nil)
 	_ component.Component      = (*Component)(nil)
+	_ component.LiveDebugging  = (*Component)(nil)
 )
 
 type Component struct {
@@ -135,6 +142,9 @@ func (j *Component) Update(args component.Arguments) error {
 	return nil
 }
 
+// LiveDebugging implements component.LiveDebugging.
+func (j *Component) LiveDebugging() {}
+
 func (j *Component) updateTargets(args Arguments) {
 	j.mutex.Lock()
 	defer j.mutex.Unlock()
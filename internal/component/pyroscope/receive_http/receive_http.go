@@ -24,6 +24,7 @@ import (
 	"github.com/grafana/alloy/internal/component/pyroscope/write"
 	"github.com/grafana/alloy/internal/featuregate"
 	"github.com/grafana/alloy/internal/runtime/logging/level"
+	"github.com/grafana/alloy/internal/service/livedebugging"
 	"github.com/grafana/alloy/internal/util"
 	pushv1 "github.com/grafana/pyroscope/api/gen/proto/go/push/v1"
 	"github.com/grafana/pyroscope/api/gen/proto/go/push/v1/pushv1connect"
@@ -38,7 +39,11 @@ func init() {
 		Args:      Arguments{},
 		Build: func(opts component.Options, args component.Arguments) (component.Component, error) {
 			tracer := opts.Tracer.Tracer("pyroscope.receive_http")
-			return New(opts.Logger, tracer, opts.Registerer, args.(Arguments))
+			debugDataPublisher, err := opts.GetServiceData(livedebugging.ServiceName)
+			if err != nil {
+				return nil, err
+			}
+			return New(opts.Logger, tracer, opts.Registerer, opts.ID, debugDataPublisher.(livedebugging.DebugDataPublisher), args.(Arguments))
 		},
 	})
 }
@@ -64,9 +69,17 @@ type Component struct {
 	mut                sync.Mutex
 	logger             log.Logger
 	tracer             trace.Tracer
+
+	componentID        livedebugging.ComponentID
+	debugDataPublisher livedebugging.DebugDataPublisher
 }
 
-func New(logger log.Logger, tracer trace.Tracer, reg prometheus.Registerer, args Arguments) (*Component, error) {
+var (
+	_ component.Component     = (*Component)(nil)
+	_ component.LiveDebugging = (*Component)(nil)
+)
+
+func New(logger log.Logger, tracer trace.Tracer, reg prometheus.Registerer, componentID string, debugDataPublisher livedebugging.DebugDataPublisher, args Arguments) (*Component, error) {
 	uncheckedCollector := util.NewUncheckedCollector(nil)
 	reg.MustRegister(uncheckedCollector)
 
@@ -75,6 +88,8 @@ func New(logger log.Logger, tracer trace.Tracer, reg prometheus.Registerer, args
 		tracer:             tracer,
 		uncheckedCollector: uncheckedCollector,
 		appendables:        args.ForwardTo,
+		componentID:        livedebugging.ComponentID(componentID),
+		debugDataPublisher: debugDataPublisher,
 	}
 
 	if err := c.Update(args); err != nil {
@@ -101,6 +116,9 @@ func (c *Component) Update(args component.Arguments) error {
 	return err
 }
 
+// LiveDebugging implements component.LiveDebugging.
+func (c *Component) LiveDebugging() {}
+
 // returns true if the server was shutdown
 func (c *Component) update(args component.Arguments) (bool, error) {
 	shutdown := false
@@ -172,6 +190,19 @@ func (c *Component) Push(ctx context.Context, req *connect.Request[pushv1.PushRe
 	defer sp.End()
 	l := pyroutil.TraceLog(c.logger, sp)
 
+	for _, series := range req.Msg.Series {
+		c.debugDataPublisher.PublishIfActive(livedebugging.NewData(
+			c.componentID,
+			livedebugging.PyroscopeProfile,
+			uint64(len(series.Samples)),
+			func() string {
+				lb := labels.NewBuilder(labels.EmptyLabels())
+				setLabelBuilderFromAPI(lb, series.Labels)
+				return fmt.Sprintf("profile: labels=%s, samples=%d", ensureServiceName(lb.Labels()), len(series.Samples))
+			},
+		))
+	}
+
 	var wg sync.WaitGroup
 	var errs error
 	var errorMut sync.Mutex
@@ -259,6 +290,15 @@ func (c *Component) handleIngest(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	c.debugDataPublisher.PublishIfActive(livedebugging.NewData(
+		c.componentID,
+		livedebugging.PyroscopeProfile,
+		1,
+		func() string {
+			return fmt.Sprintf("profile: labels=%s, content_type=%v, size=%d bytes", lbls, r.Header.Values(pyroscope.HeaderContentType), buf.Len())
+		},
+	))
+
 	var wg sync.WaitGroup
 	var errs error
 	var errorMut sync.Mutex
@@ -22,6 +22,7 @@ import (
 
 	fnet "github.com/grafana/alloy/internal/component/common/net"
 	"github.com/grafana/alloy/internal/component/pyroscope"
+	"github.com/grafana/alloy/internal/service/livedebugging"
 	"github.com/grafana/alloy/internal/util"
 	pushv1 "github.com/grafana/pyroscope/api/gen/proto/go/push/v1"
 	"github.com/grafana/pyroscope/api/gen/proto/go/push/v1/pushv1connect"
@@ -429,6 +430,8 @@ func startComponent(t *testing.T, appendables []pyroscope.Appendable) int {
 		util.TestAlloyLogger(t),
 		noop.Tracer{},
 		prometheus.NewRegistry(),
+		"pyroscope.receive_http.test",
+		livedebugging.NewLiveDebugging(),
 		args,
 	)
 	require.NoError(t, err)
@@ -566,6 +569,8 @@ func TestUpdateArgs(t *testing.T) {
 		util.TestAlloyLogger(t),
 		noop.Tracer{},
 		prometheus.NewRegistry(),
+		"pyroscope.receive_http.test",
+		livedebugging.NewLiveDebugging(),
 		args,
 	)
 	require.NoError(t, err)
@@ -24,6 +24,7 @@ import (
 	"github.com/grafana/alloy/internal/component/pyroscope"
 	"github.com/grafana/alloy/internal/featuregate"
 	"github.com/grafana/alloy/internal/runtime/logging/level"
+	"github.com/grafana/alloy/internal/service/livedebugging"
 
 	lru "github.com/hashicorp/golang-lru/v2"
 	"github.com/prometheus/common/model"
@@ -82,7 +83,8 @@ type Component struct {
 }
 
 var (
-	_ component.Component = (*Component)(nil)
+	_ component.Component     = (*Component)(nil)
+	_ component.LiveDebugging = (*Component)(nil)
 )
 
 // New creates a new pyroscope.relabel component.
@@ -92,6 +94,11 @@ func New(o component.Options, args Arguments) (*Component, error) {
 		return nil, err
 	}
 
+	debugDataPublisher, err := o.GetServiceData(livedebugging.ServiceName)
+	if err != nil {
+		return nil, err
+	}
+
 	c := &Component{
 		opts:         o,
 		metrics:      newMetrics(o.Registerer),
@@ -99,7 +106,8 @@ func New(o component.Options, args Arguments) (*Component, error) {
 		maxCacheSize: args.MaxCacheSize,
 	}
 
-	c.fanout = pyroscope.NewFanout(args.ForwardTo, o.ID, o.Registerer)
+	c.fanout = pyroscope.NewFanout(args.ForwardTo, o.ID, o.Registerer,
+		pyroscope.WithDebugDataPublisher(debugDataPublisher.(livedebugging.DebugDataPublisher)))
 
 	o.OnStateChange(Exports{
 		Receiver: c,
@@ -152,6 +160,9 @@ func (c *Component) Update(args component.Arguments) error {
 	return nil
 }
 
+// LiveDebugging implements component.LiveDebugging.
+func (c *Component) LiveDebugging() {}
+
 func (c *Component) Append(ctx context.Context, lbls labels.Labels, samples []*pyroscope.RawSample) error {
 	if c.exited.Load() {
 		return fmt.Errorf("%s has exited", c.opts.ID)
@@ -3,6 +3,7 @@ package relabel
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strings"
 	"sync"
 	"testing"
@@ -10,6 +11,7 @@ import (
 	"github.com/grafana/alloy/internal/component"
 	alloy_relabel "github.com/grafana/alloy/internal/component/common/relabel"
 	"github.com/grafana/alloy/internal/component/pyroscope"
+	"github.com/grafana/alloy/internal/service/livedebugging"
 	"github.com/grafana/alloy/internal/util"
 	"github.com/grafana/pyroscope/api/model/labelset"
 	"github.com/grafana/regexp"
@@ -173,9 +175,10 @@ func TestRelabeling(t *testing.T) {
 			app := NewTestAppender()
 
 			c, err := New(component.Options{
-				Logger:        util.TestLogger(t),
-				Registerer:    prometheus.NewRegistry(),
-				OnStateChange: func(e component.Exports) {},
+				Logger:         util.TestLogger(t),
+				Registerer:     prometheus.NewRegistry(),
+				OnStateChange:  func(e component.Exports) {},
+				GetServiceData: getServiceData,
 			}, Arguments{
 				ForwardTo:      []pyroscope.Appendable{app},
 				RelabelConfigs: tt.rules,
@@ -210,9 +213,10 @@ func TestRelabeling(t *testing.T) {
 func TestCache(t *testing.T) {
 	app := NewTestAppender()
 	c, err := New(component.Options{
-		Logger:        util.TestLogger(t),
-		Registerer:    prometheus.NewRegistry(),
-		OnStateChange: func(e component.Exports) {},
+		Logger:         util.TestLogger(t),
+		Registerer:     prometheus.NewRegistry(),
+		OnStateChange:  func(e component.Exports) {},
+		GetServiceData: getServiceData,
 	}, Arguments{
 		ForwardTo: []pyroscope.Appendable{app},
 		RelabelConfigs: []*alloy_relabel.Config{{
@@ -241,9 +245,10 @@ func TestCache(t *testing.T) {
 func TestCacheCollisions(t *testing.T) {
 	app := NewTestAppender()
 	c, err := New(component.Options{
-		Logger:        util.TestLogger(t),
-		Registerer:    prometheus.NewRegistry(),
-		OnStateChange: func(e component.Exports) {},
+		Logger:         util.TestLogger(t),
+		Registerer:     prometheus.NewRegistry(),
+		OnStateChange:  func(e component.Exports) {},
+		GetServiceData: getServiceData,
 	}, Arguments{
 		ForwardTo:      []pyroscope.Appendable{app},
 		RelabelConfigs: []*alloy_relabel.Config{},
@@ -279,9 +284,10 @@ func TestCacheCollisions(t *testing.T) {
 func TestCacheLRU(t *testing.T) {
 	app := NewTestAppender()
 	c, err := New(component.Options{
-		Logger:        util.TestLogger(t),
-		Registerer:    prometheus.NewRegistry(),
-		OnStateChange: func(e component.Exports) {},
+		Logger:         util.TestLogger(t),
+		Registerer:     prometheus.NewRegistry(),
+		OnStateChange:  func(e component.Exports) {},
+		GetServiceData: getServiceData,
 	}, Arguments{
 		ForwardTo:      []pyroscope.Appendable{app},
 		RelabelConfigs: []*alloy_relabel.Config{},
@@ -312,9 +318,10 @@ func TestCacheLRU(t *testing.T) {
 func TestCachePurge(t *testing.T) {
 	app := NewTestAppender()
 	c, err := New(component.Options{
-		Logger:        util.TestLogger(t),
-		Registerer:    prometheus.NewRegistry(),
-		OnStateChange: func(e component.Exports) {},
+		Logger:         util.TestLogger(t),
+		Registerer:     prometheus.NewRegistry(),
+		OnStateChange:  func(e component.Exports) {},
+		GetServiceData: getServiceData,
 	}, Arguments{
 		ForwardTo: []pyroscope.Appendable{app},
 		RelabelConfigs: []*alloy_relabel.Config{{
@@ -360,9 +367,10 @@ func TestMetricsWithRelabeling(t *testing.T) {
 
 	// Create component with relabel rules that will trigger different metrics
 	c, err := New(component.Options{
-		Logger:        util.TestLogger(t),
-		Registerer:    reg,
-		OnStateChange: func(e component.Exports) {},
+		Logger:         util.TestLogger(t),
+		Registerer:     reg,
+		OnStateChange:  func(e component.Exports) {},
+		GetServiceData: getServiceData,
 	}, Arguments{
 		ForwardTo: []pyroscope.Appendable{app},
 		RelabelConfigs: []*alloy_relabel.Config{{
@@ -476,3 +484,12 @@ func (t *TestAppender) Profiles() []*pyroscope.IncomingProfile {
 	defer t.mu.Unlock()
 	return t.profiles
 }
+
+func getServiceData(name string) (interface{}, error) {
+	switch name {
+	case livedebugging.ServiceName:
+		return livedebugging.NewLiveDebugging(), nil
+	default:
+		return nil, fmt.Errorf("service not found %s", name)
+	}
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/grafana/alloy/internal/runtime/logging/level"
 	"github.com/grafana/alloy/internal/service/cluster"
 	"github.com/grafana/alloy/internal/service/http"
+	"github.com/grafana/alloy/internal/service/livedebugging"
 
 	"github.com/grafana/alloy/internal/component"
 	component_config "github.com/grafana/alloy/internal/component/common/config"
@@ -252,7 +253,10 @@ type Component struct {
 	appendable *pyroscope.Fanout
 }
 
-var _ component.Component = (*Component)(nil)
+var (
+	_ component.Component     = (*Component)(nil)
+	_ component.LiveDebugging = (*Component)(nil)
+)
 
 // New creates a new pprof.scrape component.
 func New(o component.Options, args Arguments) (*Component, error) {
@@ -268,7 +272,13 @@ func New(o component.Options, args Arguments) (*Component, error) {
 	}
 	clusterData := data.(cluster.Cluster)
 
-	alloyAppendable := pyroscope.NewFanout(args.ForwardTo, o.ID, o.Registerer)
+	debugDataPublisher, err := o.GetServiceData(livedebugging.ServiceName)
+	if err != nil {
+		return nil, err
+	}
+
+	alloyAppendable := pyroscope.NewFanout(args.ForwardTo, o.ID, o.Registerer,
+		pyroscope.WithDebugDataPublisher(debugDataPublisher.(livedebugging.DebugDataPublisher)))
 	scrapeHttpOptions := Options{
 		HTTPClientOptions: []config_util.HTTPClientOption{
 			config_util.WithDialContextFunc(httpData.DialFunc),
@@ -322,6 +332,7 @@ func (c *Component) Run(ctx context.Context) error {
 			c.mut.RUnlock()
 
 			ct := discovery.NewDistributedTargets(clusteringEnabled, c.cluster, tgs)
+			ct.ReportLoad(c.opts.ID)
 			promTargets := discovery.ComponentTargetsToPromTargetGroupsForSingleJob(jobName, ct.LocalTargets())
 
 			select {
@@ -358,6 +369,9 @@ func (c *Component) Update(args component.Arguments) error {
 	return nil
 }
 
+// LiveDebugging implements component.LiveDebugging.
+func (c *Component) LiveDebugging() {}
+
 // NotifyClusterChange implements component.ClusterComponent.
 func (c *Component) NotifyClusterChange() {
 	c.mut.RLock()
@@ -22,6 +22,7 @@ import (
 	"github.com/grafana/alloy/internal/component/pyroscope"
 	"github.com/grafana/alloy/internal/service/cluster"
 	http_service "github.com/grafana/alloy/internal/service/http"
+	"github.com/grafana/alloy/internal/service/livedebugging"
 	"github.com/grafana/alloy/internal/util"
 	"github.com/grafana/alloy/syntax"
 )
@@ -72,6 +73,8 @@ func getServiceData(name string) (interface{}, error) {
 	switch name {
 	case cluster.ServiceName:
 		return cluster.Mock(), nil
+	case livedebugging.ServiceName:
+		return livedebugging.NewLiveDebugging(), nil
 	case http_service.ServiceName:
 		return http_service.Data{
 			HTTPListenAddr:   "localhost:12345",
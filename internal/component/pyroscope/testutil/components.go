@@ -8,6 +8,7 @@ import (
 	"github.com/go-kit/log"
 	"github.com/grafana/alloy/internal/component/pyroscope"
 	"github.com/grafana/alloy/internal/component/pyroscope/write"
+	"github.com/grafana/alloy/internal/service/livedebugging"
 	"github.com/prometheus/client_golang/prometheus"
 	"go.opentelemetry.io/otel/trace/noop"
 )
@@ -27,6 +28,8 @@ func CreateWriteComponent(l log.Logger, reg prometheus.Registerer, endpoint stri
 		},
 		"test",
 		"",
+		"pyroscope.write",
+		livedebugging.NewLiveDebugging(),
 		write.Arguments{Endpoints: []*write.EndpointOptions{&e}},
 	)
 	if err != nil {
@@ -6,6 +6,7 @@ import (
 	"github.com/grafana/alloy/internal/component/pyroscope/util/glue"
 	"github.com/grafana/alloy/internal/component/pyroscope/write"
 	"github.com/grafana/alloy/internal/featuregate"
+	"github.com/grafana/alloy/internal/service/livedebugging"
 	"github.com/grafana/alloy/internal/useragent"
 )
 
@@ -21,6 +22,11 @@ func init() {
 			userAgent := useragent.Get()
 			uid := alloyseed.Get().UID
 
+			debugDataPublisher, err := o.GetServiceData(livedebugging.ServiceName)
+			if err != nil {
+				return nil, err
+			}
+
 			gc, err := write.New(
 				o.Logger,
 				tracer,
@@ -30,12 +36,26 @@ func init() {
 				},
 				userAgent,
 				uid,
+				o.ID,
+				debugDataPublisher.(livedebugging.DebugDataPublisher),
 				args,
 			)
 			if err != nil {
 				return nil, err
 			}
-			return &glue.GenericComponentGlue[write.Arguments]{Impl: gc}, nil
+			return &liveDebuggingGlue{
+				GenericComponentGlue: &glue.GenericComponentGlue[write.Arguments]{Impl: gc},
+			}, nil
 		},
 	})
 }
+
+// liveDebuggingGlue marks pyroscope.write as supporting live debugging.
+type liveDebuggingGlue struct {
+	*glue.GenericComponentGlue[write.Arguments]
+}
+
+var _ component.LiveDebugging = (*liveDebuggingGlue)(nil)
+
+// LiveDebugging implements component.LiveDebugging.
+func (g *liveDebuggingGlue) LiveDebugging() {}
@@ -19,6 +19,7 @@ import (
 	"github.com/grafana/alloy/internal/component/common/config"
 	"github.com/grafana/alloy/internal/component/pyroscope"
 	"github.com/grafana/alloy/internal/component/pyroscope/util"
+	"github.com/grafana/alloy/internal/service/livedebugging"
 	"github.com/grafana/dskit/backoff"
 	pushv1 "github.com/grafana/pyroscope/api/gen/proto/go/push/v1"
 	"github.com/grafana/pyroscope/api/gen/proto/go/push/v1/pushv1connect"
@@ -112,6 +113,9 @@ type Component struct {
 	metrics       *metrics
 	userAgent     string
 	uid           string
+
+	componentID        livedebugging.ComponentID
+	debugDataPublisher livedebugging.DebugDataPublisher
 }
 
 // Exports are the set of fields exposed by the pyroscope.write component.
@@ -126,26 +130,30 @@ func New(
 	reg prometheus.Registerer,
 	onStateChange func(Exports),
 	userAgent, uid string,
+	componentID string,
+	debugDataPublisher livedebugging.DebugDataPublisher,
 	c Arguments,
 ) (*Component, error) {
 
-	m := newMetrics(reg)
-	receiver, err := newFanOut(logger, tracer, c, m, userAgent, uid)
+	comp := &Component{
+		cfg:                c,
+		logger:             logger,
+		tracer:             tracer,
+		onStateChange:      onStateChange,
+		metrics:            newMetrics(reg),
+		userAgent:          userAgent,
+		uid:                uid,
+		componentID:        livedebugging.ComponentID(componentID),
+		debugDataPublisher: debugDataPublisher,
+	}
+	receiver, err := comp.newFanOut(c)
 	if err != nil {
 		return nil, err
 	}
 	// Immediately export the receiver
 	onStateChange(Exports{Receiver: receiver})
 
-	return &Component{
-		cfg:           c,
-		logger:        logger,
-		tracer:        tracer,
-		onStateChange: onStateChange,
-		metrics:       m,
-		userAgent:     userAgent,
-		uid:           uid,
-	}, nil
+	return comp, nil
 }
 
 // Run implements Component.
@@ -157,7 +165,7 @@ func (c *Component) Run(ctx context.Context) error {
 // Update implements Component.
 func (c *Component) Update(newConfig Arguments) error {
 	c.cfg = newConfig
-	receiver, err := newFanOut(c.logger, c.tracer, newConfig, c.metrics, c.userAgent, c.uid)
+	receiver, err := c.newFanOut(newConfig)
 	if err != nil {
 		return err
 	}
@@ -173,10 +181,13 @@ type fanOutClient struct {
 	metrics       *metrics
 	tracer        trace.Tracer
 	logger        log.Logger
+
+	componentID        livedebugging.ComponentID
+	debugDataPublisher livedebugging.DebugDataPublisher
 }
 
 // newFanOut creates a new fan out client that will fan out to all endpoints.
-func newFanOut(logger log.Logger, tracer trace.Tracer, config Arguments, metrics *metrics, userAgent string, uid string) (*fanOutClient, error) {
+func (c *Component) newFanOut(config Arguments) (*fanOutClient, error) {
 	pushClients := make([]pushv1connect.PusherServiceClient, 0, len(config.Endpoints))
 	ingestClients := make(map[*EndpointOptions]*http.Client)
 
@@ -184,7 +195,7 @@ func newFanOut(logger log.Logger, tracer trace.Tracer, config Arguments, metrics
 		if endpoint.Headers == nil {
 			endpoint.Headers = map[string]string{}
 		}
-		endpoint.Headers["X-Alloy-Id"] = uid
+		endpoint.Headers["X-Alloy-Id"] = c.uid
 		httpClient, err := commonconfig.NewClientFromConfig(*endpoint.HTTPClientConfig.Convert(), endpoint.Name)
 		if err != nil {
 			return nil, err
@@ -193,17 +204,19 @@ func newFanOut(logger log.Logger, tracer trace.Tracer, config Arguments, metrics
 
 		pushClients = append(
 			pushClients,
-			pushv1connect.NewPusherServiceClient(httpClient, endpoint.URL, WithUserAgent(userAgent)),
+			pushv1connect.NewPusherServiceClient(httpClient, endpoint.URL, WithUserAgent(c.userAgent)),
 		)
 		ingestClients[endpoint] = httpClient
 	}
 	return &fanOutClient{
-		logger:        logger,
-		tracer:        tracer,
-		pushClients:   pushClients,
-		ingestClients: ingestClients,
-		config:        config,
-		metrics:       metrics,
+		logger:             c.logger,
+		tracer:             c.tracer,
+		pushClients:        pushClients,
+		ingestClients:      ingestClients,
+		config:             config,
+		metrics:            c.metrics,
+		componentID:        c.componentID,
+		debugDataPublisher: c.debugDataPublisher,
 	}, nil
 }
 
@@ -300,6 +313,7 @@ func (f *fanOutClient) Push(
 				err = fmt.Errorf("failed to push to endpoint %s (%d retries): %w", f.config.Endpoints[i].URL, backoff.NumRetries(), err)
 				util.ErrorsJoinConcurrent(&errs, err, &errorMut)
 			}
+			f.publishOutcome(f.config.Endpoints[i].URL, profileCount, backoff.NumRetries(), err)
 		}()
 	}
 
@@ -351,6 +365,15 @@ func (f *fanOutClient) Appender() pyroscope.Appender {
 
 // Append implements the Appender interface.
 func (f *fanOutClient) Append(ctx context.Context, lbs labels.Labels, samples []*pyroscope.RawSample) error {
+	f.debugDataPublisher.PublishIfActive(livedebugging.NewData(
+		f.componentID,
+		livedebugging.PyroscopeProfile,
+		uint64(len(samples)),
+		func() string {
+			return fmt.Sprintf("profile: labels=%s, samples=%d", lbs, len(samples))
+		},
+	))
+
 	// Validate labels first
 	if err := validateLabels(lbs); err != nil {
 		return fmt.Errorf("invalid labels in profile: %w", err)
@@ -421,6 +444,15 @@ func (e *PyroscopeWriteError) readBody(resp *http.Response) {
 func (f *fanOutClient) AppendIngest(ctx context.Context, profile *pyroscope.IncomingProfile) error {
 	defer f.observeLatency("-", "ingest_total")()
 
+	f.debugDataPublisher.PublishIfActive(livedebugging.NewData(
+		f.componentID,
+		livedebugging.PyroscopeProfile,
+		1,
+		func() string {
+			return fmt.Sprintf("profile: labels=%s, content_type=%v, size=%d bytes", profile.Labels, profile.ContentType, len(profile.RawBody))
+		},
+	))
+
 	ctx, sp := f.tracer.Start(ctx, "AppendIngest")
 	defer sp.End()
 
@@ -568,6 +600,7 @@ func (f *fanOutClient) AppendIngest(ctx context.Context, profile *pyroscope.Inco
 				err = fmt.Errorf("failed to ingest to endpoint %s (%d retries): %w", f.config.Endpoints[i].URL, backoff.NumRetries(), err)
 				util.ErrorsJoinConcurrent(&errs, err, &errorMut)
 			}
+			f.publishOutcome(f.config.Endpoints[i].URL, profileCount, backoff.NumRetries(), err)
 		}()
 	}
 
@@ -576,6 +609,28 @@ func (f *fanOutClient) AppendIngest(ctx context.Context, profile *pyroscope.Inco
 	return errs
 }
 
+// publishOutcome publishes the result of sending count profiles to endpoint.
+// err is the last error returned by the endpoint, or nil if the profiles were
+// delivered.
+func (f *fanOutClient) publishOutcome(endpoint string, count int64, retries int, err error) {
+	outcome := livedebugging.Outcome{
+		Result:     livedebugging.OutcomeSent,
+		Endpoint:   endpoint,
+		StatusCode: http.StatusOK,
+		Retries:    retries,
+	}
+	if err != nil {
+		outcome.Result = livedebugging.OutcomeDropped
+		outcome.StatusCode = 0
+		outcome.Err = err
+		var writeErr *PyroscopeWriteError
+		if errors.As(err, &writeErr) {
+			outcome.StatusCode = writeErr.StatusCode
+		}
+	}
+	f.debugDataPublisher.PublishIfActive(livedebugging.NewOutcomeData(f.componentID, uint64(count), outcome))
+}
+
 func (f *fanOutClient) observeLatency(endpoint, latencyType string) func() {
 	t := time.Now()
 	return func() {
@@ -15,6 +15,7 @@ import (
 	"connectrpc.com/connect"
 	"github.com/grafana/alloy/internal/component/pyroscope"
 	pyrotestlogger "github.com/grafana/alloy/internal/component/pyroscope/util/testlog"
+	"github.com/grafana/alloy/internal/service/livedebugging"
 	"github.com/grafana/alloy/syntax"
 	pushv1 "github.com/grafana/pyroscope/api/gen/proto/go/push/v1"
 	"github.com/grafana/pyroscope/api/gen/proto/go/push/v1/pushv1connect"
@@ -100,6 +101,8 @@ func Test_Write_FanOut(t *testing.T) {
 			},
 			"Alloy/239",
 			"",
+			"pyroscope.write.test",
+			livedebugging.NewLiveDebugging(),
 			arg,
 		)
 		require.NoError(t, err)
@@ -179,6 +182,8 @@ func Test_Write_Update(t *testing.T) {
 		},
 		"Alloy/239",
 		"",
+		"pyroscope.write.test",
+		livedebugging.NewLiveDebugging(),
 		argument,
 	)
 	require.NoError(t, err)
@@ -320,6 +325,8 @@ func (s *AppendIngestTestSuite) newComponent(argument Arguments) {
 		},
 		"Alloy/239",
 		"",
+		"pyroscope.write.test",
+		livedebugging.NewLiveDebugging(),
 		argument,
 	)
 	s.Require().NoError(err)
@@ -603,6 +610,8 @@ func Test_Write_FanOut_ValidateLabels(t *testing.T) {
 		},
 		"Alloy/239",
 		"",
+		"pyroscope.write.test",
+		livedebugging.NewLiveDebugging(),
 		argument,
 	)
 	require.NoError(t, err)
@@ -0,0 +1,360 @@
+// Package consul_kv implements the remote.consul_kv component.
+package consul_kv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/grafana/dskit/backoff"
+	consul "github.com/hashicorp/consul/api"
+	prom_config "github.com/prometheus/common/config"
+
+	"github.com/grafana/alloy/internal/component"
+	"github.com/grafana/alloy/internal/component/common/config"
+	"github.com/grafana/alloy/internal/featuregate"
+	"github.com/grafana/alloy/internal/runtime/logging/level"
+	"github.com/grafana/alloy/syntax/alloytypes"
+)
+
+func init() {
+	component.Register(component.Registration{
+		Name:      "remote.consul_kv",
+		Stability: featuregate.StabilityExperimental,
+		Args:      Arguments{},
+		Exports:   Exports{},
+		Build: func(opts component.Options, args component.Arguments) (component.Component, error) {
+			return New(opts, args.(Arguments))
+		},
+	})
+}
+
+// Arguments control the remote.consul_kv component.
+type Arguments struct {
+	Server     string            `alloy:"server,attr,optional"`
+	Scheme     string            `alloy:"scheme,attr,optional"`
+	Datacenter string            `alloy:"datacenter,attr,optional"`
+	Namespace  string            `alloy:"namespace,attr,optional"`
+	Partition  string            `alloy:"partition,attr,optional"`
+	Token      alloytypes.Secret `alloy:"token,attr,optional"`
+	AllowStale bool              `alloy:"allow_stale,attr,optional"`
+
+	Key            string        `alloy:"key,attr,optional"`
+	Prefix         string        `alloy:"prefix,attr,optional"`
+	IsSecret       bool          `alloy:"is_secret,attr,optional"`
+	WaitTime       time.Duration `alloy:"wait_time,attr,optional"`
+	RequestTimeout time.Duration `alloy:"request_timeout,attr,optional"`
+
+	TLSConfig config.TLSConfig `alloy:"tls_config,block,optional"`
+}
+
+// DefaultArguments holds default settings for Arguments.
+var DefaultArguments = Arguments{
+	Server:         "localhost:8500",
+	Scheme:         "http",
+	WaitTime:       5 * time.Minute,
+	RequestTimeout: 10 * time.Second,
+}
+
+// SetToDefault implements syntax.Defaulter.
+func (args *Arguments) SetToDefault() {
+	*args = DefaultArguments
+}
+
+// Validate implements syntax.Validator.
+func (args *Arguments) Validate() error {
+	if (args.Key == "") == (args.Prefix == "") {
+		return fmt.Errorf("exactly one of key or prefix must be set")
+	}
+	if args.WaitTime <= 0 {
+		return fmt.Errorf("wait_time must be greater than 0")
+	}
+	if args.RequestTimeout <= 0 {
+		return fmt.Errorf("request_timeout must be greater than 0")
+	}
+	if args.Scheme != "http" && args.Scheme != "https" {
+		return fmt.Errorf("scheme must be http or https, got %q", args.Scheme)
+	}
+	return args.TLSConfig.Validate()
+}
+
+// client creates a Consul client from the arguments.
+func (args *Arguments) client() (*consul.Client, error) {
+	tlsConfig, err := prom_config.NewTLSConfig(args.TLSConfig.Convert())
+	if err != nil {
+		return nil, err
+	}
+	transport := consul.DefaultConfig().Transport
+	transport.TLSClientConfig = tlsConfig
+
+	return consul.NewClient(&consul.Config{
+		Address:    args.Server,
+		Scheme:     args.Scheme,
+		Datacenter: args.Datacenter,
+		Namespace:  args.Namespace,
+		Partition:  args.Partition,
+		Token:      string(args.Token),
+		HttpClient: &http.Client{Transport: transport},
+	})
+}
+
+// Exports holds settings exported by remote.consul_kv.
+type Exports struct {
+	// Value is the value of the key when key is set.
+	Value alloytypes.OptionalSecret `alloy:"value,attr"`
+
+	// Data holds the keys under the prefix when prefix is set. Keys are
+	// relative to the prefix.
+	Data map[string]alloytypes.OptionalSecret `alloy:"data,attr"`
+}
+
+// Component implements the remote.consul_kv component.
+type Component struct {
+	log  log.Logger
+	opts component.Options
+
+	mut         sync.Mutex
+	args        Arguments
+	kv          *consul.KV
+	index       uint64  // Index of the last response, used for blocking queries.
+	generation  int     // Incremented by Update to discard the results of in-flight queries.
+	lastExports Exports // Used for determining whether exports should be updated
+
+	// Updated is written to whenever args updates.
+	updated     chan struct{}
+	cancelQuery context.CancelFunc
+
+	healthMut sync.RWMutex
+	health    component.Health
+}
+
+var (
+	_ component.Component       = (*Component)(nil)
+	_ component.HealthComponent = (*Component)(nil)
+)
+
+// New returns a new, unstarted, remote.consul_kv component.
+func New(opts component.Options, args Arguments) (*Component, error) {
+	c := &Component{
+		log:  opts.Logger,
+		opts: opts,
+
+		updated: make(chan struct{}, 1),
+
+		health: component.Health{
+			Health:     component.HealthTypeUnknown,
+			Message:    "component started",
+			UpdateTime: time.Now(),
+		},
+	}
+
+	if err := c.Update(args); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Run starts the remote.consul_kv component. It watches the key or prefix
+// with blocking queries, and retries with a backoff when queries fail.
+func (c *Component) Run(ctx context.Context) error {
+	bo := backoff.New(ctx, backoff.Config{
+		MinBackoff: time.Second,
+		MaxBackoff: time.Minute,
+	})
+
+	for {
+		c.mut.Lock()
+		queryCtx, cancel := context.WithTimeout(ctx, c.args.blockingQueryTimeout())
+		c.cancelQuery = cancel
+		c.mut.Unlock()
+
+		err := c.query(queryCtx, true)
+		cancel()
+
+		switch {
+		case ctx.Err() != nil:
+			return nil
+		case errors.Is(err, context.Canceled):
+			// The query was interrupted by Update; start over with the new
+			// arguments.
+			continue
+		case err != nil:
+			level.Error(c.log).Log("msg", "failed to query consul", "err", err)
+			c.updateHealth(err)
+		default:
+			c.updateHealth(nil)
+			bo.Reset()
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(bo.NextDelay()):
+		case <-c.updated:
+		}
+	}
+}
+
+// query reads the key or prefix and updates the exports if they changed. If
+// blocking is true, query waits until the key or prefix changes, or until
+// wait_time elapses. On errors, the exports are left unchanged.
+func (c *Component) query(ctx context.Context, blocking bool) error {
+	c.mut.Lock()
+	var (
+		args       = c.args
+		kv         = c.kv
+		generation = c.generation
+		q          = &consul.QueryOptions{AllowStale: args.AllowStale}
+	)
+	if blocking {
+		q.WaitIndex = c.index
+		q.WaitTime = args.WaitTime
+	}
+	c.mut.Unlock()
+
+	var (
+		data = make(map[string]string)
+		meta *consul.QueryMeta
+		err  error
+	)
+	if args.Key != "" {
+		var pair *consul.KVPair
+		pair, meta, err = kv.Get(args.Key, q.WithContext(ctx))
+		if err == nil && pair == nil {
+			err = fmt.Errorf("key %q not found", args.Key)
+		}
+		if err == nil {
+			data[args.Key] = string(pair.Value)
+		}
+	} else {
+		var pairs consul.KVPairs
+		pairs, meta, err = kv.List(args.Prefix, q.WithContext(ctx))
+		for _, pair := range pairs {
+			data[strings.TrimPrefix(pair.Key, args.Prefix)] = string(pair.Value)
+		}
+	}
+
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	// Discard results of queries started with previous arguments.
+	if generation != c.generation {
+		return context.Canceled
+	}
+	if meta != nil {
+		c.index = nextIndex(c.index, meta.LastIndex)
+	}
+	if err != nil {
+		return err
+	}
+
+	newExports := Exports{Data: make(map[string]alloytypes.OptionalSecret, len(data))}
+	for k, v := range data {
+		newExports.Data[k] = alloytypes.OptionalSecret{IsSecret: args.IsSecret, Value: v}
+	}
+	if args.Key != "" {
+		newExports.Value = newExports.Data[args.Key]
+		newExports.Data = map[string]alloytypes.OptionalSecret{}
+	}
+
+	// Only send a state change event if the exports have changed from the
+	// previous query.
+	if newExports.Value != c.lastExports.Value || !maps.Equal(newExports.Data, c.lastExports.Data) {
+		c.opts.OnStateChange(newExports)
+	}
+	c.lastExports = newExports
+	return nil
+}
+
+// blockingQueryTimeout returns the maximum duration of a blocking query.
+// Consul adds a jitter of up to wait_time/16 to blocking queries, and
+// request_timeout bounds the time spent connecting and transferring the
+// response.
+func (args *Arguments) blockingQueryTimeout() time.Duration {
+	return args.WaitTime + args.WaitTime/16 + args.RequestTimeout
+}
+
+// nextIndex returns the index to use for the next blocking query, following
+// the recommendations of the Consul documentation: the index is reset when
+// it goes backwards, and is never less than 1.
+func nextIndex(prev, last uint64) uint64 {
+	if last < prev {
+		return 0
+	}
+	return max(last, 1)
+}
+
+func (c *Component) updateHealth(err error) {
+	c.healthMut.Lock()
+	defer c.healthMut.Unlock()
+
+	if err == nil {
+		c.health = component.Health{
+			Health:     component.HealthTypeHealthy,
+			Message:    "read from consul",
+			UpdateTime: time.Now(),
+		}
+	} else {
+		c.health = component.Health{
+			Health:     component.HealthTypeUnhealthy,
+			Message:    fmt.Sprintf("reading from consul failed: %s", err),
+			UpdateTime: time.Now(),
+		}
+	}
+}
+
+// Update updates the remote.consul_kv component. After the update completes,
+// the key or prefix is read without blocking, and the error is returned if
+// the read fails or doesn't complete within request_timeout.
+func (c *Component) Update(args component.Arguments) (err error) {
+	// It's important to propagate the error in update so the initial state of
+	// the component is calculated correctly, otherwise the exports will be empty
+	// and may cause unexpected errors in downstream components.
+	defer func() {
+		if err != nil {
+			return
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), args.(Arguments).RequestTimeout)
+		defer cancel()
+		err = c.query(ctx, false)
+		c.updateHealth(err)
+	}()
+
+	newArgs := args.(Arguments)
+	cli, err := newArgs.client()
+	if err != nil {
+		return err
+	}
+
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	c.args = newArgs
+	c.kv = cli.KV()
+	c.index = 0
+	c.generation++
+	if c.cancelQuery != nil {
+		c.cancelQuery()
+	}
+
+	// Send an updated event if one wasn't already read.
+	select {
+	case c.updated <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// CurrentHealth returns the current health of the component.
+func (c *Component) CurrentHealth() component.Health {
+	c.healthMut.RLock()
+	defer c.healthMut.RUnlock()
+	return c.health
+}
@@ -0,0 +1,305 @@
+package consul_kv_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/alloy/internal/component"
+	"github.com/grafana/alloy/internal/component/remote/consul_kv"
+	"github.com/grafana/alloy/internal/runtime/componenttest"
+	"github.com/grafana/alloy/internal/util"
+	"github.com/grafana/alloy/syntax"
+	"github.com/grafana/alloy/syntax/alloytypes"
+)
+
+func TestKey(t *testing.T) {
+	srv := newFakeConsul(t)
+	srv.Put("config/level", "info")
+	srv.Put("config/other", "ignored")
+
+	ctrl := runComponent(t, fmt.Sprintf(`
+		server    = %q
+		key       = "config/level"
+		is_secret = true
+	`, srv.Addr()))
+
+	requireExports(t, ctrl, consul_kv.Exports{
+		Value: alloytypes.OptionalSecret{IsSecret: true, Value: "info"},
+		Data:  map[string]alloytypes.OptionalSecret{},
+	})
+
+	// Changes are received through blocking queries, not by polling.
+	srv.Put("config/level", "debug")
+	requireExports(t, ctrl, consul_kv.Exports{
+		Value: alloytypes.OptionalSecret{IsSecret: true, Value: "debug"},
+		Data:  map[string]alloytypes.OptionalSecret{},
+	})
+	require.Equal(t, 1, srv.NonBlockingQueries(), "only the initial read should be non-blocking")
+}
+
+func TestPrefix(t *testing.T) {
+	srv := newFakeConsul(t)
+	srv.Put("services/api/port", "8080")
+	srv.Put("other", "ignored")
+
+	ctrl := runComponent(t, fmt.Sprintf(`
+		server = %q
+		prefix = "services/"
+	`, srv.Addr()))
+
+	requireExports(t, ctrl, consul_kv.Exports{
+		Data: map[string]alloytypes.OptionalSecret{
+			"api/port": {Value: "8080"},
+		},
+	})
+
+	srv.Put("services/web/port", "80")
+	requireExports(t, ctrl, consul_kv.Exports{
+		Data: map[string]alloytypes.OptionalSecret{
+			"api/port": {Value: "8080"},
+			"web/port": {Value: "80"},
+		},
+	})
+}
+
+func TestKeepsLastValueOnError(t *testing.T) {
+	srv := newFakeConsul(t)
+	srv.Put("config/level", "info")
+
+	ctrl := runComponent(t, fmt.Sprintf(`
+		server = %q
+		key    = "config/level"
+	`, srv.Addr()))
+	good := consul_kv.Exports{
+		Value: alloytypes.OptionalSecret{Value: "info"},
+		Data:  map[string]alloytypes.OptionalSecret{},
+	}
+	requireExports(t, ctrl, good)
+	requireHealth(t, ctrl, component.HealthTypeHealthy)
+
+	// Deleting the key and failing requests both make the component unhealthy,
+	// but keep the last value.
+	srv.Delete("config/level")
+	requireHealth(t, ctrl, component.HealthTypeUnhealthy)
+	require.Equal(t, good, ctrl.Exports())
+
+	srv.SetFailing(true)
+	srv.Put("config/level", "debug")
+	requireHealth(t, ctrl, component.HealthTypeUnhealthy)
+	require.Equal(t, good, ctrl.Exports())
+
+	srv.SetFailing(false)
+	requireExports(t, ctrl, consul_kv.Exports{
+		Value: alloytypes.OptionalSecret{Value: "debug"},
+		Data:  map[string]alloytypes.OptionalSecret{},
+	})
+	requireHealth(t, ctrl, component.HealthTypeHealthy)
+}
+
+func TestRequestTimeout(t *testing.T) {
+	// The server accepts requests but never answers them.
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	t.Cleanup(srv.Close)
+
+	var args consul_kv.Arguments
+	require.NoError(t, syntax.Unmarshal([]byte(fmt.Sprintf(`
+		server          = %q
+		key             = "config/level"
+		request_timeout = "100ms"
+	`, strings.TrimPrefix(srv.URL, "http://"))), &args))
+
+	errCh := make(chan error, 1)
+	go func() {
+		_, err := consul_kv.New(component.Options{
+			Logger:        util.TestLogger(t),
+			OnStateChange: func(component.Exports) {},
+		}, args)
+		errCh <- err
+	}()
+
+	select {
+	case err := <-errCh:
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "the initial read isn't bounded by request_timeout")
+	}
+}
+
+func TestUnmarshalValidation(t *testing.T) {
+	tests := []struct {
+		name        string
+		cfg         string
+		expectedErr string
+	}{
+		{"Neither key nor prefix", ``, "exactly one of key or prefix must be set"},
+		{"Both key and prefix", `key = "a"
+			prefix = "b/"`, "exactly one of key or prefix must be set"},
+		{"Invalid wait_time", `key = "a"
+			wait_time = "0s"`, "wait_time must be greater than 0"},
+		{"Invalid request_timeout", `key = "a"
+			request_timeout = "0s"`, "request_timeout must be greater than 0"},
+		{"Invalid scheme", `key = "a"
+			scheme = "ftp"`, `scheme must be http or https, got "ftp"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var args consul_kv.Arguments
+			require.EqualError(t, syntax.Unmarshal([]byte(tt.cfg), &args), tt.expectedErr)
+		})
+	}
+}
+
+func runComponent(t *testing.T, cfg string) *componenttest.Controller {
+	t.Helper()
+
+	var args consul_kv.Arguments
+	require.NoError(t, syntax.Unmarshal([]byte(cfg), &args))
+
+	ctrl, err := componenttest.NewControllerFromID(util.TestLogger(t), "remote.consul_kv")
+	require.NoError(t, err)
+	go func() {
+		require.NoError(t, ctrl.Run(componenttest.TestContext(t), args))
+	}()
+	require.NoError(t, ctrl.WaitRunning(time.Second), "component never started")
+	return ctrl
+}
+
+func requireExports(t *testing.T, ctrl *componenttest.Controller, expect consul_kv.Exports) {
+	t.Helper()
+	require.EventuallyWithT(t, func(c *assert.CollectT) {
+		assert.Equal(c, expect, ctrl.Exports())
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func requireHealth(t *testing.T, ctrl *componenttest.Controller, expect component.HealthType) {
+	t.Helper()
+	require.EventuallyWithT(t, func(c *assert.CollectT) {
+		comp, err := ctrl.GetComponent()
+		require.NoError(c, err)
+		assert.Equal(c, expect, comp.(component.HealthComponent).CurrentHealth().Health)
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+// fakeConsul implements the subset of the Consul KV HTTP API used by the
+// component, including blocking queries.
+type fakeConsul struct {
+	srv *httptest.Server
+
+	mut         sync.Mutex
+	index       uint64
+	values      map[string]string
+	changed     chan struct{} // Closed and replaced on every change.
+	failing     bool
+	nonBlocking int
+}
+
+func newFakeConsul(t *testing.T) *fakeConsul {
+	f := &fakeConsul{
+		index:   1,
+		values:  make(map[string]string),
+		changed: make(chan struct{}),
+	}
+	f.srv = httptest.NewServer(http.HandlerFunc(f.serveKV))
+	t.Cleanup(f.srv.Close)
+	return f
+}
+
+func (f *fakeConsul) Addr() string { return strings.TrimPrefix(f.srv.URL, "http://") }
+
+func (f *fakeConsul) Put(key, value string) {
+	f.mut.Lock()
+	defer f.mut.Unlock()
+	f.values[key] = value
+	f.notifyLocked()
+}
+
+func (f *fakeConsul) Delete(key string) {
+	f.mut.Lock()
+	defer f.mut.Unlock()
+	delete(f.values, key)
+	f.notifyLocked()
+}
+
+func (f *fakeConsul) SetFailing(failing bool) {
+	f.mut.Lock()
+	defer f.mut.Unlock()
+	f.failing = failing
+	f.notifyLocked()
+}
+
+func (f *fakeConsul) NonBlockingQueries() int {
+	f.mut.Lock()
+	defer f.mut.Unlock()
+	return f.nonBlocking
+}
+
+func (f *fakeConsul) notifyLocked() {
+	f.index++
+	close(f.changed)
+	f.changed = make(chan struct{})
+}
+
+func (f *fakeConsul) serveKV(w http.ResponseWriter, r *http.Request) {
+	key, ok := strings.CutPrefix(r.URL.Path, "/v1/kv/")
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	query := r.URL.Query()
+
+	f.mut.Lock()
+	if waitIndex, _ := strconv.ParseUint(query.Get("index"), 10, 64); waitIndex == 0 {
+		f.nonBlocking++
+	} else if waitIndex >= f.index {
+		changed := f.changed
+		f.mut.Unlock()
+		select {
+		case <-changed:
+		case <-time.After(time.Second):
+		case <-r.Context().Done():
+			return
+		}
+		f.mut.Lock()
+	}
+	defer f.mut.Unlock()
+
+	if f.failing {
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	type kvPair struct {
+		Key         string
+		Value       []byte
+		ModifyIndex uint64
+	}
+	var pairs []kvPair
+	for k, v := range f.values {
+		if k == key || (query.Has("recurse") && strings.HasPrefix(k, key)) {
+			pairs = append(pairs, kvPair{Key: k, Value: []byte(v), ModifyIndex: f.index})
+		}
+	}
+	sort.Slice(pairs, func(i, j int) bool { return pairs[i].Key < pairs[j].Key })
+
+	w.Header().Set("X-Consul-Index", strconv.FormatUint(f.index, 10))
+	if len(pairs) == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(pairs)
+}
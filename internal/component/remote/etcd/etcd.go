@@ -0,0 +1,399 @@
+// Package etcd implements the remote.etcd component.
+package etcd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/grafana/dskit/backoff"
+	prom_config "github.com/prometheus/common/config"
+	clientv3 "go.etcd.io/etcd/client/v3"
+
+	"github.com/grafana/alloy/internal/component"
+	"github.com/grafana/alloy/internal/component/common/config"
+	"github.com/grafana/alloy/internal/featuregate"
+	"github.com/grafana/alloy/internal/runtime/logging/level"
+	"github.com/grafana/alloy/internal/util/zapadapter"
+	"github.com/grafana/alloy/syntax/alloytypes"
+)
+
+func init() {
+	component.Register(component.Registration{
+		Name:      "remote.etcd",
+		Stability: featuregate.StabilityExperimental,
+		Args:      Arguments{},
+		Exports:   Exports{},
+		Build: func(opts component.Options, args component.Arguments) (component.Component, error) {
+			return New(opts, args.(Arguments))
+		},
+	})
+}
+
+// Arguments control the remote.etcd component.
+type Arguments struct {
+	Endpoints   []string          `alloy:"endpoints,attr"`
+	Username    string            `alloy:"username,attr,optional"`
+	Password    alloytypes.Secret `alloy:"password,attr,optional"`
+	DialTimeout time.Duration     `alloy:"dial_timeout,attr,optional"`
+
+	Key      string `alloy:"key,attr,optional"`
+	Prefix   string `alloy:"prefix,attr,optional"`
+	IsSecret bool   `alloy:"is_secret,attr,optional"`
+
+	TLSConfig *config.TLSConfig `alloy:"tls_config,block,optional"`
+}
+
+// DefaultArguments holds default settings for Arguments.
+var DefaultArguments = Arguments{
+	DialTimeout: 5 * time.Second,
+}
+
+// SetToDefault implements syntax.Defaulter.
+func (args *Arguments) SetToDefault() {
+	*args = DefaultArguments
+}
+
+// Validate implements syntax.Validator.
+func (args *Arguments) Validate() error {
+	if len(args.Endpoints) == 0 {
+		return fmt.Errorf("at least one endpoint must be set")
+	}
+	if (args.Key == "") == (args.Prefix == "") {
+		return fmt.Errorf("exactly one of key or prefix must be set")
+	}
+	if args.DialTimeout <= 0 {
+		return fmt.Errorf("dial_timeout must be greater than 0")
+	}
+	if args.TLSConfig != nil {
+		return args.TLSConfig.Validate()
+	}
+	return nil
+}
+
+// etcdClient is the subset of the etcd client used by the component.
+type etcdClient interface {
+	Get(ctx context.Context, key string, opts ...clientv3.OpOption) (*clientv3.GetResponse, error)
+	Watch(ctx context.Context, key string, opts ...clientv3.OpOption) clientv3.WatchChan
+	Close() error
+}
+
+// newClient creates an etcd client. It is replaced in tests.
+var newClient = func(cfg clientv3.Config) (etcdClient, error) {
+	return clientv3.New(cfg)
+}
+
+// client creates an etcd client from the arguments.
+func (args *Arguments) client(logger log.Logger) (etcdClient, error) {
+	cfg := clientv3.Config{
+		Endpoints:   args.Endpoints,
+		Username:    args.Username,
+		Password:    string(args.Password),
+		DialTimeout: args.DialTimeout,
+		Logger:      zapadapter.New(logger),
+	}
+	if args.TLSConfig != nil {
+		tlsConfig, err := prom_config.NewTLSConfig(args.TLSConfig.Convert())
+		if err != nil {
+			return nil, err
+		}
+		cfg.TLS = tlsConfig
+	}
+	return newClient(cfg)
+}
+
+// opts returns the options to read or watch the key or prefix.
+func (args *Arguments) opts() (string, []clientv3.OpOption) {
+	if args.Key != "" {
+		return args.Key, nil
+	}
+	return args.Prefix, []clientv3.OpOption{clientv3.WithPrefix()}
+}
+
+// Exports holds settings exported by remote.etcd.
+type Exports struct {
+	// Value is the value of the key when key is set.
+	Value alloytypes.OptionalSecret `alloy:"value,attr"`
+
+	// Data holds the keys under the prefix when prefix is set. Keys are
+	// relative to the prefix.
+	Data map[string]alloytypes.OptionalSecret `alloy:"data,attr"`
+}
+
+// Component implements the remote.etcd component.
+type Component struct {
+	log  log.Logger
+	opts component.Options
+
+	mut         sync.Mutex
+	args        Arguments
+	cli         etcdClient
+	values      map[string]string // Values of the keys read, by full key.
+	revision    int64             // Revision of the values.
+	generation  int               // Incremented by Update to discard the results of in-flight watches.
+	lastExports Exports           // Used for determining whether exports should be updated
+
+	// Updated is written to whenever args updates.
+	updated     chan struct{}
+	cancelWatch context.CancelFunc
+
+	healthMut sync.RWMutex
+	health    component.Health
+}
+
+var (
+	_ component.Component       = (*Component)(nil)
+	_ component.HealthComponent = (*Component)(nil)
+)
+
+// New returns a new, unstarted, remote.etcd component.
+func New(opts component.Options, args Arguments) (*Component, error) {
+	c := &Component{
+		log:  opts.Logger,
+		opts: opts,
+
+		updated: make(chan struct{}, 1),
+
+		health: component.Health{
+			Health:     component.HealthTypeUnknown,
+			Message:    "component started",
+			UpdateTime: time.Now(),
+		},
+	}
+
+	if err := c.Update(args); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Run starts the remote.etcd component. It watches the key or prefix, and
+// reads it again with a backoff when the watch fails.
+func (c *Component) Run(ctx context.Context) error {
+	defer func() {
+		c.mut.Lock()
+		defer c.mut.Unlock()
+		if c.cli != nil {
+			_ = c.cli.Close()
+		}
+	}()
+
+	bo := backoff.New(ctx, backoff.Config{
+		MinBackoff: time.Second,
+		MaxBackoff: time.Minute,
+	})
+
+	for {
+		watchCtx, cancel := context.WithCancel(ctx)
+		c.mut.Lock()
+		c.cancelWatch = cancel
+		c.mut.Unlock()
+
+		err := c.watch(watchCtx, bo.Reset)
+		cancel()
+
+		switch {
+		case ctx.Err() != nil:
+			return nil
+		case errors.Is(err, context.Canceled):
+			// The watch was interrupted by Update; start over with the new
+			// arguments.
+			continue
+		}
+		level.Error(c.log).Log("msg", "failed to watch etcd", "err", err)
+		c.updateHealth(err)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(bo.NextDelay()):
+		case <-c.updated:
+		}
+	}
+}
+
+// watch reads the key or prefix, and then applies the changes to it until
+// the watch fails. synced is called once the key or prefix has been read.
+func (c *Component) watch(ctx context.Context, synced func()) error {
+	if err := c.read(ctx); err != nil {
+		return err
+	}
+	c.updateHealth(nil)
+	synced()
+
+	c.mut.Lock()
+	var (
+		args       = c.args
+		cli        = c.cli
+		generation = c.generation
+		revision   = c.revision
+	)
+	c.mut.Unlock()
+
+	key, opts := args.opts()
+	wch := cli.Watch(ctx, key, append(opts, clientv3.WithRev(revision+1))...)
+	for resp := range wch {
+		if err := resp.Err(); err != nil {
+			return err
+		}
+
+		c.mut.Lock()
+		if generation != c.generation {
+			c.mut.Unlock()
+			return context.Canceled
+		}
+		var deleted bool
+		for _, ev := range resp.Events {
+			switch ev.Type {
+			case clientv3.EventTypePut:
+				c.values[string(ev.Kv.Key)] = string(ev.Kv.Value)
+			case clientv3.EventTypeDelete:
+				delete(c.values, string(ev.Kv.Key))
+				deleted = deleted || args.Key != ""
+			}
+		}
+		c.revision = resp.Header.Revision
+		if !deleted {
+			c.exportLocked()
+		}
+		c.mut.Unlock()
+
+		if deleted {
+			return fmt.Errorf("key %q was deleted", args.Key)
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return errors.New("watch closed")
+}
+
+// read reads the key or prefix and updates the exports if they changed. On
+// errors, the exports are left unchanged.
+func (c *Component) read(ctx context.Context) error {
+	c.mut.Lock()
+	var (
+		args       = c.args
+		cli        = c.cli
+		generation = c.generation
+	)
+	c.mut.Unlock()
+
+	key, opts := args.opts()
+	resp, err := cli.Get(ctx, key, opts...)
+	if err == nil && args.Key != "" && len(resp.Kvs) == 0 {
+		err = fmt.Errorf("key %q not found", args.Key)
+	}
+
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	// Discard results of reads started with previous arguments.
+	if generation != c.generation {
+		return context.Canceled
+	}
+	if err != nil {
+		return err
+	}
+
+	c.values = make(map[string]string, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		c.values[string(kv.Key)] = string(kv.Value)
+	}
+	c.revision = resp.Header.Revision
+	c.exportLocked()
+	return nil
+}
+
+// exportLocked updates the exports from the values read if they changed.
+// c.mut must be held when calling.
+func (c *Component) exportLocked() {
+	newExports := Exports{Data: make(map[string]alloytypes.OptionalSecret, len(c.values))}
+	if c.args.Key != "" {
+		newExports.Value = alloytypes.OptionalSecret{IsSecret: c.args.IsSecret, Value: c.values[c.args.Key]}
+	} else {
+		for k, v := range c.values {
+			newExports.Data[strings.TrimPrefix(k, c.args.Prefix)] = alloytypes.OptionalSecret{IsSecret: c.args.IsSecret, Value: v}
+		}
+	}
+
+	// Only send a state change event if the exports have changed.
+	if newExports.Value != c.lastExports.Value || !maps.Equal(newExports.Data, c.lastExports.Data) {
+		c.opts.OnStateChange(newExports)
+	}
+	c.lastExports = newExports
+}
+
+func (c *Component) updateHealth(err error) {
+	c.healthMut.Lock()
+	defer c.healthMut.Unlock()
+
+	if err == nil {
+		c.health = component.Health{
+			Health:     component.HealthTypeHealthy,
+			Message:    "watching etcd",
+			UpdateTime: time.Now(),
+		}
+	} else {
+		c.health = component.Health{
+			Health:     component.HealthTypeUnhealthy,
+			Message:    fmt.Sprintf("watching etcd failed: %s", err),
+			UpdateTime: time.Now(),
+		}
+	}
+}
+
+// Update updates the remote.etcd component. After the update completes, the
+// key or prefix is read, and the error is returned if the read fails.
+func (c *Component) Update(args component.Arguments) (err error) {
+	// It's important to propagate the error in update so the initial state of
+	// the component is calculated correctly, otherwise the exports will be empty
+	// and may cause unexpected errors in downstream components.
+	defer func() {
+		if err != nil {
+			return
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), args.(Arguments).DialTimeout)
+		defer cancel()
+		err = c.read(ctx)
+		c.updateHealth(err)
+	}()
+
+	newArgs := args.(Arguments)
+	cli, err := newArgs.client(c.log)
+	if err != nil {
+		return err
+	}
+
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	if c.cli != nil {
+		_ = c.cli.Close()
+	}
+	c.args = newArgs
+	c.cli = cli
+	c.generation++
+	if c.cancelWatch != nil {
+		c.cancelWatch()
+	}
+
+	// Send an updated event if one wasn't already read.
+	select {
+	case c.updated <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// CurrentHealth returns the current health of the component.
+func (c *Component) CurrentHealth() component.Health {
+	c.healthMut.RLock()
+	defer c.healthMut.RUnlock()
+	return c.health
+}
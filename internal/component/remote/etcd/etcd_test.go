@@ -0,0 +1,320 @@
+package etcd
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+
+	"github.com/grafana/alloy/internal/component"
+	"github.com/grafana/alloy/internal/runtime/componenttest"
+	"github.com/grafana/alloy/internal/util"
+	"github.com/grafana/alloy/syntax"
+	"github.com/grafana/alloy/syntax/alloytypes"
+)
+
+func TestKey(t *testing.T) {
+	store := newFakeEtcd(t)
+	store.Put("config/level", "info")
+	store.Put("config/other", "ignored")
+
+	ctrl := runComponent(t, `
+		endpoints = ["localhost:2379"]
+		key       = "config/level"
+		is_secret = true
+	`)
+
+	requireExports(t, ctrl, Exports{
+		Value: alloytypes.OptionalSecret{IsSecret: true, Value: "info"},
+		Data:  map[string]alloytypes.OptionalSecret{},
+	})
+
+	// Changes are received through the watch, not by reading again.
+	reads := store.Reads()
+	store.Put("config/other", "still ignored")
+	store.Put("config/level", "debug")
+	requireExports(t, ctrl, Exports{
+		Value: alloytypes.OptionalSecret{IsSecret: true, Value: "debug"},
+		Data:  map[string]alloytypes.OptionalSecret{},
+	})
+	require.Equal(t, reads, store.Reads(), "changes should not cause reads")
+}
+
+func TestPrefix(t *testing.T) {
+	store := newFakeEtcd(t)
+	store.Put("services/api/port", "8080")
+	store.Put("other", "ignored")
+
+	ctrl := runComponent(t, `
+		endpoints = ["localhost:2379"]
+		prefix    = "services/"
+	`)
+
+	requireExports(t, ctrl, Exports{
+		Data: map[string]alloytypes.OptionalSecret{
+			"api/port": {Value: "8080"},
+		},
+	})
+
+	store.Put("services/web/port", "80")
+	store.Delete("services/api/port")
+	requireExports(t, ctrl, Exports{
+		Data: map[string]alloytypes.OptionalSecret{
+			"web/port": {Value: "80"},
+		},
+	})
+}
+
+func TestKeepsLastValueOnError(t *testing.T) {
+	store := newFakeEtcd(t)
+	store.Put("config/level", "info")
+
+	ctrl := runComponent(t, `
+		endpoints = ["localhost:2379"]
+		key       = "config/level"
+	`)
+	good := Exports{
+		Value: alloytypes.OptionalSecret{Value: "info"},
+		Data:  map[string]alloytypes.OptionalSecret{},
+	}
+	requireExports(t, ctrl, good)
+	requireHealth(t, ctrl, component.HealthTypeHealthy)
+
+	// Deleting the key makes the component unhealthy, but keeps the last value.
+	store.Delete("config/level")
+	requireHealth(t, ctrl, component.HealthTypeUnhealthy)
+	require.Equal(t, good, ctrl.Exports())
+
+	store.Put("config/level", "info")
+	requireHealth(t, ctrl, component.HealthTypeHealthy)
+
+	// Failing watches and reads make the component unhealthy too.
+	store.SetFailing(true)
+	store.Put("config/level", "debug")
+	requireHealth(t, ctrl, component.HealthTypeUnhealthy)
+	require.Equal(t, good, ctrl.Exports())
+
+	store.SetFailing(false)
+	requireExports(t, ctrl, Exports{
+		Value: alloytypes.OptionalSecret{Value: "debug"},
+		Data:  map[string]alloytypes.OptionalSecret{},
+	})
+	requireHealth(t, ctrl, component.HealthTypeHealthy)
+}
+
+func TestUnmarshalValidation(t *testing.T) {
+	tests := []struct {
+		name        string
+		cfg         string
+		expectedErr string
+	}{
+		{"No endpoints", `endpoints = []
+			key = "a"`, "at least one endpoint must be set"},
+		{"Neither key nor prefix", `endpoints = ["localhost:2379"]`, "exactly one of key or prefix must be set"},
+		{"Both key and prefix", `endpoints = ["localhost:2379"]
+			key = "a"
+			prefix = "b/"`, "exactly one of key or prefix must be set"},
+		{"Invalid dial_timeout", `endpoints = ["localhost:2379"]
+			key = "a"
+			dial_timeout = "0s"`, "dial_timeout must be greater than 0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var args Arguments
+			require.EqualError(t, syntax.Unmarshal([]byte(tt.cfg), &args), tt.expectedErr)
+		})
+	}
+}
+
+func runComponent(t *testing.T, cfg string) *componenttest.Controller {
+	t.Helper()
+
+	var args Arguments
+	require.NoError(t, syntax.Unmarshal([]byte(cfg), &args))
+
+	ctrl, err := componenttest.NewControllerFromID(util.TestLogger(t), "remote.etcd")
+	require.NoError(t, err)
+	go func() {
+		require.NoError(t, ctrl.Run(componenttest.TestContext(t), args))
+	}()
+	require.NoError(t, ctrl.WaitRunning(time.Second), "component never started")
+	return ctrl
+}
+
+func requireExports(t *testing.T, ctrl *componenttest.Controller, expect Exports) {
+	t.Helper()
+	require.EventuallyWithT(t, func(c *assert.CollectT) {
+		assert.Equal(c, expect, ctrl.Exports())
+	}, 10*time.Second, 10*time.Millisecond)
+}
+
+func requireHealth(t *testing.T, ctrl *componenttest.Controller, expect component.HealthType) {
+	t.Helper()
+	require.EventuallyWithT(t, func(c *assert.CollectT) {
+		comp, err := ctrl.GetComponent()
+		require.NoError(c, err)
+		assert.Equal(c, expect, comp.(component.HealthComponent).CurrentHealth().Health)
+	}, 10*time.Second, 10*time.Millisecond)
+}
+
+// fakeEtcd is an in-memory implementation of the subset of the etcd client
+// used by the component. It replaces newClient for the duration of a test.
+type fakeEtcd struct {
+	mut      sync.Mutex
+	revision int64
+	values   map[string]*mvccpb.KeyValue
+	history  []*clientv3.Event
+	watchers map[*fakeWatcher]struct{}
+	failing  bool
+	reads    int
+}
+
+type fakeWatcher struct {
+	key, end string
+	ch       chan clientv3.WatchResponse
+}
+
+func newFakeEtcd(t *testing.T) *fakeEtcd {
+	f := &fakeEtcd{
+		revision: 1,
+		values:   make(map[string]*mvccpb.KeyValue),
+		watchers: make(map[*fakeWatcher]struct{}),
+	}
+
+	prev := newClient
+	newClient = func(clientv3.Config) (etcdClient, error) { return f, nil }
+	t.Cleanup(func() { newClient = prev })
+	return f
+}
+
+func (f *fakeEtcd) Put(key, value string) {
+	f.mut.Lock()
+	defer f.mut.Unlock()
+	f.revision++
+	kv := &mvccpb.KeyValue{Key: []byte(key), Value: []byte(value), ModRevision: f.revision}
+	f.values[key] = kv
+	f.notifyLocked(&clientv3.Event{Type: clientv3.EventTypePut, Kv: kv})
+}
+
+func (f *fakeEtcd) Delete(key string) {
+	f.mut.Lock()
+	defer f.mut.Unlock()
+	f.revision++
+	delete(f.values, key)
+	f.notifyLocked(&clientv3.Event{
+		Type: clientv3.EventTypeDelete,
+		Kv:   &mvccpb.KeyValue{Key: []byte(key), ModRevision: f.revision},
+	})
+}
+
+// SetFailing makes reads fail and cancels all watches, as if the history had
+// been compacted.
+func (f *fakeEtcd) SetFailing(failing bool) {
+	f.mut.Lock()
+	defer f.mut.Unlock()
+	f.failing = failing
+	if !failing {
+		return
+	}
+	for w := range f.watchers {
+		w.ch <- clientv3.WatchResponse{
+			Header:          etcdserverpb.ResponseHeader{Revision: f.revision},
+			CompactRevision: f.revision,
+			Canceled:        true,
+		}
+		f.closeLocked(w)
+	}
+}
+
+func (f *fakeEtcd) Reads() int {
+	f.mut.Lock()
+	defer f.mut.Unlock()
+	return f.reads
+}
+
+func (f *fakeEtcd) notifyLocked(ev *clientv3.Event) {
+	f.history = append(f.history, ev)
+	for w := range f.watchers {
+		if w.matches(string(ev.Kv.Key)) {
+			w.ch <- clientv3.WatchResponse{
+				Header: etcdserverpb.ResponseHeader{Revision: f.revision},
+				Events: []*clientv3.Event{ev},
+			}
+		}
+	}
+}
+
+func (f *fakeEtcd) closeLocked(w *fakeWatcher) {
+	if _, ok := f.watchers[w]; ok {
+		delete(f.watchers, w)
+		close(w.ch)
+	}
+}
+
+func (f *fakeEtcd) Get(_ context.Context, key string, opts ...clientv3.OpOption) (*clientv3.GetResponse, error) {
+	op := clientv3.OpGet(key, opts...)
+	w := &fakeWatcher{key: key, end: string(op.RangeBytes())}
+
+	f.mut.Lock()
+	defer f.mut.Unlock()
+	f.reads++
+	if f.failing {
+		return nil, errors.New("etcdserver: request timed out")
+	}
+
+	resp := &clientv3.GetResponse{Header: &etcdserverpb.ResponseHeader{Revision: f.revision}}
+	for k, kv := range f.values {
+		if w.matches(k) {
+			resp.Kvs = append(resp.Kvs, kv)
+		}
+	}
+	sort.Slice(resp.Kvs, func(i, j int) bool { return string(resp.Kvs[i].Key) < string(resp.Kvs[j].Key) })
+	return resp, nil
+}
+
+func (f *fakeEtcd) Watch(ctx context.Context, key string, opts ...clientv3.OpOption) clientv3.WatchChan {
+	op := clientv3.OpGet(key, opts...)
+	w := &fakeWatcher{key: key, end: string(op.RangeBytes()), ch: make(chan clientv3.WatchResponse, 100)}
+
+	f.mut.Lock()
+	defer f.mut.Unlock()
+	f.watchers[w] = struct{}{}
+
+	// Replay the events since the requested revision.
+	for _, ev := range f.history {
+		if ev.Kv.ModRevision >= op.Rev() && w.matches(string(ev.Kv.Key)) {
+			w.ch <- clientv3.WatchResponse{
+				Header: etcdserverpb.ResponseHeader{Revision: f.revision},
+				Events: []*clientv3.Event{ev},
+			}
+		}
+	}
+
+	go func() {
+		<-ctx.Done()
+		f.mut.Lock()
+		defer f.mut.Unlock()
+		f.closeLocked(w)
+	}()
+	return w.ch
+}
+
+func (f *fakeEtcd) Close() error { return nil }
+
+func (w *fakeWatcher) matches(key string) bool {
+	if w.end == "" {
+		return key == w.key
+	}
+	// The component only uses WithPrefix, for which end is the prefix with
+	// its last byte incremented.
+	return strings.HasPrefix(key, w.key)
+}
@@ -2,10 +2,19 @@
 package http
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -17,7 +26,9 @@ import (
 	"github.com/grafana/alloy/internal/runtime/logging/level"
 	"github.com/grafana/alloy/internal/useragent"
 	"github.com/grafana/alloy/syntax/alloytypes"
+	"github.com/natefinch/atomic"
 	prom_config "github.com/prometheus/common/config"
+	"gopkg.in/yaml.v3"
 )
 
 var userAgent = useragent.Get()
@@ -45,15 +56,30 @@ type Arguments struct {
 	Headers map[string]string `alloy:"headers,attr,optional"`
 	Body    string            `alloy:"body,attr,optional"`
 
+	Format  string `alloy:"format,attr,optional"`
+	Persist bool   `alloy:"persist,attr,optional"`
+
 	Client common_config.HTTPClientConfig `alloy:"client,block,optional"`
 }
 
+// Supported values of the format argument.
+const (
+	FormatRaw  = "raw"
+	FormatJSON = "json"
+	FormatYAML = "yaml"
+)
+
+// contentFile is the name of the file used to persist the last content
+// received in the component's data directory.
+const contentFile = "content.json"
+
 // DefaultArguments holds default settings for Arguments.
 var DefaultArguments = Arguments{
 	PollFrequency: 1 * time.Minute,
 	PollTimeout:   10 * time.Second,
 	Client:        common_config.DefaultHTTPClientConfig,
 	Method:        http.MethodGet,
+	Format:        FormatRaw,
 }
 
 // SetToDefault implements syntax.Defaulter.
@@ -77,12 +103,63 @@ func (args *Arguments) Validate() error {
 		return err
 	}
 
+	switch args.Format {
+	case FormatRaw:
+	case FormatJSON, FormatYAML:
+		if args.IsSecret {
+			return fmt.Errorf("is_secret can't be used with format %q", args.Format)
+		}
+	default:
+		return fmt.Errorf("format must be one of %q, %q or %q, got %q", FormatRaw, FormatJSON, FormatYAML, args.Format)
+	}
+
+	// The persisted body is stored in plain text.
+	if args.Persist && args.IsSecret {
+		return fmt.Errorf("persist can't be used with is_secret")
+	}
+
 	return nil
 }
 
 // Exports holds settings exported by remote.http.
 type Exports struct {
 	Content alloytypes.OptionalSecret `alloy:"content,attr"`
+
+	// Decoded holds the content decoded according to the format argument. It
+	// is nil when the format is raw.
+	Decoded any `alloy:"decoded,attr"`
+}
+
+// cachedResponse is the last successful response received, used for
+// conditional requests and persisted across restarts when enabled.
+type cachedResponse struct {
+	// Request identifies the request the response was received for, see
+	// requestKey.
+	Request      string `json:"request"`
+	Content      string `json:"content"`
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"last_modified,omitempty"`
+}
+
+// requestKey returns a hash of the settings of the request made by the
+// component, so responses to previous requests are neither reused nor
+// persisted in plain text with the headers they were sent with.
+func requestKey(args Arguments) string {
+	h := sha256.New()
+	for _, s := range []string{args.Method, args.URL, args.Body} {
+		fmt.Fprintf(h, "%d:%s", len(s), s)
+	}
+	for _, name := range slices.Sorted(maps.Keys(args.Headers)) {
+		fmt.Fprintf(h, "%d:%s%d:%s", len(name), name, len(args.Headers[name]), args.Headers[name])
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// exportedFrom holds the inputs used to compute the current exports.
+type exportedFrom struct {
+	content  string
+	isSecret bool
+	format   string
 }
 
 // Component implements the remote.http component.
@@ -90,11 +167,13 @@ type Component struct {
 	log  log.Logger
 	opts component.Options
 
-	mut         sync.Mutex
-	args        Arguments
-	cli         *http.Client
-	lastPoll    time.Time
-	lastExports Exports // Used for determining whether exports should be updated
+	mut          sync.Mutex
+	args         Arguments
+	cli          *http.Client
+	lastPoll     time.Time
+	cached       *cachedResponse // Last successful response; nil if there's none.
+	exported     bool
+	lastExported exportedFrom // Used for determining whether exports should be updated
 
 	// Updated is written to whenever args updates.
 	updated chan struct{}
@@ -123,6 +202,14 @@ func New(opts component.Options, args Arguments) (*Component, error) {
 		},
 	}
 
+	if args.Persist {
+		cached, err := loadCachedResponse(c.contentPath())
+		if err != nil {
+			level.Warn(opts.Logger).Log("msg", "failed to load persisted content", "err", err)
+		}
+		c.cached = cached
+	}
+
 	if err := c.Update(args); err != nil {
 		return nil, err
 	}
@@ -209,6 +296,21 @@ func (c *Component) pollError() error {
 	for name, value := range c.args.Headers {
 		req.Header.Set(name, value)
 	}
+	key := requestKey(c.args)
+	cached := c.cached
+	if cached != nil && cached.Request != key {
+		cached = nil
+	}
+	if cached != nil {
+		// Make the request conditional, unless the conditions were set
+		// explicitly.
+		if cached.ETag != "" && req.Header.Get("If-None-Match") == "" {
+			req.Header.Set("If-None-Match", cached.ETag)
+		}
+		if cached.LastModified != "" && req.Header.Get("If-Modified-Since") == "" {
+			req.Header.Set("If-Modified-Since", cached.LastModified)
+		}
+	}
 	req = req.WithContext(ctx)
 
 	resp, err := c.cli.Do(req)
@@ -216,6 +318,13 @@ func (c *Component) pollError() error {
 		level.Error(c.log).Log("msg", "failed to perform request", "err", err)
 		return fmt.Errorf("performing request: %w", err)
 	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		// The content didn't change since the last response; it's only
+		// exported again if the arguments changed how it's exported.
+		return c.exportLocked(cached.Content)
+	}
 
 	bb, err := io.ReadAll(resp.Body)
 	if err != nil {
@@ -229,23 +338,116 @@ func (c *Component) pollError() error {
 	}
 
 	stringContent := strings.TrimSpace(string(bb))
+	if err := c.exportLocked(stringContent); err != nil {
+		level.Error(c.log).Log("msg", "failed to decode response", "format", c.args.Format, "err", err)
+		return err
+	}
 
-	newExports := Exports{
-		Content: alloytypes.OptionalSecret{
-			IsSecret: c.args.IsSecret,
-			Value:    stringContent,
-		},
+	newCached := &cachedResponse{
+		Request:      key,
+		Content:      stringContent,
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+	}
+	if c.args.Persist && (c.cached == nil || *c.cached != *newCached) {
+		if err := saveCachedResponse(c.contentPath(), newCached); err != nil {
+			level.Error(c.log).Log("msg", "failed to persist content", "err", err)
+		}
 	}
+	c.cached = newCached
+	return nil
+}
+
+// exportLocked decodes content and exports it, unless it was already
+// exported with the current arguments. On errors, the exports are left
+// unchanged. c.mut must be held when calling.
+func (c *Component) exportLocked(content string) error {
+	from := exportedFrom{content: content, isSecret: c.args.IsSecret, format: c.args.Format}
 
 	// Only send a state change event if the exports have changed from the
 	// previous poll.
-	if c.lastExports != newExports {
-		c.opts.OnStateChange(newExports)
+	if c.exported && c.lastExported == from {
+		return nil
+	}
+
+	decoded, err := decode(c.args.Format, content)
+	if err != nil {
+		return fmt.Errorf("decoding content as %s: %w", c.args.Format, err)
 	}
-	c.lastExports = newExports
+	c.opts.OnStateChange(Exports{
+		Content: alloytypes.OptionalSecret{
+			IsSecret: c.args.IsSecret,
+			Value:    content,
+		},
+		Decoded: decoded,
+	})
+	c.exported = true
+	c.lastExported = from
 	return nil
 }
 
+// exportCached exports the content of the last successful response to the
+// current request, if any. It returns false if there's no such response.
+func (c *Component) exportCached() bool {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	if c.cached == nil || c.cached.Request != requestKey(c.args) {
+		return false
+	}
+	return c.exportLocked(c.cached.Content) == nil
+}
+
+// decode decodes content according to format. It returns nil for the raw
+// format.
+func decode(format, content string) (any, error) {
+	var res any
+	switch format {
+	case FormatJSON:
+		if err := json.Unmarshal([]byte(content), &res); err != nil {
+			return nil, err
+		}
+	case FormatYAML:
+		if err := yaml.Unmarshal([]byte(content), &res); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func (c *Component) contentPath() string {
+	return filepath.Join(c.opts.DataPath, contentFile)
+}
+
+// loadCachedResponse loads the response persisted at path. A missing file
+// isn't an error.
+func loadCachedResponse(path string) (*cachedResponse, error) {
+	bb, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var cached cachedResponse
+	if err := json.Unmarshal(bb, &cached); err != nil {
+		return nil, fmt.Errorf("decoding %s: %w", path, err)
+	}
+	return &cached, nil
+}
+
+// saveCachedResponse persists cached at path.
+func saveCachedResponse(path string, cached *cachedResponse) error {
+	bb, err := json.Marshal(cached)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+	return atomic.WriteFile(path, bytes.NewReader(bb))
+}
+
 // Update updates the remote.http component. After the update completes, a
 // poll is forced.
 func (c *Component) Update(args component.Arguments) (err error) {
@@ -261,6 +463,13 @@ func (c *Component) Update(args component.Arguments) (err error) {
 		}
 		err = c.pollError()
 		c.updatePollHealth(err)
+
+		// When persistence is enabled, fall back to the last content received
+		// so that the configuration can load while the endpoint is down.
+		if err != nil && c.args.Persist && c.exportCached() {
+			level.Warn(c.log).Log("msg", "polling failed, using the last content received", "err", err)
+			err = nil
+		}
 	}()
 
 	c.mut.Lock()
@@ -1,6 +1,7 @@
 package http_test
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"net/http"
@@ -9,6 +10,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/grafana/alloy/internal/component"
 	http_component "github.com/grafana/alloy/internal/component/remote/http"
 	"github.com/grafana/alloy/internal/runtime/componenttest"
 	"github.com/grafana/alloy/internal/runtime/logging/level"
@@ -121,6 +123,32 @@ func TestUnmarshalValidation(t *testing.T) {
 			`,
 			`poll_frequency must be greater than 0`,
 		},
+		{
+			"Invalid format",
+			`
+			url = "http://example.com"
+			format = "xml"
+			`,
+			`format must be one of "raw", "json" or "yaml", got "xml"`,
+		},
+		{
+			"Secret decoded content",
+			`
+			url = "http://example.com"
+			format = "json"
+			is_secret = true
+			`,
+			`is_secret can't be used with format "json"`,
+		},
+		{
+			"Persisted secret",
+			`
+			url = "http://example.com"
+			is_secret = true
+			persist = true
+			`,
+			`persist can't be used with is_secret`,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.testname, func(t *testing.T) {
@@ -130,6 +158,149 @@ func TestUnmarshalValidation(t *testing.T) {
 	}
 }
 
+func TestConditionalRequests(t *testing.T) {
+	var (
+		mut                       sync.Mutex
+		fullResponses, notChanged int
+		stateChanges              int
+	)
+
+	var handler lazyHandler
+	srv := httptest.NewServer(&handler)
+	defer srv.Close()
+
+	handler.SetHandler(func(w http.ResponseWriter, r *http.Request) {
+		mut.Lock()
+		defer mut.Unlock()
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notChanged++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		fullResponses++
+		w.Header().Set("ETag", `"v1"`)
+		fmt.Fprintln(w, `{"targets": ["localhost:9090"]}`)
+	})
+
+	ctrl, err := componenttest.NewControllerFromID(util.TestLogger(t), "remote.http")
+	require.NoError(t, err)
+
+	cfg := fmt.Sprintf(`
+		url    = "%s"
+		format = "json"
+
+		poll_frequency = "50ms"
+		poll_timeout   = "25ms"
+	`, srv.URL)
+	var args http_component.Arguments
+	require.NoError(t, syntax.Unmarshal([]byte(cfg), &args))
+
+	go func() {
+		err := ctrl.Run(componenttest.TestContext(t), args, func(opts component.Options) component.Options {
+			onStateChange := opts.OnStateChange
+			opts.OnStateChange = func(e component.Exports) {
+				mut.Lock()
+				stateChanges++
+				mut.Unlock()
+				onStateChange(e)
+			}
+			return opts
+		})
+		require.NoError(t, err)
+	}()
+	require.NoError(t, ctrl.WaitRunning(time.Second), "component never started")
+
+	require.Eventually(t, func() bool {
+		mut.Lock()
+		defer mut.Unlock()
+		return notChanged >= 3
+	}, 5*time.Second, 10*time.Millisecond)
+
+	mut.Lock()
+	defer mut.Unlock()
+	require.Equal(t, 1, fullResponses, "unchanged content should not be transferred again")
+	require.Equal(t, 1, stateChanges, "unchanged content should not be exported again")
+	require.Equal(t, http_component.Exports{
+		Content: alloytypes.OptionalSecret{Value: `{"targets": ["localhost:9090"]}`},
+		Decoded: map[string]any{"targets": []any{"localhost:9090"}},
+	}, ctrl.Exports())
+}
+
+func TestDecodeYAML(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, "level: debug\nreplicas: 3")
+	}))
+	defer srv.Close()
+
+	ctrl, err := componenttest.NewControllerFromID(util.TestLogger(t), "remote.http")
+	require.NoError(t, err)
+
+	var args http_component.Arguments
+	require.NoError(t, syntax.Unmarshal([]byte(fmt.Sprintf(`
+		url    = "%s"
+		format = "yaml"
+	`, srv.URL)), &args))
+
+	go func() {
+		require.NoError(t, ctrl.Run(componenttest.TestContext(t), args))
+	}()
+	require.NoError(t, ctrl.WaitRunning(time.Second), "component never started")
+	require.Equal(t, map[string]any{"level": "debug", "replicas": 3}, ctrl.Exports().(http_component.Exports).Decoded)
+}
+
+func TestPersist(t *testing.T) {
+	var handler lazyHandler
+	srv := httptest.NewServer(&handler)
+	defer srv.Close()
+
+	handler.SetHandler(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, "Hello, world!")
+	})
+
+	var args http_component.Arguments
+	require.NoError(t, syntax.Unmarshal([]byte(fmt.Sprintf(`
+		url     = "%s"
+		persist = true
+	`, srv.URL)), &args))
+
+	dataPath := t.TempDir()
+	withDataPath := func(opts component.Options) component.Options {
+		opts.DataPath = dataPath
+		return opts
+	}
+	expect := http_component.Exports{
+		Content: alloytypes.OptionalSecret{Value: "Hello, world!"},
+	}
+
+	// Run a first component while the endpoint is up.
+	ctx, cancel := context.WithCancel(t.Context())
+	ctrl, err := componenttest.NewControllerFromID(util.TestLogger(t), "remote.http")
+	require.NoError(t, err)
+	go func() {
+		require.NoError(t, ctrl.Run(ctx, args, withDataPath))
+	}()
+	require.NoError(t, ctrl.WaitRunning(time.Second), "component never started")
+	require.Equal(t, expect, ctrl.Exports())
+	cancel()
+
+	// A new component starts with the persisted content while the endpoint is
+	// down, but is reported as unhealthy.
+	handler.SetHandler(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	})
+	ctrl, err = componenttest.NewControllerFromID(util.TestLogger(t), "remote.http")
+	require.NoError(t, err)
+	go func() {
+		require.NoError(t, ctrl.Run(componenttest.TestContext(t), args, withDataPath))
+	}()
+	require.NoError(t, ctrl.WaitRunning(time.Second), "component never started")
+	require.Equal(t, expect, ctrl.Exports())
+
+	comp, err := ctrl.GetComponent()
+	require.NoError(t, err)
+	require.Equal(t, component.HealthTypeUnhealthy, comp.(component.HealthComponent).CurrentHealth().Health)
+}
+
 func eventually(t *testing.T, min, max time.Duration, retries int, f func() error) {
 	t.Helper()
 
@@ -156,8 +156,48 @@ func (state *State) alloyLabelForComponent(c groupedInstanceID) string {
 // Next returns the set of Alloy component IDs for a given data type that the
 // current component being converted should forward data to.
 func (state *State) Next(c componentstatus.InstanceID, signal pipeline.Signal) []componentID {
-	instances := state.nextInstances(c, signal)
+	return state.toComponentIDs(state.nextInstances(c, signal))
+}
+
+// NextInPipeline returns the set of Alloy component IDs that the current
+// component should forward data to within the pipeline with the given ID.
+// Connectors which route data to specific pipelines, rather than to every
+// pipeline they're a receiver of, use it to look up the consumers of each
+// pipeline.
+func (state *State) NextInPipeline(c componentstatus.InstanceID, id pipeline.ID) []componentID {
+	var instances []groupedInstanceID
+
+	for _, g := range state.groups {
+		if g.Name != id.Name() {
+			continue
+		}
+
+		var nextIDs []componentstatus.InstanceID
+		switch id.Signal() {
+		case pipeline.SignalMetrics:
+			nextIDs = g.NextMetrics(c)
+		case pipeline.SignalLogs:
+			nextIDs = g.NextLogs(c)
+		case pipeline.SignalTraces:
+			nextIDs = g.NextTraces(c)
+		default:
+			panic(fmt.Sprintf("otelcolconvert: unknown data type %q", id.Signal()))
+		}
+
+		for _, nextID := range nextIDs {
+			instances = append(instances, groupedInstanceID{
+				InstanceID: nextID,
+				groupName:  g.Name,
+			})
+		}
+	}
+
+	return state.toComponentIDs(instances)
+}
 
+// toComponentIDs converts instances of OpenTelemetry components into the IDs
+// of the Alloy components which receive their data.
+func (state *State) toComponentIDs(instances []groupedInstanceID) []componentID {
 	var ids []componentID
 
 	for _, instance := range instances {
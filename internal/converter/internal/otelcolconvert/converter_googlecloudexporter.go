@@ -2,6 +2,7 @@ package otelcolconvert
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/GoogleCloudPlatform/opentelemetry-operations-go/exporter/collector"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/googlecloudexporter"
@@ -11,6 +12,7 @@ import (
 
 	"github.com/grafana/alloy/internal/component/otelcol/exporter/googlecloud"
 	googlecloudconfig "github.com/grafana/alloy/internal/component/otelcol/exporter/googlecloud/config"
+	"github.com/grafana/alloy/internal/component/otelcol/extension"
 	"github.com/grafana/alloy/internal/converter/diag"
 	"github.com/grafana/alloy/internal/converter/internal/common"
 )
@@ -33,9 +35,17 @@ func (googleCloudExporterConverter) ConvertAndAppend(state *State, id components
 	var diags diag.Diagnostics
 
 	label := state.AlloyComponentLabel()
+	overrideHook := func(val interface{}) interface{} {
+		switch val.(type) {
+		case extension.ExtensionHandler:
+			ext := state.LookupExtension(*cfg.(*googlecloudexporter.Config).QueueSettings.StorageID)
+			return common.CustomTokenizer{Expr: fmt.Sprintf("%s.%s.handler", strings.Join(ext.Name, "."), ext.Label)}
+		}
+		return common.GetAlloyTypesOverrideHook()(val)
+	}
 
 	args := toGoogleCloudExporter(cfg.(*googlecloudexporter.Config))
-	block := common.NewBlockWithOverride([]string{"otelcol", "exporter", "googlecloud"}, label, args)
+	block := common.NewBlockWithOverrideFn([]string{"otelcol", "exporter", "googlecloud"}, label, args, overrideHook)
 
 	diags.Add(
 		diag.SeverityLevelInfo,
@@ -10,6 +10,7 @@ import (
 
 	otelconfig "github.com/grafana/alloy/internal/component/otelcol/config"
 	googlecloudpubsubconfig "github.com/grafana/alloy/internal/component/otelcol/exporter/googlecloudpubsub/config"
+	"github.com/grafana/alloy/internal/component/otelcol/extension"
 
 	"github.com/grafana/alloy/internal/converter/diag"
 	"github.com/grafana/alloy/internal/converter/internal/common"
@@ -34,10 +35,20 @@ func (googleCloudPubSubExporterConverter) InputComponentName() string {
 func (c googleCloudPubSubExporterConverter) ConvertAndAppend(state *State, id componentstatus.InstanceID, cfg component.Config) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	block := common.NewBlockWithOverride(
+	overrideHook := func(val interface{}) interface{} {
+		switch val.(type) {
+		case extension.ExtensionHandler:
+			ext := state.LookupExtension(*cfg.(*googlecloudpubsubexporter.Config).QueueSettings.StorageID)
+			return common.CustomTokenizer{Expr: fmt.Sprintf("%s.%s.handler", strings.Join(ext.Name, "."), ext.Label)}
+		}
+		return common.GetAlloyTypesOverrideHook()(val)
+	}
+
+	block := common.NewBlockWithOverrideFn(
 		strings.Split(c.InputComponentName(), "."),
 		state.AlloyComponentLabel(),
 		toGoogleCloudPubSubExporter(cfg.(*googlecloudpubsubexporter.Config)),
+		overrideHook,
 	)
 
 	diags.Add(
@@ -0,0 +1,71 @@
+package otelcolconvert
+
+import (
+	"fmt"
+
+	"github.com/grafana/alloy/internal/component/otelcol/extension/health_check"
+	"github.com/grafana/alloy/internal/converter/diag"
+	"github.com/grafana/alloy/internal/converter/internal/common"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/healthcheckextension"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+)
+
+func init() {
+	converters = append(converters, healthCheckExtensionConverter{})
+}
+
+type healthCheckExtensionConverter struct{}
+
+func (healthCheckExtensionConverter) Factory() component.Factory {
+	return healthcheckextension.NewFactory()
+}
+
+func (healthCheckExtensionConverter) InputComponentName() string {
+	return "otelcol.extension.health_check"
+}
+
+func (healthCheckExtensionConverter) ConvertAndAppend(state *State, id componentstatus.InstanceID, cfg component.Config) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	label := state.AlloyComponentLabel()
+
+	hcCfg := cfg.(*healthcheckextension.Config)
+	args := toHealthCheckExtension(hcCfg)
+	block := common.NewBlockWithOverride([]string{"otelcol", "extension", "health_check"}, label, args)
+
+	diags.Add(
+		diag.SeverityLevelWarn,
+		fmt.Sprintf("%s: the endpoint is not converted, the health check is served by the Alloy HTTP server at /api/v0/component/otelcol.extension.health_check.%s/", StringifyInstanceID(id), label),
+	)
+	if hcCfg.Path != "/" {
+		diags.Add(diag.SeverityLevelWarn, fmt.Sprintf("%s: the path configuration is not supported", StringifyInstanceID(id)))
+	}
+	if hcCfg.CheckCollectorPipeline.Enabled {
+		diags.Add(diag.SeverityLevelWarn, fmt.Sprintf("%s: the check_collector_pipeline configuration is not supported", StringifyInstanceID(id)))
+	}
+
+	diags.Add(
+		diag.SeverityLevelInfo,
+		fmt.Sprintf("Converted %s into %s", StringifyInstanceID(id), StringifyBlock(block)),
+	)
+
+	state.Body().AppendBlock(block)
+	return diags
+}
+
+func toHealthCheckExtension(cfg *healthcheckextension.Config) *health_check.Arguments {
+	var responseBody *health_check.ResponseBodyArguments
+	if cfg.ResponseBody != nil {
+		responseBody = &health_check.ResponseBodyArguments{
+			Healthy:   cfg.ResponseBody.Healthy,
+			Unhealthy: cfg.ResponseBody.Unhealthy,
+		}
+	}
+
+	return &health_check.Arguments{
+		ResponseBody: responseBody,
+
+		DebugMetrics: common.DefaultValue[health_check.Arguments]().DebugMetrics,
+	}
+}
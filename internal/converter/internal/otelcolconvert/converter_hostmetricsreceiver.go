@@ -0,0 +1,273 @@
+package otelcolconvert
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/grafana/alloy/internal/component/otelcol"
+	"github.com/grafana/alloy/internal/component/otelcol/receiver/hostmetrics"
+	"github.com/grafana/alloy/internal/converter/diag"
+	"github.com/grafana/alloy/internal/converter/internal/common"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+func init() {
+	converters = append(converters, hostmetricsReceiverConverter{})
+}
+
+type hostmetricsReceiverConverter struct{}
+
+func (hostmetricsReceiverConverter) Factory() component.Factory {
+	return hostmetricsreceiver.NewFactory()
+}
+
+func (hostmetricsReceiverConverter) InputComponentName() string { return "" }
+
+func (hostmetricsReceiverConverter) ConvertAndAppend(state *State, id componentstatus.InstanceID, cfg component.Config) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	label := state.AlloyComponentLabel()
+
+	args := toHostmetricsReceiver(state, id, cfg.(*hostmetricsreceiver.Config))
+	block := common.NewBlockWithOverride([]string{"otelcol", "receiver", "hostmetrics"}, label, args)
+
+	diags.Add(
+		diag.SeverityLevelInfo,
+		fmt.Sprintf("Converted %s into %s", StringifyInstanceID(id), StringifyBlock(block)),
+	)
+
+	state.Body().AppendBlock(block)
+	return diags
+}
+
+func toHostmetricsReceiver(state *State, id componentstatus.InstanceID, cfg *hostmetricsreceiver.Config) *hostmetrics.Arguments {
+	var (
+		nextMetrics = state.Next(id, pipeline.SignalMetrics)
+	)
+
+	args := &hostmetrics.Arguments{
+		RootPath:                   cfg.RootPath,
+		MetadataCollectionInterval: cfg.MetadataCollectionInterval,
+
+		Controller: toScraperControllerArguments(cfg.ControllerConfig),
+
+		DebugMetrics: common.DefaultValue[hostmetrics.Arguments]().DebugMetrics,
+
+		Output: &otelcol.ConsumerArguments{
+			Metrics: ToTokenizedConsumers(nextMetrics),
+		},
+	}
+
+	// The scraper configs are types from internal upstream packages, so they're
+	// read through their mapstructure representation.
+	for typ, scraperCfg := range cfg.Scrapers {
+		scraper := encodeMapstruct(scraperCfg)
+
+		switch typ.String() {
+		case "cpu":
+			args.CPU = &hostmetrics.CPUScraperArguments{
+				Metrics: toHostmetricsCPUMetricsArguments(encodeMapstruct(scraper["metrics"])),
+			}
+		case "disk":
+			args.Disk = &hostmetrics.DiskScraperArguments{
+				Include: toHostmetricsMatchArguments(encodeMapstruct(scraper["include"]), "devices"),
+				Exclude: toHostmetricsMatchArguments(encodeMapstruct(scraper["exclude"]), "devices"),
+				Metrics: toHostmetricsDiskMetricsArguments(encodeMapstruct(scraper["metrics"])),
+			}
+		case "filesystem":
+			args.Filesystem = &hostmetrics.FilesystemScraperArguments{
+				IncludeVirtualFilesystems: scraper["include_virtual_filesystems"].(bool),
+				IncludeDevices:            toHostmetricsMatchArguments(encodeMapstruct(scraper["include_devices"]), "devices"),
+				ExcludeDevices:            toHostmetricsMatchArguments(encodeMapstruct(scraper["exclude_devices"]), "devices"),
+				IncludeFSTypes:            toHostmetricsMatchArguments(encodeMapstruct(scraper["include_fs_types"]), "fs_types"),
+				ExcludeFSTypes:            toHostmetricsMatchArguments(encodeMapstruct(scraper["exclude_fs_types"]), "fs_types"),
+				IncludeMountPoints:        toHostmetricsMatchArguments(encodeMapstruct(scraper["include_mount_points"]), "mount_points"),
+				ExcludeMountPoints:        toHostmetricsMatchArguments(encodeMapstruct(scraper["exclude_mount_points"]), "mount_points"),
+				Metrics:                   toHostmetricsFilesystemMetricsArguments(encodeMapstruct(scraper["metrics"])),
+			}
+		case "load":
+			args.Load = &hostmetrics.LoadScraperArguments{
+				CPUAverage: scraper["cpu_average"].(bool),
+				Metrics:    toHostmetricsLoadMetricsArguments(encodeMapstruct(scraper["metrics"])),
+			}
+		case "memory":
+			args.Memory = &hostmetrics.MemoryScraperArguments{
+				Metrics: toHostmetricsMemoryMetricsArguments(encodeMapstruct(scraper["metrics"])),
+			}
+		case "network":
+			args.Network = &hostmetrics.NetworkScraperArguments{
+				Include: toHostmetricsMatchArguments(encodeMapstruct(scraper["include"]), "interfaces"),
+				Exclude: toHostmetricsMatchArguments(encodeMapstruct(scraper["exclude"]), "interfaces"),
+				Metrics: toHostmetricsNetworkMetricsArguments(encodeMapstruct(scraper["metrics"])),
+			}
+		case "paging":
+			args.Paging = &hostmetrics.PagingScraperArguments{
+				Metrics: toHostmetricsPagingMetricsArguments(encodeMapstruct(scraper["metrics"])),
+			}
+		case "processes":
+			args.Processes = &hostmetrics.ProcessesScraperArguments{
+				Metrics: toHostmetricsProcessesMetricsArguments(encodeMapstruct(scraper["metrics"])),
+			}
+		case "process":
+			args.Process = &hostmetrics.ProcessScraperArguments{
+				Include:                toHostmetricsMatchArguments(encodeMapstruct(scraper["include"]), "names"),
+				Exclude:                toHostmetricsMatchArguments(encodeMapstruct(scraper["exclude"]), "names"),
+				MuteProcessAllErrors:   omittedBool(scraper["mute_process_all_errors"]),
+				MuteProcessNameError:   omittedBool(scraper["mute_process_name_error"]),
+				MuteProcessIOError:     omittedBool(scraper["mute_process_io_error"]),
+				MuteProcessCgroupError: omittedBool(scraper["mute_process_cgroup_error"]),
+				MuteProcessExeError:    omittedBool(scraper["mute_process_exe_error"]),
+				MuteProcessUserError:   omittedBool(scraper["mute_process_user_error"]),
+				ScrapeProcessDelay:     scraper["scrape_process_delay"].(time.Duration),
+				Metrics:                toHostmetricsProcessMetricsArguments(encodeMapstruct(scraper["metrics"])),
+				ResourceAttributes:     toHostmetricsProcessResourceAttributesArguments(encodeMapstruct(scraper["resource_attributes"])),
+			}
+		}
+	}
+
+	return args
+}
+
+// toHostmetricsMatchArguments converts an upstream include or exclude filter,
+// where key is the name of the field holding the list of values.
+func toHostmetricsMatchArguments(cfg map[string]any, key string) *hostmetrics.MatchArguments {
+	values, _ := cfg[key].([]string)
+	if len(values) == 0 {
+		return nil
+	}
+
+	matchType := encodeString(cfg["match_type"])
+	if matchType == "" {
+		matchType = "strict"
+	}
+	return &hostmetrics.MatchArguments{
+		Values:    values,
+		MatchType: matchType,
+	}
+}
+
+func toHostmetricsCPUMetricsArguments(cfg map[string]any) hostmetrics.CPUMetricsArguments {
+	return hostmetrics.CPUMetricsArguments{
+		SystemCPUFrequency:     toHostmetricsMetricArguments(encodeMapstruct(cfg["system.cpu.frequency"])),
+		SystemCPULogicalCount:  toHostmetricsMetricArguments(encodeMapstruct(cfg["system.cpu.logical.count"])),
+		SystemCPUPhysicalCount: toHostmetricsMetricArguments(encodeMapstruct(cfg["system.cpu.physical.count"])),
+		SystemCPUTime:          toHostmetricsMetricArguments(encodeMapstruct(cfg["system.cpu.time"])),
+		SystemCPUUtilization:   toHostmetricsMetricArguments(encodeMapstruct(cfg["system.cpu.utilization"])),
+	}
+}
+
+func toHostmetricsDiskMetricsArguments(cfg map[string]any) hostmetrics.DiskMetricsArguments {
+	return hostmetrics.DiskMetricsArguments{
+		SystemDiskIo:                toHostmetricsMetricArguments(encodeMapstruct(cfg["system.disk.io"])),
+		SystemDiskIoTime:            toHostmetricsMetricArguments(encodeMapstruct(cfg["system.disk.io_time"])),
+		SystemDiskMerged:            toHostmetricsMetricArguments(encodeMapstruct(cfg["system.disk.merged"])),
+		SystemDiskOperationTime:     toHostmetricsMetricArguments(encodeMapstruct(cfg["system.disk.operation_time"])),
+		SystemDiskOperations:        toHostmetricsMetricArguments(encodeMapstruct(cfg["system.disk.operations"])),
+		SystemDiskPendingOperations: toHostmetricsMetricArguments(encodeMapstruct(cfg["system.disk.pending_operations"])),
+		SystemDiskWeightedIoTime:    toHostmetricsMetricArguments(encodeMapstruct(cfg["system.disk.weighted_io_time"])),
+	}
+}
+
+func toHostmetricsFilesystemMetricsArguments(cfg map[string]any) hostmetrics.FilesystemMetricsArguments {
+	return hostmetrics.FilesystemMetricsArguments{
+		SystemFilesystemInodesUsage: toHostmetricsMetricArguments(encodeMapstruct(cfg["system.filesystem.inodes.usage"])),
+		SystemFilesystemUsage:       toHostmetricsMetricArguments(encodeMapstruct(cfg["system.filesystem.usage"])),
+		SystemFilesystemUtilization: toHostmetricsMetricArguments(encodeMapstruct(cfg["system.filesystem.utilization"])),
+	}
+}
+
+func toHostmetricsLoadMetricsArguments(cfg map[string]any) hostmetrics.LoadMetricsArguments {
+	return hostmetrics.LoadMetricsArguments{
+		SystemCPULoadAverage15m: toHostmetricsMetricArguments(encodeMapstruct(cfg["system.cpu.load_average.15m"])),
+		SystemCPULoadAverage1m:  toHostmetricsMetricArguments(encodeMapstruct(cfg["system.cpu.load_average.1m"])),
+		SystemCPULoadAverage5m:  toHostmetricsMetricArguments(encodeMapstruct(cfg["system.cpu.load_average.5m"])),
+	}
+}
+
+func toHostmetricsMemoryMetricsArguments(cfg map[string]any) hostmetrics.MemoryMetricsArguments {
+	return hostmetrics.MemoryMetricsArguments{
+		SystemLinuxMemoryAvailable: toHostmetricsMetricArguments(encodeMapstruct(cfg["system.linux.memory.available"])),
+		SystemLinuxMemoryDirty:     toHostmetricsMetricArguments(encodeMapstruct(cfg["system.linux.memory.dirty"])),
+		SystemMemoryLimit:          toHostmetricsMetricArguments(encodeMapstruct(cfg["system.memory.limit"])),
+		SystemMemoryPageSize:       toHostmetricsMetricArguments(encodeMapstruct(cfg["system.memory.page_size"])),
+		SystemMemoryUsage:          toHostmetricsMetricArguments(encodeMapstruct(cfg["system.memory.usage"])),
+		SystemMemoryUtilization:    toHostmetricsMetricArguments(encodeMapstruct(cfg["system.memory.utilization"])),
+	}
+}
+
+func toHostmetricsNetworkMetricsArguments(cfg map[string]any) hostmetrics.NetworkMetricsArguments {
+	return hostmetrics.NetworkMetricsArguments{
+		SystemNetworkConnections:    toHostmetricsMetricArguments(encodeMapstruct(cfg["system.network.connections"])),
+		SystemNetworkConntrackCount: toHostmetricsMetricArguments(encodeMapstruct(cfg["system.network.conntrack.count"])),
+		SystemNetworkConntrackMax:   toHostmetricsMetricArguments(encodeMapstruct(cfg["system.network.conntrack.max"])),
+		SystemNetworkDropped:        toHostmetricsMetricArguments(encodeMapstruct(cfg["system.network.dropped"])),
+		SystemNetworkErrors:         toHostmetricsMetricArguments(encodeMapstruct(cfg["system.network.errors"])),
+		SystemNetworkIo:             toHostmetricsMetricArguments(encodeMapstruct(cfg["system.network.io"])),
+		SystemNetworkPackets:        toHostmetricsMetricArguments(encodeMapstruct(cfg["system.network.packets"])),
+	}
+}
+
+func toHostmetricsPagingMetricsArguments(cfg map[string]any) hostmetrics.PagingMetricsArguments {
+	return hostmetrics.PagingMetricsArguments{
+		SystemPagingFaults:      toHostmetricsMetricArguments(encodeMapstruct(cfg["system.paging.faults"])),
+		SystemPagingOperations:  toHostmetricsMetricArguments(encodeMapstruct(cfg["system.paging.operations"])),
+		SystemPagingUsage:       toHostmetricsMetricArguments(encodeMapstruct(cfg["system.paging.usage"])),
+		SystemPagingUtilization: toHostmetricsMetricArguments(encodeMapstruct(cfg["system.paging.utilization"])),
+	}
+}
+
+func toHostmetricsProcessesMetricsArguments(cfg map[string]any) hostmetrics.ProcessesMetricsArguments {
+	return hostmetrics.ProcessesMetricsArguments{
+		SystemProcessesCount:   toHostmetricsMetricArguments(encodeMapstruct(cfg["system.processes.count"])),
+		SystemProcessesCreated: toHostmetricsMetricArguments(encodeMapstruct(cfg["system.processes.created"])),
+	}
+}
+
+func toHostmetricsProcessMetricsArguments(cfg map[string]any) hostmetrics.ProcessMetricsArguments {
+	return hostmetrics.ProcessMetricsArguments{
+		ProcessContextSwitches:     toHostmetricsMetricArguments(encodeMapstruct(cfg["process.context_switches"])),
+		ProcessCPUTime:             toHostmetricsMetricArguments(encodeMapstruct(cfg["process.cpu.time"])),
+		ProcessCPUUtilization:      toHostmetricsMetricArguments(encodeMapstruct(cfg["process.cpu.utilization"])),
+		ProcessDiskIo:              toHostmetricsMetricArguments(encodeMapstruct(cfg["process.disk.io"])),
+		ProcessDiskOperations:      toHostmetricsMetricArguments(encodeMapstruct(cfg["process.disk.operations"])),
+		ProcessHandles:             toHostmetricsMetricArguments(encodeMapstruct(cfg["process.handles"])),
+		ProcessMemoryUsage:         toHostmetricsMetricArguments(encodeMapstruct(cfg["process.memory.usage"])),
+		ProcessMemoryUtilization:   toHostmetricsMetricArguments(encodeMapstruct(cfg["process.memory.utilization"])),
+		ProcessMemoryVirtual:       toHostmetricsMetricArguments(encodeMapstruct(cfg["process.memory.virtual"])),
+		ProcessOpenFileDescriptors: toHostmetricsMetricArguments(encodeMapstruct(cfg["process.open_file_descriptors"])),
+		ProcessPagingFaults:        toHostmetricsMetricArguments(encodeMapstruct(cfg["process.paging.faults"])),
+		ProcessSignalsPending:      toHostmetricsMetricArguments(encodeMapstruct(cfg["process.signals_pending"])),
+		ProcessThreads:             toHostmetricsMetricArguments(encodeMapstruct(cfg["process.threads"])),
+		ProcessUptime:              toHostmetricsMetricArguments(encodeMapstruct(cfg["process.uptime"])),
+	}
+}
+
+func toHostmetricsProcessResourceAttributesArguments(cfg map[string]any) hostmetrics.ProcessResourceAttributesArguments {
+	return hostmetrics.ProcessResourceAttributesArguments{
+		ProcessCgroup:         toHostmetricsResourceAttributeArguments(encodeMapstruct(cfg["process.cgroup"])),
+		ProcessCommand:        toHostmetricsResourceAttributeArguments(encodeMapstruct(cfg["process.command"])),
+		ProcessCommandLine:    toHostmetricsResourceAttributeArguments(encodeMapstruct(cfg["process.command_line"])),
+		ProcessExecutableName: toHostmetricsResourceAttributeArguments(encodeMapstruct(cfg["process.executable.name"])),
+		ProcessExecutablePath: toHostmetricsResourceAttributeArguments(encodeMapstruct(cfg["process.executable.path"])),
+		ProcessOwner:          toHostmetricsResourceAttributeArguments(encodeMapstruct(cfg["process.owner"])),
+		ProcessParentPid:      toHostmetricsResourceAttributeArguments(encodeMapstruct(cfg["process.parent_pid"])),
+		ProcessPid:            toHostmetricsResourceAttributeArguments(encodeMapstruct(cfg["process.pid"])),
+	}
+}
+func toHostmetricsMetricArguments(cfg map[string]any) hostmetrics.MetricArguments {
+	return hostmetrics.MetricArguments{Enabled: cfg["enabled"].(bool)}
+}
+
+func toHostmetricsResourceAttributeArguments(cfg map[string]any) hostmetrics.ResourceAttributeArguments {
+	return hostmetrics.ResourceAttributeArguments{Enabled: cfg["enabled"].(bool)}
+}
+
+// omittedBool returns the value of a boolean field whose mapstructure tag has
+// omitempty, and which is thus missing from the map when false.
+func omittedBool(v any) bool {
+	b, _ := v.(bool)
+	return b
+}
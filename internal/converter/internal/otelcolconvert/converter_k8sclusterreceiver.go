@@ -0,0 +1,88 @@
+package otelcolconvert
+
+import (
+	"fmt"
+
+	"github.com/grafana/alloy/internal/component/otelcol"
+	"github.com/grafana/alloy/internal/component/otelcol/receiver/k8s_cluster"
+	"github.com/grafana/alloy/internal/converter/diag"
+	"github.com/grafana/alloy/internal/converter/internal/common"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+func init() {
+	converters = append(converters, k8sClusterReceiverConverter{})
+}
+
+type k8sClusterReceiverConverter struct{}
+
+func (k8sClusterReceiverConverter) Factory() component.Factory {
+	return k8sclusterreceiver.NewFactory()
+}
+
+func (k8sClusterReceiverConverter) InputComponentName() string { return "" }
+
+func (k8sClusterReceiverConverter) ConvertAndAppend(state *State, id componentstatus.InstanceID, cfg component.Config) diag.Diagnostics {
+	label := state.AlloyComponentLabel()
+
+	receiverCfg := cfg.(*k8sclusterreceiver.Config)
+	args, diags := toK8sClusterReceiver(state, id, receiverCfg)
+	block := common.NewBlockWithOverride([]string{"otelcol", "receiver", "k8s_cluster"}, label, args)
+
+	if receiverCfg.K8sLeaderElector != nil {
+		appendSingletonClustering(block)
+		diags.Add(
+			diag.SeverityLevelWarn,
+			fmt.Sprintf("%s: k8s_leader_elector was replaced with the singleton mode of Alloy clustering, which must be enabled for a single instance to run the receiver", StringifyInstanceID(id)),
+		)
+	}
+
+	diags.Add(
+		diag.SeverityLevelInfo,
+		fmt.Sprintf("Converted %s into %s", StringifyInstanceID(id), StringifyBlock(block)),
+	)
+
+	state.Body().AppendBlock(block)
+	return diags
+}
+
+func toK8sClusterReceiver(state *State, id componentstatus.InstanceID, cfg *k8sclusterreceiver.Config) (*k8s_cluster.Arguments, diag.Diagnostics) {
+	var (
+		diags diag.Diagnostics
+
+		nextMetrics = state.Next(id, pipeline.SignalMetrics)
+		nextLogs    = state.Next(id, pipeline.SignalLogs)
+	)
+
+	if len(cfg.MetadataExporters) > 0 {
+		diags.Add(
+			diag.SeverityLevelWarn,
+			fmt.Sprintf("%s: the metadata_exporters configuration is not supported", StringifyInstanceID(id)),
+		)
+	}
+
+	args := &k8s_cluster.Arguments{
+		KubernetesAPIConfig: otelcol.KubernetesAPIConfig{
+			AuthType: string(cfg.AuthType),
+			Context:  cfg.Context,
+		},
+		CollectionInterval:         cfg.CollectionInterval,
+		MetadataCollectionInterval: cfg.MetadataCollectionInterval,
+		NodeConditionsToReport:     cfg.NodeConditionTypesToReport,
+		AllocatableTypesToReport:   cfg.AllocatableTypesToReport,
+		Distribution:               cfg.Distribution,
+		Namespaces:                 cfg.Namespaces,
+
+		DebugMetrics: common.DefaultValue[k8s_cluster.Arguments]().DebugMetrics,
+
+		Output: &otelcol.ConsumerArguments{
+			Metrics: ToTokenizedConsumers(nextMetrics),
+			Logs:    ToTokenizedConsumers(nextLogs),
+		},
+	}
+
+	return args, diags
+}
@@ -0,0 +1,96 @@
+package otelcolconvert
+
+import (
+	"fmt"
+
+	"github.com/grafana/alloy/internal/component/otelcol"
+	"github.com/grafana/alloy/internal/component/otelcol/receiver/k8sobjects"
+	"github.com/grafana/alloy/internal/converter/diag"
+	"github.com/grafana/alloy/internal/converter/internal/common"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sobjectsreceiver"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+func init() {
+	converters = append(converters, k8sObjectsReceiverConverter{})
+}
+
+type k8sObjectsReceiverConverter struct{}
+
+func (k8sObjectsReceiverConverter) Factory() component.Factory {
+	return k8sobjectsreceiver.NewFactory()
+}
+
+func (k8sObjectsReceiverConverter) InputComponentName() string { return "" }
+
+func (k8sObjectsReceiverConverter) ConvertAndAppend(state *State, id componentstatus.InstanceID, cfg component.Config) diag.Diagnostics {
+	label := state.AlloyComponentLabel()
+
+	receiverCfg := cfg.(*k8sobjectsreceiver.Config)
+	args, diags := toK8sObjectsReceiver(state, id, receiverCfg)
+	block := common.NewBlockWithOverride([]string{"otelcol", "receiver", "k8sobjects"}, label, args)
+
+	if receiverCfg.K8sLeaderElector != nil {
+		appendSingletonClustering(block)
+		diags.Add(
+			diag.SeverityLevelWarn,
+			fmt.Sprintf("%s: k8s_leader_elector was replaced with the singleton mode of Alloy clustering, which must be enabled for a single instance to run the receiver", StringifyInstanceID(id)),
+		)
+	}
+
+	diags.Add(
+		diag.SeverityLevelInfo,
+		fmt.Sprintf("Converted %s into %s", StringifyInstanceID(id), StringifyBlock(block)),
+	)
+
+	state.Body().AppendBlock(block)
+	return diags
+}
+
+func toK8sObjectsReceiver(state *State, id componentstatus.InstanceID, cfg *k8sobjectsreceiver.Config) (*k8sobjects.Arguments, diag.Diagnostics) {
+	var (
+		diags diag.Diagnostics
+
+		nextLogs = state.Next(id, pipeline.SignalLogs)
+	)
+
+	args := &k8sobjects.Arguments{
+		KubernetesAPIConfig: otelcol.KubernetesAPIConfig{
+			AuthType: string(cfg.AuthType),
+			Context:  cfg.Context,
+		},
+		ErrorMode:           string(cfg.ErrorMode),
+		IncludeInitialState: cfg.IncludeInitialState,
+
+		DebugMetrics: common.DefaultValue[k8sobjects.Arguments]().DebugMetrics,
+
+		Output: &otelcol.ConsumerArguments{
+			Logs: ToTokenizedConsumers(nextLogs),
+		},
+	}
+
+	for _, o := range cfg.Objects {
+		object := k8sobjects.Object{
+			Name:            o.Name,
+			Group:           o.Group,
+			Namespaces:      o.Namespaces,
+			Mode:            string(o.Mode),
+			LabelSelector:   o.LabelSelector,
+			FieldSelector:   o.FieldSelector,
+			Interval:        o.Interval,
+			ResourceVersion: o.ResourceVersion,
+		}
+		// Upstream only defaults the mode when its config is validated.
+		if object.Mode == "" {
+			object.Mode = k8sobjects.ModePull
+		}
+		for _, t := range o.ExcludeWatchType {
+			object.ExcludeWatchType = append(object.ExcludeWatchType, string(t))
+		}
+		args.Objects = append(args.Objects, object)
+	}
+
+	return args, diags
+}
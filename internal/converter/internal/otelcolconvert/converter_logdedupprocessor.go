@@ -0,0 +1,62 @@
+package otelcolconvert
+
+import (
+	"fmt"
+
+	"github.com/grafana/alloy/internal/component/otelcol"
+	"github.com/grafana/alloy/internal/component/otelcol/processor/logdedup"
+	"github.com/grafana/alloy/internal/converter/diag"
+	"github.com/grafana/alloy/internal/converter/internal/common"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/logdedupprocessor"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+func init() {
+	converters = append(converters, logdedupProcessorConverter{})
+}
+
+type logdedupProcessorConverter struct{}
+
+func (logdedupProcessorConverter) Factory() component.Factory {
+	return logdedupprocessor.NewFactory()
+}
+
+func (logdedupProcessorConverter) InputComponentName() string {
+	return "otelcol.processor.logdedup"
+}
+
+func (logdedupProcessorConverter) ConvertAndAppend(state *State, id componentstatus.InstanceID, cfg component.Config) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	label := state.AlloyComponentLabel()
+
+	args := toLogdedupProcessor(state, id, cfg.(*logdedupprocessor.Config))
+	block := common.NewBlockWithOverride([]string{"otelcol", "processor", "logdedup"}, label, args)
+
+	diags.Add(
+		diag.SeverityLevelInfo,
+		fmt.Sprintf("Converted %s into %s", StringifyInstanceID(id), StringifyBlock(block)),
+	)
+
+	state.Body().AppendBlock(block)
+	return diags
+}
+
+func toLogdedupProcessor(state *State, id componentstatus.InstanceID, cfg *logdedupprocessor.Config) *logdedup.Arguments {
+	nextLogs := state.Next(id, pipeline.SignalLogs)
+
+	return &logdedup.Arguments{
+		LogCountAttribute: cfg.LogCountAttribute,
+		Interval:          cfg.Interval,
+		Timezone:          cfg.Timezone,
+		ExcludeFields:     cfg.ExcludeFields,
+		IncludeFields:     cfg.IncludeFields,
+		Conditions:        cfg.Conditions,
+		Output: &otelcol.ConsumerArguments{
+			Logs: ToTokenizedConsumers(nextLogs),
+		},
+		DebugMetrics: common.DefaultValue[logdedup.Arguments]().DebugMetrics,
+	}
+}
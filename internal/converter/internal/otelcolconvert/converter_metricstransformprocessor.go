@@ -0,0 +1,99 @@
+package otelcolconvert
+
+import (
+	"fmt"
+
+	"github.com/grafana/alloy/internal/component/otelcol"
+	"github.com/grafana/alloy/internal/component/otelcol/processor/metricstransform"
+	"github.com/grafana/alloy/internal/converter/diag"
+	"github.com/grafana/alloy/internal/converter/internal/common"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/metricstransformprocessor"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+func init() {
+	converters = append(converters, metricstransformProcessorConverter{})
+}
+
+type metricstransformProcessorConverter struct{}
+
+func (metricstransformProcessorConverter) Factory() component.Factory {
+	return metricstransformprocessor.NewFactory()
+}
+
+func (metricstransformProcessorConverter) InputComponentName() string {
+	return "otelcol.processor.metricstransform"
+}
+
+func (metricstransformProcessorConverter) ConvertAndAppend(state *State, id componentstatus.InstanceID, cfg component.Config) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	label := state.AlloyComponentLabel()
+
+	args := toMetricstransformProcessor(state, id, cfg.(*metricstransformprocessor.Config))
+	block := common.NewBlockWithOverride([]string{"otelcol", "processor", "metricstransform"}, label, args)
+
+	diags.Add(
+		diag.SeverityLevelInfo,
+		fmt.Sprintf("Converted %s into %s", StringifyInstanceID(id), StringifyBlock(block)),
+	)
+
+	state.Body().AppendBlock(block)
+	return diags
+}
+
+func toMetricstransformProcessor(state *State, id componentstatus.InstanceID, cfg *metricstransformprocessor.Config) *metricstransform.Arguments {
+	nextMetrics := state.Next(id, pipeline.SignalMetrics)
+
+	// The transform configs are unexported upstream types, so they're read
+	// field by field.
+	var transforms []metricstransform.Transform
+	for _, t := range cfg.Transforms {
+		transform := metricstransform.Transform{
+			Include:             t.MetricIncludeFilter.Include,
+			MatchType:           string(t.MetricIncludeFilter.MatchType),
+			MatchLabels:         t.MetricIncludeFilter.MatchLabels,
+			Action:              string(t.Action),
+			NewName:             t.NewName,
+			GroupResourceLabels: t.GroupResourceLabels,
+			AggregationType:     string(t.AggregationType),
+			SubmatchCase:        string(t.SubmatchCase),
+		}
+		if transform.MatchType == "" {
+			transform.MatchType = metricstransform.MatchTypeStrict
+		}
+
+		for _, op := range t.Operations {
+			operation := metricstransform.Operation{
+				Action:           string(op.Action),
+				Label:            op.Label,
+				NewLabel:         op.NewLabel,
+				LabelSet:         op.LabelSet,
+				AggregationType:  string(op.AggregationType),
+				AggregatedValues: op.AggregatedValues,
+				NewValue:         op.NewValue,
+				LabelValue:       op.LabelValue,
+				Scale:            op.Scale,
+			}
+			for _, va := range op.ValueActions {
+				operation.ValueActions = append(operation.ValueActions, metricstransform.ValueAction{
+					Value:    va.Value,
+					NewValue: va.NewValue,
+				})
+			}
+			transform.Operations = append(transform.Operations, operation)
+		}
+
+		transforms = append(transforms, transform)
+	}
+
+	return &metricstransform.Arguments{
+		Transforms: transforms,
+		Output: &otelcol.ConsumerArguments{
+			Metrics: ToTokenizedConsumers(nextMetrics),
+		},
+		DebugMetrics: common.DefaultValue[metricstransform.Arguments]().DebugMetrics,
+	}
+}
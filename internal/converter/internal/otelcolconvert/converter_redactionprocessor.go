@@ -0,0 +1,91 @@
+package otelcolconvert
+
+import (
+	"fmt"
+
+	"github.com/grafana/alloy/internal/component/otelcol"
+	"github.com/grafana/alloy/internal/component/otelcol/processor/redaction"
+	"github.com/grafana/alloy/internal/converter/diag"
+	"github.com/grafana/alloy/internal/converter/internal/common"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/redactionprocessor"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+func init() {
+	converters = append(converters, redactionProcessorConverter{})
+}
+
+type redactionProcessorConverter struct{}
+
+func (redactionProcessorConverter) Factory() component.Factory {
+	return redactionprocessor.NewFactory()
+}
+
+func (redactionProcessorConverter) InputComponentName() string {
+	return "otelcol.processor.redaction"
+}
+
+func (redactionProcessorConverter) ConvertAndAppend(state *State, id componentstatus.InstanceID, cfg component.Config) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	label := state.AlloyComponentLabel()
+
+	args, convDiags := toRedactionProcessor(state, id, cfg.(*redactionprocessor.Config))
+	diags.AddAll(convDiags)
+	block := common.NewBlockWithOverride([]string{"otelcol", "processor", "redaction"}, label, args)
+
+	diags.Add(
+		diag.SeverityLevelInfo,
+		fmt.Sprintf("Converted %s into %s", StringifyInstanceID(id), StringifyBlock(block)),
+	)
+
+	state.Body().AppendBlock(block)
+	return diags
+}
+
+func toRedactionProcessor(state *State, id componentstatus.InstanceID, cfg *redactionprocessor.Config) (*redaction.Arguments, diag.Diagnostics) {
+	var (
+		diags diag.Diagnostics
+
+		nextMetrics = state.Next(id, pipeline.SignalMetrics)
+		nextLogs    = state.Next(id, pipeline.SignalLogs)
+		nextTraces  = state.Next(id, pipeline.SignalTraces)
+	)
+
+	if cfg.URLSanitization.Enabled {
+		diags.Add(diag.SeverityLevelWarn, fmt.Sprintf("%s: the url_sanitizer configuration is not supported", StringifyInstanceID(id)))
+	}
+	db := cfg.DBSanitizer
+	if db.SQLConfig.Enabled || db.RedisConfig.Enabled || db.ValkeyConfig.Enabled || db.MemcachedConfig.Enabled ||
+		db.MongoConfig.Enabled || db.OpenSearchConfig.Enabled || db.ESConfig.Enabled {
+		diags.Add(diag.SeverityLevelWarn, fmt.Sprintf("%s: the db_sanitizer configuration is not supported", StringifyInstanceID(id)))
+	}
+
+	// An empty summary behaves the same as "silent" upstream.
+	summary := cfg.Summary
+	if summary == "" {
+		summary = "silent"
+	}
+
+	return &redaction.Arguments{
+		AllowAllKeys:       cfg.AllowAllKeys,
+		AllowedKeys:        cfg.AllowedKeys,
+		IgnoredKeys:        cfg.IgnoredKeys,
+		BlockedKeyPatterns: cfg.BlockedKeyPatterns,
+		BlockedValues:      cfg.BlockedValues,
+		AllowedValues:      cfg.AllowedValues,
+		HashFunction:       cfg.HashFunction.String(),
+		RedactAllTypes:     cfg.RedactAllTypes,
+		Summary:            summary,
+
+		Output: &otelcol.ConsumerArguments{
+			Metrics: ToTokenizedConsumers(nextMetrics),
+			Logs:    ToTokenizedConsumers(nextLogs),
+			Traces:  ToTokenizedConsumers(nextTraces),
+		},
+
+		DebugMetrics: common.DefaultValue[redaction.Arguments]().DebugMetrics,
+	}, diags
+}
@@ -0,0 +1,119 @@
+package otelcolconvert
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/grafana/alloy/internal/component/otelcol"
+	"github.com/grafana/alloy/internal/component/otelcol/connector/routing"
+	"github.com/grafana/alloy/internal/converter/diag"
+	"github.com/grafana/alloy/internal/converter/internal/common"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/routingconnector"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+func init() {
+	converters = append(converters, routingConnectorConverter{})
+}
+
+type routingConnectorConverter struct{}
+
+func (routingConnectorConverter) Factory() component.Factory {
+	return routingconnector.NewFactory()
+}
+
+func (routingConnectorConverter) InputComponentName() string {
+	return "otelcol.connector.routing"
+}
+
+func (routingConnectorConverter) ConvertAndAppend(state *State, id componentstatus.InstanceID, cfg component.Config) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// The routing connector sends data to the pipelines named in its routing
+	// table rather than to the pipelines of its own group, so it's only
+	// converted for the groups which export data to it.
+	if !exportsTo(state.group, id.ComponentID()) {
+		return diags
+	}
+
+	label := state.AlloyComponentLabel()
+
+	args, convDiags := toRoutingConnector(state, id, cfg.(*routingconnector.Config))
+	diags.AddAll(convDiags)
+	block := common.NewBlockWithOverride([]string{"otelcol", "connector", "routing"}, label, args)
+
+	diags.Add(
+		diag.SeverityLevelInfo,
+		fmt.Sprintf("Converted %s into %s", StringifyInstanceID(id), StringifyBlock(block)),
+	)
+
+	state.Body().AppendBlock(block)
+	return diags
+}
+
+// exportsTo reports whether any pipeline of group exports data to id.
+func exportsTo(group *pipelineGroup, id component.ID) bool {
+	return slices.Contains(group.Metrics.Exporters, id) ||
+		slices.Contains(group.Logs.Exporters, id) ||
+		slices.Contains(group.Traces.Exporters, id)
+}
+
+func toRoutingConnector(state *State, id componentstatus.InstanceID, cfg *routingconnector.Config) (*routing.Arguments, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	args := &routing.Arguments{
+		ErrorMode:    cfg.ErrorMode,
+		Output:       toRoutingOutput(state, id, cfg.DefaultPipelines),
+		DebugMetrics: common.DefaultValue[routing.Arguments]().DebugMetrics,
+	}
+
+	for _, item := range cfg.Table {
+		pipelines := item.Pipelines
+		if len(pipelines) == 0 {
+			// Upstream sends data matching a route without pipelines to the
+			// default pipelines.
+			pipelines = cfg.DefaultPipelines
+		}
+
+		route := routing.Route{
+			Context:   item.Context,
+			Condition: item.Condition,
+			Statement: item.Statement,
+			Output:    toRoutingOutput(state, id, pipelines),
+		}
+		if route.Context == "" {
+			route.Context = "resource"
+		}
+		if err := route.Validate(); err != nil {
+			diags.Add(diag.SeverityLevelError, fmt.Sprintf("Cannot convert route of %s: %s", StringifyInstanceID(id), err))
+		}
+		args.Routes = append(args.Routes, route)
+	}
+
+	return args, diags
+}
+
+// toRoutingOutput returns the consumers of the given pipelines.
+func toRoutingOutput(state *State, id componentstatus.InstanceID, pipelines []pipeline.ID) *otelcol.ConsumerArguments {
+	var nextMetrics, nextLogs, nextTraces []componentID
+
+	for _, pipelineID := range pipelines {
+		next := state.NextInPipeline(id, pipelineID)
+		switch pipelineID.Signal() {
+		case pipeline.SignalMetrics:
+			nextMetrics = append(nextMetrics, next...)
+		case pipeline.SignalLogs:
+			nextLogs = append(nextLogs, next...)
+		case pipeline.SignalTraces:
+			nextTraces = append(nextTraces, next...)
+		}
+	}
+
+	return &otelcol.ConsumerArguments{
+		Metrics: ToTokenizedConsumers(nextMetrics),
+		Logs:    ToTokenizedConsumers(nextLogs),
+		Traces:  ToTokenizedConsumers(nextTraces),
+	}
+}
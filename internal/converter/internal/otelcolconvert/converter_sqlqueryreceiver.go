@@ -0,0 +1,129 @@
+package otelcolconvert
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/grafana/alloy/internal/component/otelcol"
+	"github.com/grafana/alloy/internal/component/otelcol/extension"
+	"github.com/grafana/alloy/internal/component/otelcol/receiver/sqlquery"
+	"github.com/grafana/alloy/internal/converter/diag"
+	"github.com/grafana/alloy/internal/converter/internal/common"
+	"github.com/grafana/alloy/syntax/alloytypes"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/sqlqueryreceiver"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+func init() {
+	converters = append(converters, sqlqueryReceiverConverter{})
+}
+
+type sqlqueryReceiverConverter struct{}
+
+func (sqlqueryReceiverConverter) Factory() component.Factory {
+	return sqlqueryreceiver.NewFactory()
+}
+
+func (sqlqueryReceiverConverter) InputComponentName() string { return "" }
+
+func (sqlqueryReceiverConverter) ConvertAndAppend(state *State, id componentstatus.InstanceID, cfg component.Config) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	label := state.AlloyComponentLabel()
+	overrideHook := func(val interface{}) interface{} {
+		switch val.(type) {
+		case extension.ExtensionHandler:
+			ext := state.LookupExtension(*cfg.(*sqlqueryreceiver.Config).StorageID)
+			return common.CustomTokenizer{Expr: fmt.Sprintf("%s.%s.handler", strings.Join(ext.Name, "."), ext.Label)}
+		}
+		return common.GetAlloyTypesOverrideHook()(val)
+	}
+
+	args := toSqlqueryReceiver(state, id, cfg.(*sqlqueryreceiver.Config))
+	block := common.NewBlockWithOverrideFn([]string{"otelcol", "receiver", "sqlquery"}, label, args, overrideHook)
+
+	diags.Add(
+		diag.SeverityLevelInfo,
+		fmt.Sprintf("Converted %s into %s", StringifyInstanceID(id), StringifyBlock(block)),
+	)
+
+	state.Body().AppendBlock(block)
+	return diags
+}
+
+func toSqlqueryReceiver(state *State, id componentstatus.InstanceID, cfg *sqlqueryreceiver.Config) *sqlquery.Arguments {
+	var (
+		nextMetrics = state.Next(id, pipeline.SignalMetrics)
+		nextLogs    = state.Next(id, pipeline.SignalLogs)
+	)
+
+	args := &sqlquery.Arguments{
+		Driver:           cfg.Driver,
+		DataSource:       alloytypes.Secret(cfg.DataSource),
+		Host:             cfg.Host,
+		Port:             cfg.Port,
+		Database:         cfg.Database,
+		Username:         cfg.Username,
+		Password:         alloytypes.Secret(cfg.Password),
+		AdditionalParams: cfg.AdditionalParams,
+		MaxOpenConn:      cfg.MaxOpenConn,
+
+		Controller: toScraperControllerArguments(cfg.ControllerConfig),
+
+		Telemetry: sqlquery.TelemetryArguments{
+			Logs: sqlquery.TelemetryLogsArguments{
+				Query: cfg.Telemetry.Logs.Query,
+			},
+		},
+
+		DebugMetrics: common.DefaultValue[sqlquery.Arguments]().DebugMetrics,
+
+		Output: &otelcol.ConsumerArguments{
+			Metrics: ToTokenizedConsumers(nextMetrics),
+			Logs:    ToTokenizedConsumers(nextLogs),
+		},
+	}
+
+	// The query configs are types from an internal upstream package, so
+	// they're read field by field.
+	for _, q := range cfg.Queries {
+		query := sqlquery.Query{
+			SQL:                q.SQL,
+			TrackingColumn:     q.TrackingColumn,
+			TrackingStartValue: q.TrackingStartValue,
+		}
+		for _, m := range q.Metrics {
+			query.Metrics = append(query.Metrics, sqlquery.Metric{
+				MetricName:       m.MetricName,
+				ValueColumn:      m.ValueColumn,
+				AttributeColumns: m.AttributeColumns,
+				Monotonic:        m.Monotonic,
+				ValueType:        string(m.ValueType),
+				DataType:         string(m.DataType),
+				Aggregation:      string(m.Aggregation),
+				Unit:             m.Unit,
+				Description:      m.Description,
+				StaticAttributes: m.StaticAttributes,
+				StartTsColumn:    m.StartTsColumn,
+				TsColumn:         m.TsColumn,
+			})
+		}
+		for _, l := range q.Logs {
+			query.Logs = append(query.Logs, sqlquery.Log{
+				BodyColumn:       l.BodyColumn,
+				AttributeColumns: l.AttributeColumns,
+			})
+		}
+		args.Queries = append(args.Queries, query)
+	}
+
+	if cfg.StorageID != nil {
+		args.Storage = &extension.ExtensionHandler{
+			ID: *cfg.StorageID,
+		}
+	}
+
+	return args
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/grafana/alloy/internal/converter/diag"
 	"github.com/grafana/alloy/internal/converter/internal/common"
+	"github.com/grafana/alloy/internal/nodeconf/clustering"
 	"github.com/grafana/alloy/syntax/token/builder"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componentstatus"
@@ -37,6 +38,15 @@ func StringifyBlock(block *builder.Block) string {
 	return fmt.Sprintf("%s.%s", strings.Join(block.Name, "."), block.Label)
 }
 
+// appendSingletonClustering appends a clustering block to block which runs
+// the component on a single instance of an Alloy cluster. It replaces the
+// leader election of upstream components.
+func appendSingletonClustering(block *builder.Block) {
+	clusteringBlock := builder.NewBlock([]string{clustering.BlockName}, "")
+	clusteringBlock.Body().SetAttributeValue("mode", clustering.ModeSingleton)
+	block.Body().AppendBlock(clusteringBlock)
+}
+
 // ConvertWithoutValidation is similar to `otelcolconvert.go`'s Convert but without validating generated configs
 // This is to help testing `sigv4authextension` converter as its Validate() method calls up external cloud
 // service and we can't inject mock SigV4 credential provider since the attribute is set as internal in the
@@ -0,0 +1,97 @@
+// Package clustering holds the clustering block which the component
+// controller handles on behalf of components.
+package clustering
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/grafana/alloy/internal/featuregate"
+	"github.com/grafana/alloy/syntax/ast"
+)
+
+const BlockName = "clustering"
+
+// Stability is the stability level of the clustering block handled by the
+// controller.
+const Stability = featuregate.StabilityExperimental
+
+// ModeSingleton runs the component on the single instance of the cluster
+// which owns the component ID. The component is idle on every other instance.
+const ModeSingleton = "singleton"
+
+// Arguments holds the settings of a clustering block handled by the
+// controller.
+type Arguments struct {
+	// Mode determines how the component is scheduled across the instances of
+	// the cluster.
+	Mode string `alloy:"mode,attr"`
+}
+
+// Validate implements syntax.Validator.
+func (args *Arguments) Validate() error {
+	switch args.Mode {
+	case ModeSingleton:
+		return nil
+	default:
+		return fmt.Errorf("unsupported clustering mode %q, supported modes: %q", args.Mode, ModeSingleton)
+	}
+}
+
+// Split extracts the clustering block handled by the controller from the body
+// of a component. Components whose arguments declare their own clustering
+// block, such as the ones which distribute targets across the cluster, handle
+// it themselves: body is returned as is for them.
+//
+// block is nil if body doesn't have a clustering block handled by the
+// controller.
+func Split(args any, body ast.Body) (rest ast.Body, block *ast.BlockStmt) {
+	if args != nil && hasClusteringBlock(reflect.TypeOf(args)) {
+		return body, nil
+	}
+
+	for i, stmt := range body {
+		b, ok := stmt.(*ast.BlockStmt)
+		if !ok || b.Label != "" || b.GetBlockName() != BlockName {
+			continue
+		}
+
+		rest = make(ast.Body, 0, len(body)-1)
+		rest = append(rest, body[:i]...)
+		rest = append(rest, body[i+1:]...)
+		return rest, b
+	}
+	return body, nil
+}
+
+// hasClusteringBlock returns whether the arguments type t declares a
+// clustering block.
+func hasClusteringBlock(t reflect.Type) bool {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag, ok := field.Tag.Lookup("alloy")
+		if !ok {
+			continue
+		}
+
+		parts := strings.Split(tag, ",")
+		switch {
+		case slices.Contains(parts[1:], "squash"):
+			if hasClusteringBlock(field.Type) {
+				return true
+			}
+		case parts[0] == BlockName && slices.Contains(parts[1:], "block"):
+			return true
+		}
+	}
+	return false
+}
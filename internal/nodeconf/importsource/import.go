@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/grafana/alloy/internal/component"
+	"github.com/grafana/alloy/internal/featuregate"
 	"github.com/grafana/alloy/syntax/vm"
 )
 
@@ -15,6 +16,7 @@ const (
 	String
 	Git
 	HTTP
+	OCI
 )
 
 const (
@@ -22,8 +24,12 @@ const (
 	BlockNameString = "import.string"
 	BlockNameHTTP   = "import.http"
 	BlockNameGit    = "import.git"
+	BlockNameOCI    = "import.oci"
 )
 
+// OCIStabilityLevel is the stability level of import.oci blocks.
+const OCIStabilityLevel = featuregate.StabilityExperimental
+
 const ModulePath = "module_path"
 
 // ImportSource retrieves a module from a source.
@@ -42,16 +48,19 @@ type ImportSource interface {
 
 // NewImportSource creates a new ImportSource depending on the type.
 // onContentChange is used by the source when it receives new content.
-func NewImportSource(sourceType SourceType, managedOpts component.Options, eval *vm.Evaluator, onContentChange func(map[string]string)) ImportSource {
+// lock pins the modules of the remote sources, it may be nil.
+func NewImportSource(sourceType SourceType, managedOpts component.Options, eval *vm.Evaluator, onContentChange func(map[string]string), lock *Lockfile) ImportSource {
 	switch sourceType {
 	case File:
 		return NewImportFile(managedOpts, eval, onContentChange)
 	case String:
 		return NewImportString(eval, onContentChange)
 	case HTTP:
-		return NewImportHTTP(managedOpts, eval, onContentChange)
+		return NewImportHTTP(managedOpts, eval, onContentChange, lock)
 	case Git:
-		return NewImportGit(managedOpts, eval, onContentChange)
+		return NewImportGit(managedOpts, eval, onContentChange, lock)
+	case OCI:
+		return NewImportOCI(managedOpts, eval, onContentChange)
 	}
 	panic(fmt.Errorf("unsupported source type: %v", sourceType))
 }
@@ -67,6 +76,8 @@ func GetSourceType(fullName string) SourceType {
 		return HTTP
 	case BlockNameGit:
 		return Git
+	case BlockNameOCI:
+		return OCI
 	}
 	panic(fmt.Errorf("name does not map to a known source type: %v", fullName))
 }
@@ -26,6 +26,7 @@ type ImportFile struct {
 	managedOpts     component.Options
 	eval            *vm.Evaluator
 	onContentChange func(map[string]string)
+	verifier        *verifier
 	logger          log.Logger
 
 	reloadCh chan struct{}
@@ -48,11 +49,13 @@ var _ ImportSource = (*ImportFile)(nil)
 
 func NewImportFile(managedOpts component.Options, eval *vm.Evaluator, onContentChange func(map[string]string)) *ImportFile {
 	opts := managedOpts
+	verifier := newVerifier(onContentChange)
 	return &ImportFile{
 		reloadCh:        make(chan struct{}, 1),
 		managedOpts:     opts,
 		eval:            eval,
-		onContentChange: onContentChange,
+		onContentChange: verifier.OnContentChange,
+		verifier:        verifier,
 		logger:          managedOpts.Logger,
 	}
 }
@@ -64,6 +67,8 @@ type FileArguments struct {
 	Type filedetector.Detector `alloy:"detector,attr,optional"`
 	// PollFrequency determines the frequency to check for changes when Type is Poll.
 	PollFrequency time.Duration `alloy:"poll_frequency,attr,optional"`
+	// Verify configures the verification of the module.
+	Verify *VerifyArguments `alloy:"verify,block,optional"`
 }
 
 var DefaultFileArguments = FileArguments{
@@ -85,6 +90,10 @@ func (im *ImportFile) Evaluate(scope *vm.Scope) error {
 		return fmt.Errorf("decoding configuration: %w", err)
 	}
 
+	if err := im.verifier.Update(arguments.Verify); err != nil {
+		return fmt.Errorf("updating verification: %w", err)
+	}
+
 	if equality.DeepEqual(im.args, arguments) {
 		return nil
 	}
@@ -196,7 +205,7 @@ func (im *ImportFile) readFile() error {
 func (im *ImportFile) CurrentHealth() component.Health {
 	im.healthMut.RLock()
 	defer im.healthMut.RUnlock()
-	return im.health
+	return component.LeastHealthy(im.health, im.verifier.CurrentHealth())
 }
 
 func (im *ImportFile) setHealth(h component.Health) {
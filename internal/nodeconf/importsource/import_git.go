@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io/fs"
+	"os"
 	"path/filepath"
 	"strings"
 	"sync"
@@ -31,6 +33,9 @@ type ImportGit struct {
 	args            GitArguments
 	repoPath        string
 	onContentChange func(map[string]string)
+	verifier        *verifier
+	lock            *Lockfile
+	vendored        bool
 
 	argsChanged chan struct{}
 
@@ -50,6 +55,9 @@ type GitArguments struct {
 	Path          string            `alloy:"path,attr"`
 	PullFrequency time.Duration     `alloy:"pull_frequency,attr,optional"`
 	GitAuthConfig vcs.GitAuthConfig `alloy:",squash"`
+
+	// Verify configures the verification of the module.
+	Verify *VerifyArguments `alloy:"verify,block,optional"`
 }
 
 var DefaultGitArguments = GitArguments{
@@ -77,13 +85,16 @@ func (args *GitArguments) SetToDefault() {
 	*args = DefaultGitArguments
 }
 
-func NewImportGit(managedOpts component.Options, eval *vm.Evaluator, onContentChange func(map[string]string)) *ImportGit {
+func NewImportGit(managedOpts component.Options, eval *vm.Evaluator, onContentChange func(map[string]string), lock *Lockfile) *ImportGit {
+	verifier := newVerifier(onContentChange)
 	return &ImportGit{
 		opts:            managedOpts,
 		log:             managedOpts.Logger,
 		eval:            eval,
 		argsChanged:     make(chan struct{}, 1),
-		onContentChange: onContentChange,
+		onContentChange: verifier.OnContentChange,
+		verifier:        verifier,
+		lock:            lock,
 	}
 }
 
@@ -93,6 +104,10 @@ func (im *ImportGit) Evaluate(scope *vm.Scope) error {
 		return fmt.Errorf("decoding configuration: %w", err)
 	}
 
+	if err := im.verifier.Update(arguments.Verify); err != nil {
+		return fmt.Errorf("updating verification: %w", err)
+	}
+
 	if equality.DeepEqual(im.args, arguments) {
 		return nil
 	}
@@ -206,9 +221,22 @@ func (im *ImportGit) Update(args component.Arguments) (err error) {
 		Auth:       newArgs.GitAuthConfig,
 	}
 
+	// Pin the revision to the commit of the lockfile. Vendored modules are read
+	// from a copy of the repository and never fetched.
+	im.vendored = false
+	if locked, ok := im.lock.LookupGit(newArgs.Repository, newArgs.Revision); ok {
+		repoOpts.Revision = locked.Commit
+		if locked.Vendor != "" {
+			im.vendored = true
+			im.repoPath = im.lock.VendorPath(locked.Vendor)
+		}
+	} else if im.lock != nil {
+		level.Warn(im.log).Log("msg", "repository revision isn't pinned by the lockfile", "repository", newArgs.Repository, "revision", newArgs.Revision)
+	}
+
 	// Create or update the repo field.
 	// Failure to update repository makes the module loader temporarily use cached contents on disk
-	if im.repo == nil || !equality.DeepEqual(repoOpts, im.repoOpts) {
+	if !im.vendored && (im.repo == nil || !equality.DeepEqual(repoOpts, im.repoOpts)) {
 		r, err := vcs.NewGitRepo(context.Background(), im.repoPath, repoOpts)
 		if err != nil {
 			if errors.As(err, &vcs.UpdateFailedError{}) {
@@ -249,25 +277,29 @@ func (im *ImportGit) Update(args component.Arguments) (err error) {
 // pollFile fetches the latest content from the repository and updates the
 // controller. pollFile must only be called with im.mut held.
 func (im *ImportGit) pollFile(ctx context.Context, args GitArguments) error {
-	// Make sure our repo is up-to-date.
-	if err := im.repo.Update(ctx); err != nil {
-		return err
+	var tree moduleTree = vendorTree(im.repoPath)
+	if !im.vendored {
+		// Make sure our repo is up-to-date.
+		if err := im.repo.Update(ctx); err != nil {
+			return err
+		}
+		tree = im.repo
 	}
 
-	info, err := im.repo.Stat(args.Path)
+	info, err := tree.Stat(args.Path)
 	if err != nil {
 		return err
 	}
 
 	if info.IsDir() {
-		return im.handleDirectory(args.Path)
+		return im.handleDirectory(tree, args.Path)
 	}
 
-	return im.handleFile(args.Path)
+	return im.handleFile(tree, args.Path)
 }
 
-func (im *ImportGit) handleDirectory(path string) error {
-	filesInfo, err := im.repo.ReadDir(path)
+func (im *ImportGit) handleDirectory(tree moduleTree, path string) error {
+	filesInfo, err := tree.ReadDir(path)
 	if err != nil {
 		return err
 	}
@@ -277,7 +309,7 @@ func (im *ImportGit) handleDirectory(path string) error {
 		if fi.IsDir() || !strings.HasSuffix(fi.Name(), ".alloy") {
 			continue
 		}
-		bb, err := im.repo.ReadFile(filepath.Join(path, fi.Name()))
+		bb, err := tree.ReadFile(filepath.Join(path, fi.Name()))
 		if err != nil {
 			return err
 		}
@@ -287,8 +319,8 @@ func (im *ImportGit) handleDirectory(path string) error {
 	return nil
 }
 
-func (im *ImportGit) handleFile(path string) error {
-	bb, err := im.repo.ReadFile(path)
+func (im *ImportGit) handleFile(tree moduleTree, path string) error {
+	bb, err := tree.ReadFile(path)
 	if err != nil {
 		return err
 	}
@@ -296,11 +328,47 @@ func (im *ImportGit) handleFile(path string) error {
 	return nil
 }
 
+// moduleTree gives access to the files of a repository.
+type moduleTree interface {
+	Stat(path string) (fs.FileInfo, error)
+	ReadDir(path string) ([]fs.FileInfo, error)
+	ReadFile(path string) ([]byte, error)
+}
+
+var _ moduleTree = (*vcs.GitRepo)(nil)
+
+// vendorTree is a vendored copy of a repository stored in a local directory.
+type vendorTree string
+
+func (t vendorTree) Stat(path string) (fs.FileInfo, error) {
+	return os.Stat(filepath.Join(string(t), path))
+}
+
+func (t vendorTree) ReadDir(path string) ([]fs.FileInfo, error) {
+	entries, err := os.ReadDir(filepath.Join(string(t), path))
+	if err != nil {
+		return nil, err
+	}
+	infos := make([]fs.FileInfo, 0, len(entries))
+	for _, e := range entries {
+		info, err := e.Info()
+		if err != nil {
+			return nil, err
+		}
+		infos = append(infos, info)
+	}
+	return infos, nil
+}
+
+func (t vendorTree) ReadFile(path string) ([]byte, error) {
+	return os.ReadFile(filepath.Join(string(t), path))
+}
+
 // CurrentHealth implements component.HealthComponent.
 func (im *ImportGit) CurrentHealth() component.Health {
 	im.healthMut.RLock()
 	defer im.healthMut.RUnlock()
-	return im.health
+	return component.LeastHealthy(im.health, im.verifier.CurrentHealth())
 }
 
 // Update the evaluator.
@@ -4,13 +4,16 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"os"
 	"path"
+	"strings"
 	"time"
 
 	"github.com/grafana/alloy/internal/component"
 	common_config "github.com/grafana/alloy/internal/component/common/config"
 	remote_http "github.com/grafana/alloy/internal/component/remote/http"
 	"github.com/grafana/alloy/internal/runtime/equality"
+	"github.com/grafana/alloy/internal/runtime/logging/level"
 	"github.com/grafana/alloy/syntax/vm"
 )
 
@@ -20,18 +23,24 @@ type ImportHTTP struct {
 	arguments         HTTPArguments
 	managedOpts       component.Options
 	eval              *vm.Evaluator
+	verifier          *verifier
+	lock              *Lockfile
+	vendored          bool
 }
 
 var _ ImportSource = (*ImportHTTP)(nil)
 
-func NewImportHTTP(managedOpts component.Options, eval *vm.Evaluator, onContentChange func(map[string]string)) *ImportHTTP {
+func NewImportHTTP(managedOpts component.Options, eval *vm.Evaluator, onContentChange func(map[string]string), lock *Lockfile) *ImportHTTP {
 	opts := managedOpts
+	verifier := newVerifier(onContentChange)
 	opts.OnStateChange = func(e component.Exports) {
-		onContentChange(map[string]string{opts.ID: e.(remote_http.Exports).Content.Value})
+		verifier.OnContentChange(map[string]string{opts.ID: e.(remote_http.Exports).Content.Value})
 	}
 	return &ImportHTTP{
 		managedOpts: opts,
 		eval:        eval,
+		verifier:    verifier,
+		lock:        lock,
 	}
 }
 
@@ -46,6 +55,9 @@ type HTTPArguments struct {
 	Body    string            `alloy:"body,attr,optional"`
 
 	Client common_config.HTTPClientConfig `alloy:"client,block,optional"`
+
+	// Verify configures the verification of the module.
+	Verify *VerifyArguments `alloy:"verify,block,optional"`
 }
 
 // DefaultHTTPArguments holds default settings for HTTPArguments.
@@ -66,6 +78,30 @@ func (im *ImportHTTP) Evaluate(scope *vm.Scope) error {
 	if err := im.eval.Evaluate(scope, &arguments); err != nil {
 		return fmt.Errorf("decoding configuration: %w", err)
 	}
+
+	// The content must match the digest of the lockfile.
+	verify := arguments.Verify
+	locked, isLocked := im.lock.LookupHTTP(arguments.URL)
+	if isLocked {
+		var err error
+		if verify, err = pinVerify(verify, locked); err != nil {
+			return err
+		}
+	} else if im.lock != nil {
+		level.Warn(im.managedOpts.Logger).Log("msg", "url isn't pinned by the lockfile", "url", arguments.URL)
+	}
+	if err := im.verifier.Update(verify); err != nil {
+		return fmt.Errorf("updating verification: %w", err)
+	}
+
+	vendored := isLocked && locked.Vendor != ""
+	if (im.managedRemoteHTTP != nil && vendored) || (im.vendored && !vendored) {
+		return fmt.Errorf("switching between a vendored and a remote module requires a restart")
+	}
+	if vendored {
+		return im.readVendored(arguments, locked)
+	}
+
 	remoteHttpArguments := remote_http.Arguments{
 		URL:           arguments.URL,
 		PollFrequency: arguments.PollFrequency,
@@ -96,12 +132,51 @@ func (im *ImportHTTP) Evaluate(scope *vm.Scope) error {
 	return nil
 }
 
+// pinVerify returns the verification settings of a module pinned by the
+// lockfile. The sha256 of the verify block, if any, must match the digest of
+// the lockfile.
+func pinVerify(verify *VerifyArguments, locked LockedHTTP) (*VerifyArguments, error) {
+	pinned := VerifyArguments{SHA256: locked.SHA256}
+	if verify == nil {
+		return &pinned, nil
+	}
+	if verify.SHA256 != "" && !strings.EqualFold(verify.SHA256, locked.SHA256) {
+		return nil, fmt.Errorf("sha256 %s of the verify block conflicts with sha256 %s of %s in %s", strings.ToLower(verify.SHA256), locked.SHA256, locked.URL, LockfileName)
+	}
+	pinned.PublicKey, pinned.Signature = verify.PublicKey, verify.Signature
+	return &pinned, nil
+}
+
+// readVendored loads the module from the copy referenced by the lockfile
+// instead of polling the URL.
+func (im *ImportHTTP) readVendored(arguments HTTPArguments, locked LockedHTTP) error {
+	im.vendored = true
+	if equality.DeepEqual(im.arguments, arguments) {
+		return nil
+	}
+
+	bb, err := os.ReadFile(im.lock.VendorPath(locked.Vendor))
+	if err != nil {
+		return fmt.Errorf("reading vendored module: %w", err)
+	}
+	im.arguments = arguments
+	im.verifier.OnContentChange(map[string]string{im.managedOpts.ID: string(bb)})
+	return nil
+}
+
 func (im *ImportHTTP) Run(ctx context.Context) error {
+	if im.vendored {
+		<-ctx.Done()
+		return nil
+	}
 	return im.managedRemoteHTTP.Run(ctx)
 }
 
 func (im *ImportHTTP) CurrentHealth() component.Health {
-	return im.managedRemoteHTTP.CurrentHealth()
+	if im.vendored {
+		return im.verifier.CurrentHealth()
+	}
+	return component.LeastHealthy(im.managedRemoteHTTP.CurrentHealth(), im.verifier.CurrentHealth())
 }
 
 // Update the evaluator.
@@ -0,0 +1,34 @@
+package importsource
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPinVerify(t *testing.T) {
+	var (
+		digest = strings.Repeat("ab", 32)
+		other  = strings.Repeat("cd", 32)
+		locked = LockedHTTP{URL: "https://example.com/module.alloy", SHA256: digest}
+	)
+
+	pinned, err := pinVerify(nil, locked)
+	require.NoError(t, err)
+	require.Equal(t, &VerifyArguments{SHA256: digest}, pinned)
+
+	// The signature settings of the verify block are kept.
+	pinned, err = pinVerify(&VerifyArguments{PublicKey: "key", Signature: "sig"}, locked)
+	require.NoError(t, err)
+	require.Equal(t, &VerifyArguments{SHA256: digest, PublicKey: "key", Signature: "sig"}, pinned)
+
+	pinned, err = pinVerify(&VerifyArguments{SHA256: strings.ToUpper(digest)}, locked)
+	require.NoError(t, err)
+	require.Equal(t, &VerifyArguments{SHA256: digest}, pinned)
+
+	// The digest of the verify block is never dropped in favor of the one of
+	// the lockfile.
+	_, err = pinVerify(&VerifyArguments{SHA256: other}, locked)
+	require.EqualError(t, err, "sha256 "+other+" of the verify block conflicts with sha256 "+digest+" of https://example.com/module.alloy in alloy-modules.lock")
+}
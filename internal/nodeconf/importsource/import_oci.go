@@ -0,0 +1,467 @@
+package importsource
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+
+	"github.com/grafana/alloy/internal/component"
+	common_config "github.com/grafana/alloy/internal/component/common/config"
+	"github.com/grafana/alloy/internal/runtime/equality"
+	"github.com/grafana/alloy/internal/runtime/logging/level"
+	"github.com/grafana/alloy/syntax"
+	"github.com/grafana/alloy/syntax/alloytypes"
+	"github.com/grafana/alloy/syntax/vm"
+)
+
+// AnnotationTitle is the layer annotation holding the name of the file
+// stored in the layer.
+const AnnotationTitle = "org.opencontainers.image.title"
+
+// ociCacheMetadataFile records which artifact the cached files come from.
+const ociCacheMetadataFile = ".oci-metadata.json"
+
+// ImportOCI imports a module from an artifact stored in an OCI registry.
+type ImportOCI struct {
+	opts            component.Options
+	log             log.Logger
+	eval            *vm.Evaluator
+	mut             sync.RWMutex
+	args            OCIArguments
+	ref             name.Reference
+	digest          string
+	cachePath       string
+	onContentChange func(map[string]string)
+	verifier        *verifier
+
+	argsChanged chan struct{}
+
+	healthMut sync.RWMutex
+	health    component.Health
+}
+
+var (
+	_ ImportSource              = (*ImportOCI)(nil)
+	_ component.Component       = (*ImportOCI)(nil)
+	_ component.HealthComponent = (*ImportOCI)(nil)
+)
+
+// OCIArguments holds values which are used to pull a module from an OCI
+// registry.
+type OCIArguments struct {
+	Reference     string                   `alloy:"reference,attr"`
+	Path          string                   `alloy:"path,attr,optional"`
+	PullFrequency time.Duration            `alloy:"pull_frequency,attr,optional"`
+	PullTimeout   time.Duration            `alloy:"pull_timeout,attr,optional"`
+	Insecure      bool                     `alloy:"insecure,attr,optional"`
+	BasicAuth     *common_config.BasicAuth `alloy:"basic_auth,block,optional"`
+	BearerToken   alloytypes.Secret        `alloy:"bearer_token,attr,optional"`
+
+	// Verify configures the verification of the module.
+	Verify *VerifyArguments `alloy:"verify,block,optional"`
+}
+
+// DefaultOCIArguments holds default settings for OCIArguments.
+var DefaultOCIArguments = OCIArguments{
+	PullFrequency: time.Minute,
+	PullTimeout:   10 * time.Second,
+}
+
+var (
+	_ syntax.Validator = (*OCIArguments)(nil)
+	_ syntax.Defaulter = (*OCIArguments)(nil)
+)
+
+// SetToDefault implements syntax.Defaulter.
+func (args *OCIArguments) SetToDefault() {
+	*args = DefaultOCIArguments
+}
+
+// Validate implements syntax.Validator.
+func (args *OCIArguments) Validate() error {
+	if _, err := args.parseReference(); err != nil {
+		return fmt.Errorf("invalid reference %q: %w", args.Reference, err)
+	}
+	if args.Path != "" && filepath.Base(args.Path) != args.Path {
+		return fmt.Errorf("path must be a file name, got %q", args.Path)
+	}
+	if args.PullFrequency < 0 {
+		return fmt.Errorf("pull_frequency must not be negative")
+	}
+	if args.PullTimeout <= 0 {
+		return fmt.Errorf("pull_timeout must be greater than 0")
+	}
+	if args.BasicAuth != nil && args.BearerToken != "" {
+		return fmt.Errorf("at most one of basic_auth and bearer_token must be configured")
+	}
+	return args.BasicAuth.Validate()
+}
+
+func (args *OCIArguments) parseReference() (name.Reference, error) {
+	var opts []name.Option
+	if args.Insecure {
+		opts = append(opts, name.Insecure)
+	}
+	return name.ParseReference(args.Reference, opts...)
+}
+
+// remoteOptions returns the options used to talk to the registry. When no
+// credentials are configured, the credentials of the local Docker
+// configuration are used.
+func (args *OCIArguments) remoteOptions(ctx context.Context) ([]remote.Option, error) {
+	opts := []remote.Option{remote.WithContext(ctx)}
+
+	switch {
+	case args.BasicAuth != nil:
+		password := string(args.BasicAuth.Password)
+		if args.BasicAuth.PasswordFile != "" {
+			bb, err := os.ReadFile(args.BasicAuth.PasswordFile)
+			if err != nil {
+				return nil, fmt.Errorf("reading password file: %w", err)
+			}
+			password = strings.TrimSpace(string(bb))
+		}
+		opts = append(opts, remote.WithAuth(&authn.Basic{
+			Username: args.BasicAuth.Username,
+			Password: password,
+		}))
+	case args.BearerToken != "":
+		opts = append(opts, remote.WithAuth(&authn.Bearer{Token: string(args.BearerToken)}))
+	default:
+		opts = append(opts, remote.WithAuthFromKeychain(authn.DefaultKeychain))
+	}
+	return opts, nil
+}
+
+func NewImportOCI(managedOpts component.Options, eval *vm.Evaluator, onContentChange func(map[string]string)) *ImportOCI {
+	verifier := newVerifier(onContentChange)
+	return &ImportOCI{
+		opts:            managedOpts,
+		log:             managedOpts.Logger,
+		eval:            eval,
+		cachePath:       filepath.Join(managedOpts.DataPath, "oci"),
+		argsChanged:     make(chan struct{}, 1),
+		onContentChange: verifier.OnContentChange,
+		verifier:        verifier,
+	}
+}
+
+func (im *ImportOCI) Evaluate(scope *vm.Scope) error {
+	var arguments OCIArguments
+	if err := im.eval.Evaluate(scope, &arguments); err != nil {
+		return fmt.Errorf("decoding configuration: %w", err)
+	}
+
+	if err := im.verifier.Update(arguments.Verify); err != nil {
+		return fmt.Errorf("updating verification: %w", err)
+	}
+
+	if equality.DeepEqual(im.args, arguments) {
+		return nil
+	}
+
+	if err := im.Update(arguments); err != nil {
+		return fmt.Errorf("updating component: %w", err)
+	}
+	return nil
+}
+
+func (im *ImportOCI) Run(ctx context.Context) error {
+	var (
+		ticker  *time.Ticker
+		tickerC <-chan time.Time
+	)
+	defer func() {
+		if ticker != nil {
+			ticker.Stop()
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case <-im.argsChanged:
+			im.mut.RLock()
+			pullFrequency := im.args.PullFrequency
+			// An artifact referenced by digest can't change.
+			if _, ok := im.ref.(name.Digest); ok {
+				pullFrequency = 0
+			}
+			im.mut.RUnlock()
+			ticker, tickerC = im.updateTicker(pullFrequency, ticker, tickerC)
+
+		case <-tickerC:
+			level.Debug(im.log).Log("msg", "checking artifact for updates")
+			im.tickPull(ctx)
+		}
+	}
+}
+
+func (im *ImportOCI) updateTicker(pullFrequency time.Duration, ticker *time.Ticker, tickerC <-chan time.Time) (*time.Ticker, <-chan time.Time) {
+	if pullFrequency > 0 {
+		if ticker == nil {
+			ticker = time.NewTicker(pullFrequency)
+			tickerC = ticker.C
+		} else {
+			ticker.Reset(pullFrequency)
+		}
+		return ticker, tickerC
+	}
+
+	if ticker != nil {
+		ticker.Stop()
+	}
+	return nil, nil
+}
+
+func (im *ImportOCI) tickPull(ctx context.Context) {
+	im.mut.Lock()
+	ctx, cancel := context.WithTimeout(ctx, im.args.PullTimeout)
+	err := im.pull(ctx, im.args)
+	cancel()
+	im.mut.Unlock()
+
+	im.updateHealth(err)
+
+	if err != nil {
+		level.Error(im.log).Log("msg", "failed to pull artifact", "err", err)
+	}
+}
+
+func (im *ImportOCI) updateHealth(err error) {
+	im.healthMut.Lock()
+	defer im.healthMut.Unlock()
+
+	if err != nil {
+		im.health = component.Health{
+			Health:     component.HealthTypeUnhealthy,
+			Message:    err.Error(),
+			UpdateTime: time.Now(),
+		}
+	} else {
+		im.health = component.Health{
+			Health:     component.HealthTypeHealthy,
+			Message:    "module updated",
+			UpdateTime: time.Now(),
+		}
+	}
+}
+
+// Update implements component.Component.
+// If the artifact can't be pulled but a previous pull of the same reference
+// is cached on disk, the cached module is used and the error is only
+// reported through the health of the source.
+func (im *ImportOCI) Update(args component.Arguments) error {
+	im.mut.Lock()
+	defer im.mut.Unlock()
+
+	newArgs := args.(OCIArguments)
+
+	ref, err := newArgs.parseReference()
+	if err != nil {
+		return err
+	}
+	if newArgs.Reference != im.args.Reference || newArgs.Path != im.args.Path {
+		// Force the content to be reloaded.
+		im.digest = ""
+	}
+	im.ref = ref
+
+	ctx, cancel := context.WithTimeout(context.Background(), newArgs.PullTimeout)
+	pullErr := im.pull(ctx, newArgs)
+	cancel()
+	if pullErr != nil && im.digest == "" {
+		level.Error(im.log).Log("msg", "failed to pull artifact, using cached module", "err", pullErr)
+		if err := im.loadCache(newArgs); err != nil {
+			return errors.Join(pullErr, err)
+		}
+	}
+	im.updateHealth(pullErr)
+
+	// Schedule an update for handling the changed arguments.
+	select {
+	case im.argsChanged <- struct{}{}:
+	default:
+	}
+
+	im.args = newArgs
+	return nil
+}
+
+// pull fetches the artifact if its digest changed since the last pull and
+// updates the controller. pull must only be called with im.mut held.
+func (im *ImportOCI) pull(ctx context.Context, args OCIArguments) error {
+	opts, err := args.remoteOptions(ctx)
+	if err != nil {
+		return err
+	}
+
+	desc, err := remote.Head(im.ref, opts...)
+	if err != nil {
+		return fmt.Errorf("resolving %s: %w", im.ref, err)
+	}
+	if desc.Digest.String() == im.digest {
+		return nil
+	}
+
+	// Pin the digest so that the manifest and the layers match the resolved
+	// descriptor even if the tag is moved in the meantime.
+	img, err := remote.Image(im.ref.Context().Digest(desc.Digest.String()), opts...)
+	if err != nil {
+		return fmt.Errorf("fetching %s: %w", im.ref, err)
+	}
+	manifest, err := img.Manifest()
+	if err != nil {
+		return fmt.Errorf("fetching manifest of %s: %w", im.ref, err)
+	}
+
+	content := make(map[string]string)
+	for _, l := range manifest.Layers {
+		title := l.Annotations[AnnotationTitle]
+		if title == "" || filepath.Base(title) != title {
+			continue
+		}
+		if args.Path != "" && title != args.Path {
+			continue
+		}
+		if args.Path == "" && !strings.HasSuffix(title, ".alloy") {
+			continue
+		}
+
+		layer, err := img.LayerByDigest(l.Digest)
+		if err != nil {
+			return fmt.Errorf("fetching layer %s: %w", title, err)
+		}
+		rc, err := layer.Compressed()
+		if err != nil {
+			return fmt.Errorf("fetching layer %s: %w", title, err)
+		}
+		bb, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return fmt.Errorf("reading layer %s: %w", title, err)
+		}
+		content[title] = string(bb)
+	}
+
+	if len(content) == 0 {
+		if args.Path != "" {
+			return fmt.Errorf("artifact %s has no layer titled %q", im.ref, args.Path)
+		}
+		return fmt.Errorf("artifact %s has no .alloy layer", im.ref)
+	}
+
+	if err := im.writeCache(args, desc.Digest.String(), content); err != nil {
+		level.Warn(im.log).Log("msg", "failed to cache module", "err", err)
+	}
+
+	im.digest = desc.Digest.String()
+	im.onContentChange(content)
+	return nil
+}
+
+type ociCacheMetadata struct {
+	Reference string `json:"reference"`
+	Path      string `json:"path"`
+	Digest    string `json:"digest"`
+}
+
+// writeCache replaces the cached module with content.
+func (im *ImportOCI) writeCache(args OCIArguments, digest string, content map[string]string) error {
+	tmpPath := im.cachePath + ".tmp"
+	if err := os.RemoveAll(tmpPath); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(tmpPath, 0750); err != nil {
+		return err
+	}
+
+	for title, data := range content {
+		if err := os.WriteFile(filepath.Join(tmpPath, title), []byte(data), 0640); err != nil {
+			return err
+		}
+	}
+
+	bb, err := json.Marshal(ociCacheMetadata{
+		Reference: args.Reference,
+		Path:      args.Path,
+		Digest:    digest,
+	})
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(tmpPath, ociCacheMetadataFile), bb, 0640); err != nil {
+		return err
+	}
+
+	if err := os.RemoveAll(im.cachePath); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, im.cachePath)
+}
+
+// loadCache updates the controller with the cached module if it was pulled
+// with the same arguments. loadCache must only be called with im.mut held.
+func (im *ImportOCI) loadCache(args OCIArguments) error {
+	bb, err := os.ReadFile(filepath.Join(im.cachePath, ociCacheMetadataFile))
+	if err != nil {
+		return fmt.Errorf("no cached module: %w", err)
+	}
+	var metadata ociCacheMetadata
+	if err := json.Unmarshal(bb, &metadata); err != nil {
+		return fmt.Errorf("decoding cache metadata: %w", err)
+	}
+	if metadata.Reference != args.Reference || metadata.Path != args.Path {
+		return fmt.Errorf("no cached module for %s", args.Reference)
+	}
+
+	entries, err := os.ReadDir(im.cachePath)
+	if err != nil {
+		return err
+	}
+	content := make(map[string]string)
+	for _, e := range entries {
+		if e.IsDir() || e.Name() == ociCacheMetadataFile {
+			continue
+		}
+		bb, err := os.ReadFile(filepath.Join(im.cachePath, e.Name()))
+		if err != nil {
+			return err
+		}
+		content[e.Name()] = string(bb)
+	}
+
+	im.digest = metadata.Digest
+	im.onContentChange(content)
+	return nil
+}
+
+// CurrentHealth implements component.HealthComponent.
+func (im *ImportOCI) CurrentHealth() component.Health {
+	im.healthMut.RLock()
+	defer im.healthMut.RUnlock()
+	return component.LeastHealthy(im.health, im.verifier.CurrentHealth())
+}
+
+// Update the evaluator.
+func (im *ImportOCI) SetEval(eval *vm.Evaluator) {
+	im.eval = eval
+}
+
+func (im *ImportOCI) ModulePath() string {
+	return im.cachePath
+}
@@ -0,0 +1,132 @@
+package importsource
+
+import (
+	"context"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/alloy/internal/component"
+	"github.com/grafana/alloy/internal/util"
+)
+
+func TestImportOCI_Cache(t *testing.T) {
+	srv := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+	defer srv.Close()
+
+	reference := strings.TrimPrefix(srv.URL, "http://") + "/modules/module:v1"
+	ref, err := name.ParseReference(reference)
+	require.NoError(t, err)
+	img, err := mutate.Append(empty.Image,
+		mutate.Addendum{
+			Layer:       static.NewLayer([]byte(`declare "a" {}`), types.MediaType("text/plain")),
+			Annotations: map[string]string{AnnotationTitle: "module.alloy"},
+		},
+		mutate.Addendum{
+			Layer:       static.NewLayer([]byte(`# readme`), types.MediaType("text/markdown")),
+			Annotations: map[string]string{AnnotationTitle: "README.md"},
+		},
+	)
+	require.NoError(t, err)
+	require.NoError(t, remote.Write(ref, img))
+
+	opts := component.Options{
+		Logger:   util.TestLogger(t),
+		DataPath: t.TempDir(),
+	}
+	args := OCIArguments{Reference: reference, PullFrequency: time.Minute, PullTimeout: 10 * time.Second}
+
+	var content map[string]string
+	im := NewImportOCI(opts, nil, func(m map[string]string) { content = m })
+	require.NoError(t, im.Update(args))
+	require.Equal(t, map[string]string{"module.alloy": `declare "a" {}`}, content)
+	require.Equal(t, component.HealthTypeHealthy, im.CurrentHealth().Health)
+
+	// The registry is gone, the module is loaded from the cache.
+	srv.Close()
+	content = nil
+	im = NewImportOCI(opts, nil, func(m map[string]string) { content = m })
+	require.NoError(t, im.Update(args))
+	require.Equal(t, map[string]string{"module.alloy": `declare "a" {}`}, content)
+	require.Equal(t, component.HealthTypeUnhealthy, im.CurrentHealth().Health)
+
+	// The cache can't be used for another file of the artifact.
+	args.Path = "README.md"
+	im = NewImportOCI(opts, nil, func(m map[string]string) { content = m })
+	require.ErrorContains(t, im.Update(args), "no cached module")
+}
+
+func TestImportOCI_PullTimeout(t *testing.T) {
+	var hang atomic.Bool
+	reg := registry.New(registry.Logger(log.New(io.Discard, "", 0)))
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if hang.Load() {
+			<-r.Context().Done()
+			return
+		}
+		reg.ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+
+	reference := strings.TrimPrefix(srv.URL, "http://") + "/modules/module:v1"
+	ref, err := name.ParseReference(reference)
+	require.NoError(t, err)
+	img, err := mutate.Append(empty.Image, mutate.Addendum{
+		Layer:       static.NewLayer([]byte(`declare "a" {}`), types.MediaType("text/plain")),
+		Annotations: map[string]string{AnnotationTitle: "module.alloy"},
+	})
+	require.NoError(t, err)
+	require.NoError(t, remote.Write(ref, img))
+
+	opts := component.Options{
+		Logger:   util.TestLogger(t),
+		DataPath: t.TempDir(),
+	}
+	args := OCIArguments{Reference: reference, PullFrequency: time.Minute, PullTimeout: 100 * time.Millisecond}
+
+	im := NewImportOCI(opts, nil, func(map[string]string) {})
+	require.NoError(t, im.Update(args))
+	require.Equal(t, component.HealthTypeHealthy, im.CurrentHealth().Health)
+
+	// Periodic pulls from a registry which doesn't answer fail after
+	// pull_timeout.
+	hang.Store(true)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		im.tickPull(context.Background())
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "pull didn't time out")
+	}
+	require.Equal(t, component.HealthTypeUnhealthy, im.CurrentHealth().Health)
+	require.Contains(t, im.CurrentHealth().Message, context.DeadlineExceeded.Error())
+
+	// So do the pulls made when the arguments are updated.
+	opts.DataPath = t.TempDir()
+	im = NewImportOCI(opts, nil, func(map[string]string) {})
+	errc := make(chan error, 1)
+	go func() { errc <- im.Update(args) }()
+	select {
+	case err := <-errc:
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "pull didn't time out")
+	}
+}
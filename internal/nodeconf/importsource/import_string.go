@@ -15,20 +15,25 @@ type ImportString struct {
 	arguments       StringArguments
 	eval            *vm.Evaluator
 	onContentChange func(map[string]string)
+	verifier        *verifier
 	modulePath      string
 }
 
 var _ ImportSource = (*ImportString)(nil)
 
 func NewImportString(eval *vm.Evaluator, onContentChange func(map[string]string)) *ImportString {
+	verifier := newVerifier(onContentChange)
 	return &ImportString{
 		eval:            eval,
-		onContentChange: onContentChange,
+		onContentChange: verifier.OnContentChange,
+		verifier:        verifier,
 	}
 }
 
 type StringArguments struct {
 	Content alloytypes.OptionalSecret `alloy:"content,attr"`
+	// Verify configures the verification of the module.
+	Verify *VerifyArguments `alloy:"verify,block,optional"`
 }
 
 func (im *ImportString) Evaluate(scope *vm.Scope) error {
@@ -37,6 +42,10 @@ func (im *ImportString) Evaluate(scope *vm.Scope) error {
 		return fmt.Errorf("decoding configuration: %w", err)
 	}
 
+	if err := im.verifier.Update(arguments.Verify); err != nil {
+		return fmt.Errorf("updating verification: %w", err)
+	}
+
 	if equality.DeepEqual(im.arguments, arguments) {
 		return nil
 	}
@@ -55,11 +64,9 @@ func (im *ImportString) Run(ctx context.Context) error {
 	return nil
 }
 
-// ImportString is always healthy
+// ImportString is always healthy, unless its content fails verification.
 func (im *ImportString) CurrentHealth() component.Health {
-	return component.Health{
-		Health: component.HealthTypeHealthy,
-	}
+	return im.verifier.CurrentHealth()
 }
 
 // Update the evaluator.
@@ -0,0 +1,127 @@
+package importsource
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// LockfileName is the name of the lockfile stored next to a configuration.
+const LockfileName = "alloy-modules.lock"
+
+// lockfileVersion is the version of the format of the lockfile.
+const lockfileVersion = 1
+
+// Lockfile pins the modules imported by import.git and import.http blocks to
+// an exact commit or content digest.
+type Lockfile struct {
+	Version int          `json:"version"`
+	Git     []LockedGit  `json:"git,omitempty"`
+	HTTP    []LockedHTTP `json:"http,omitempty"`
+
+	// dir is the directory of the lockfile. Vendor paths are relative to it.
+	dir string
+}
+
+// LockedGit pins a revision of a Git repository to a commit.
+type LockedGit struct {
+	Repository string `json:"repository"`
+	Revision   string `json:"revision"`
+	Commit     string `json:"commit"`
+	// Vendor is the path of a clone of the repository, relative to the
+	// lockfile.
+	Vendor string `json:"vendor,omitempty"`
+}
+
+// LockedHTTP pins the content served at a URL to its SHA-256 digest.
+type LockedHTTP struct {
+	URL    string `json:"url"`
+	SHA256 string `json:"sha256"`
+	// Vendor is the path of a copy of the content, relative to the lockfile.
+	Vendor string `json:"vendor,omitempty"`
+}
+
+// NewLockfile returns an empty lockfile stored in dir.
+func NewLockfile(dir string) *Lockfile {
+	return &Lockfile{Version: lockfileVersion, dir: dir}
+}
+
+// ReadLockfile reads the lockfile at path.
+func ReadLockfile(path string) (*Lockfile, error) {
+	bb, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	l := NewLockfile(filepath.Dir(path))
+	if err := json.Unmarshal(bb, l); err != nil {
+		return nil, fmt.Errorf("decoding lockfile %s: %w", path, err)
+	}
+	if l.Version != lockfileVersion {
+		return nil, fmt.Errorf("unsupported lockfile version %d in %s", l.Version, path)
+	}
+	return l, nil
+}
+
+// WriteFile writes the lockfile to path. The entries are sorted so that the
+// lockfile can be stored in version control.
+func (l *Lockfile) WriteFile(path string) error {
+	sort.Slice(l.Git, func(i, j int) bool {
+		if l.Git[i].Repository != l.Git[j].Repository {
+			return l.Git[i].Repository < l.Git[j].Repository
+		}
+		return l.Git[i].Revision < l.Git[j].Revision
+	})
+	sort.Slice(l.HTTP, func(i, j int) bool {
+		return l.HTTP[i].URL < l.HTTP[j].URL
+	})
+
+	bb, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(bb, '\n'), 0644)
+}
+
+// Dir returns the directory of the lockfile.
+func (l *Lockfile) Dir() string {
+	return l.dir
+}
+
+// VendorPath returns the location on disk of a vendored module.
+func (l *Lockfile) VendorPath(vendor string) string {
+	if filepath.IsAbs(vendor) {
+		return vendor
+	}
+	return filepath.Join(l.dir, vendor)
+}
+
+// LookupGit returns the entry pinning revision of repository. It's safe to
+// call LookupGit on a nil Lockfile.
+func (l *Lockfile) LookupGit(repository, revision string) (LockedGit, bool) {
+	if l == nil {
+		return LockedGit{}, false
+	}
+	for _, e := range l.Git {
+		if e.Repository == repository && e.Revision == revision {
+			return e, true
+		}
+	}
+	return LockedGit{}, false
+}
+
+// LookupHTTP returns the entry pinning the content served at url. It's safe
+// to call LookupHTTP on a nil Lockfile.
+func (l *Lockfile) LookupHTTP(url string) (LockedHTTP, bool) {
+	if l == nil {
+		return LockedHTTP{}, false
+	}
+	for _, e := range l.HTTP {
+		if e.URL == url {
+			return e, true
+		}
+	}
+	return LockedHTTP{}, false
+}
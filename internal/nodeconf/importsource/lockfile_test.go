@@ -0,0 +1,56 @@
+package importsource
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLockfile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, LockfileName)
+
+	lock := NewLockfile(dir)
+	lock.HTTP = []LockedHTTP{
+		{URL: "https://example.com/b.alloy", SHA256: "bb"},
+		{URL: "https://example.com/a.alloy", SHA256: "aa", Vendor: "alloy-modules/a.alloy"},
+	}
+	lock.Git = []LockedGit{
+		{Repository: "https://example.com/repo.git", Revision: "main", Commit: "c1"},
+	}
+	require.NoError(t, lock.WriteFile(path))
+
+	read, err := ReadLockfile(path)
+	require.NoError(t, err)
+	require.Equal(t, dir, read.Dir())
+
+	// Entries are sorted when written.
+	require.Equal(t, "https://example.com/a.alloy", read.HTTP[0].URL)
+
+	entry, ok := read.LookupHTTP("https://example.com/a.alloy")
+	require.True(t, ok)
+	require.Equal(t, "aa", entry.SHA256)
+	require.Equal(t, filepath.Join(dir, "alloy-modules", "a.alloy"), read.VendorPath(entry.Vendor))
+
+	gitEntry, ok := read.LookupGit("https://example.com/repo.git", "main")
+	require.True(t, ok)
+	require.Equal(t, "c1", gitEntry.Commit)
+
+	_, ok = read.LookupGit("https://example.com/repo.git", "dev")
+	require.False(t, ok)
+
+	// Lookups are safe on a nil lockfile.
+	var nilLock *Lockfile
+	_, ok = nilLock.LookupHTTP("https://example.com/a.alloy")
+	require.False(t, ok)
+}
+
+func TestReadLockfile_UnsupportedVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), LockfileName)
+	require.NoError(t, os.WriteFile(path, []byte(`{"version": 2}`), 0644))
+
+	_, err := ReadLockfile(path)
+	require.ErrorContains(t, err, "unsupported lockfile version 2")
+}
@@ -0,0 +1,275 @@
+package importsource
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/grafana/alloy/internal/component"
+	"github.com/grafana/alloy/internal/runtime/equality"
+	"github.com/grafana/alloy/syntax"
+)
+
+// VerifyArguments configures how the content of a module is verified before
+// it's loaded.
+type VerifyArguments struct {
+	// SHA256 is the expected hex-encoded SHA-256 digest of the module.
+	SHA256 string `alloy:"sha256,attr,optional"`
+	// PublicKey is the PEM-encoded ed25519 or ECDSA public key used to check
+	// Signature.
+	PublicKey string `alloy:"public_key,attr,optional"`
+	// Signature is the base64-encoded detached signature of the module.
+	Signature string `alloy:"signature,attr,optional"`
+}
+
+var _ syntax.Validator = (*VerifyArguments)(nil)
+
+// Validate implements syntax.Validator.
+func (args *VerifyArguments) Validate() error {
+	if args.SHA256 == "" && args.PublicKey == "" {
+		return fmt.Errorf("at least one of sha256 and public_key must be set")
+	}
+	if args.SHA256 != "" {
+		if bb, err := hex.DecodeString(args.SHA256); err != nil || len(bb) != sha256.Size {
+			return fmt.Errorf("sha256 must be a hex-encoded SHA-256 digest")
+		}
+	}
+	if (args.PublicKey == "") != (args.Signature == "") {
+		return fmt.Errorf("public_key and signature must be set together")
+	}
+	if args.PublicKey != "" {
+		if _, err := parsePublicKey(args.PublicKey); err != nil {
+			return err
+		}
+		if _, err := base64.StdEncoding.DecodeString(strings.TrimSpace(args.Signature)); err != nil {
+			return fmt.Errorf("signature must be base64-encoded: %w", err)
+		}
+	}
+	return nil
+}
+
+func parsePublicKey(s string) (crypto.PublicKey, error) {
+	block, _ := pem.Decode([]byte(s))
+	if block == nil {
+		return nil, fmt.Errorf("public_key must be PEM-encoded")
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parsing public_key: %w", err)
+	}
+	switch key.(type) {
+	case ed25519.PublicKey, *ecdsa.PublicKey:
+		return key, nil
+	default:
+		return nil, fmt.Errorf("public_key must be an ed25519 or ECDSA key, got %T", key)
+	}
+}
+
+// ModulePayload returns the bytes that the digest and the signature of a
+// module are computed over.
+//
+// For a module made of a single file, it's the content of the file. For a
+// module made of several files, it's the list of the SHA-256 digests of the
+// files sorted by their path relative to the directory of the module, in the
+// format of the sha256sum command.
+func ModulePayload(content map[string]string) []byte {
+	if len(content) == 1 {
+		for _, c := range content {
+			return []byte(c)
+		}
+	}
+
+	names := relativeNames(content)
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	var buf bytes.Buffer
+	for _, name := range sorted {
+		fmt.Fprintf(&buf, "%x  %s\n", sha256.Sum256([]byte(content[names[name]])), name)
+	}
+	return buf.Bytes()
+}
+
+// relativeNames maps the paths of the files of a module, relative to the
+// deepest directory which contains all of them, to their keys in content.
+// Paths use forward slashes on every platform.
+func relativeNames(content map[string]string) map[string]string {
+	var (
+		segments = make(map[string][]string, len(content))
+		common   []string
+	)
+	for k := range content {
+		segs := strings.Split(filepath.ToSlash(k), "/")
+		dir := segs[:len(segs)-1]
+		if common == nil {
+			common = dir
+		}
+		n := 0
+		for n < len(common) && n < len(dir) && common[n] == dir[n] {
+			n++
+		}
+		common = common[:n]
+		segments[k] = segs
+	}
+
+	names := make(map[string]string, len(content))
+	for k, segs := range segments {
+		names[strings.Join(segs[len(common):], "/")] = k
+	}
+	return names
+}
+
+// verifier sits between a source and the controller and only passes on the
+// content which passes verification. Content which fails verification is
+// never loaded: the module keeps the last verified content, if any, and the
+// failure is reported through the health of the source.
+type verifier struct {
+	onContentChange func(map[string]string)
+
+	mut       sync.Mutex
+	args      *VerifyArguments
+	publicKey crypto.PublicKey
+	content   map[string]string // Last content received from the source.
+
+	healthMut sync.RWMutex
+	health    component.Health
+}
+
+func newVerifier(onContentChange func(map[string]string)) *verifier {
+	return &verifier{
+		onContentChange: onContentChange,
+		health:          component.Health{Health: component.HealthTypeHealthy},
+	}
+}
+
+// resetHealth marks the verifier as healthy without taking precedence over
+// the health of the source.
+func (v *verifier) resetHealth() {
+	v.healthMut.Lock()
+	defer v.healthMut.Unlock()
+	v.health = component.Health{Health: component.HealthTypeHealthy}
+}
+
+// Update sets the verification settings. When they change, the last content
+// received from the source is verified again, so that a module and its
+// signature don't need to be updated at the same time.
+func (v *verifier) Update(args *VerifyArguments) error {
+	v.mut.Lock()
+	defer v.mut.Unlock()
+
+	if equality.DeepEqual(v.args, args) {
+		return nil
+	}
+
+	var publicKey crypto.PublicKey
+	if args != nil && args.PublicKey != "" {
+		var err error
+		if publicKey, err = parsePublicKey(args.PublicKey); err != nil {
+			return err
+		}
+	}
+	v.args, v.publicKey = args, publicKey
+
+	if v.content != nil {
+		v.process(v.content)
+	} else if args == nil {
+		v.resetHealth()
+	}
+	return nil
+}
+
+// OnContentChange is used by the source when it receives new content.
+func (v *verifier) OnContentChange(content map[string]string) {
+	v.mut.Lock()
+	defer v.mut.Unlock()
+
+	v.content = content
+	v.process(content)
+}
+
+// process must only be called with v.mut held.
+func (v *verifier) process(content map[string]string) {
+	if v.args == nil {
+		v.resetHealth()
+		v.onContentChange(content)
+		return
+	}
+
+	if err := v.verify(content); err != nil {
+		v.setHealth(component.HealthTypeUnhealthy, fmt.Sprintf("module verification failed: %s", err))
+		return
+	}
+	v.setHealth(component.HealthTypeHealthy, "module verified")
+	v.onContentChange(content)
+}
+
+func (v *verifier) verify(content map[string]string) error {
+	payload := ModulePayload(content)
+
+	if v.args.SHA256 != "" {
+		digest := sha256.Sum256(payload)
+		if actual := hex.EncodeToString(digest[:]); !strings.EqualFold(actual, v.args.SHA256) {
+			return fmt.Errorf("sha256 digest mismatch: expected %s, got %s", strings.ToLower(v.args.SHA256), actual)
+		}
+	}
+
+	if v.publicKey != nil {
+		signature, err := base64.StdEncoding.DecodeString(strings.TrimSpace(v.args.Signature))
+		if err != nil {
+			return fmt.Errorf("decoding signature: %w", err)
+		}
+		if err := verifySignature(v.publicKey, payload, signature); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func verifySignature(publicKey crypto.PublicKey, payload []byte, signature []byte) error {
+	var ok bool
+	switch key := publicKey.(type) {
+	case ed25519.PublicKey:
+		ok = ed25519.Verify(key, payload, signature)
+	case *ecdsa.PublicKey:
+		digest := sha256.Sum256(payload)
+		ok = ecdsa.VerifyASN1(key, digest[:], signature)
+	}
+	if !ok {
+		return errors.New("invalid signature")
+	}
+	return nil
+}
+
+func (v *verifier) setHealth(t component.HealthType, msg string) {
+	v.healthMut.Lock()
+	defer v.healthMut.Unlock()
+
+	v.health = component.Health{
+		Health:     t,
+		Message:    msg,
+		UpdateTime: time.Now(),
+	}
+}
+
+// CurrentHealth returns the health of the last verification.
+func (v *verifier) CurrentHealth() component.Health {
+	v.healthMut.RLock()
+	defer v.healthMut.RUnlock()
+	return v.health
+}
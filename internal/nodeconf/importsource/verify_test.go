@@ -0,0 +1,174 @@
+package importsource
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/alloy/internal/component"
+	"github.com/grafana/alloy/syntax"
+)
+
+func TestModulePayload(t *testing.T) {
+	require.Equal(t, []byte(`declare "a" {}`), ModulePayload(map[string]string{
+		"/etc/alloy/module.alloy": `declare "a" {}`,
+	}))
+
+	// Same output as `sha256sum a.alloy b.alloy`.
+	require.Equal(t,
+		"3a6eb0790f39ac87c94f3856b2dd2c5d110e6811602261a9a923d3bb23adc8b7  a.alloy\n"+
+			"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08  b.alloy\n",
+		string(ModulePayload(map[string]string{
+			"dir/b.alloy": "test",
+			"dir/a.alloy": "data",
+		})),
+	)
+
+	// Files with the same name in different directories are all covered.
+	require.Equal(t,
+		"3a6eb0790f39ac87c94f3856b2dd2c5d110e6811602261a9a923d3bb23adc8b7  a.alloy\n"+
+			"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08  sub/a.alloy\n",
+		string(ModulePayload(map[string]string{
+			"/etc/alloy/modules/a.alloy":     "data",
+			"/etc/alloy/modules/sub/a.alloy": "test",
+		})),
+	)
+}
+
+func TestVerifier_SHA256(t *testing.T) {
+	var loaded map[string]string
+	v := newVerifier(func(m map[string]string) { loaded = m })
+
+	module := map[string]string{"module.alloy": `declare "a" {}`}
+	digest := sha256.Sum256([]byte(module["module.alloy"]))
+	require.NoError(t, v.Update(&VerifyArguments{SHA256: hex.EncodeToString(digest[:])}))
+
+	v.OnContentChange(module)
+	require.Equal(t, module, loaded)
+	require.Equal(t, component.HealthTypeHealthy, v.CurrentHealth().Health)
+
+	// Tampered content is never loaded, the last verified content is kept.
+	v.OnContentChange(map[string]string{"module.alloy": `declare "b" {}`})
+	require.Equal(t, module, loaded)
+	health := v.CurrentHealth()
+	require.Equal(t, component.HealthTypeUnhealthy, health.Health)
+	require.Contains(t, health.Message, "module verification failed: sha256 digest mismatch")
+}
+
+func TestVerifier_Signature(t *testing.T) {
+	ed25519Pub, ed25519Priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	ecdsaPriv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	module := map[string]string{"module.alloy": `declare "a" {}`}
+	payload := ModulePayload(module)
+
+	ecdsaDigest := sha256.Sum256(payload)
+	ecdsaSig, err := ecdsa.SignASN1(rand.Reader, ecdsaPriv, ecdsaDigest[:])
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		publicKey string
+		signature []byte
+	}{
+		{
+			name:      "ed25519",
+			publicKey: encodePublicKey(t, ed25519Pub),
+			signature: ed25519.Sign(ed25519Priv, payload),
+		},
+		{
+			name:      "ecdsa",
+			publicKey: encodePublicKey(t, &ecdsaPriv.PublicKey),
+			signature: ecdsaSig,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var loaded map[string]string
+			v := newVerifier(func(m map[string]string) { loaded = m })
+
+			// The content is received before its signature, it isn't loaded until
+			// the signature is set.
+			require.NoError(t, v.Update(&VerifyArguments{
+				PublicKey: tc.publicKey,
+				Signature: base64.StdEncoding.EncodeToString([]byte("invalid")),
+			}))
+			v.OnContentChange(module)
+			require.Nil(t, loaded)
+			health := v.CurrentHealth()
+			require.Equal(t, component.HealthTypeUnhealthy, health.Health)
+			require.Equal(t, "module verification failed: invalid signature", health.Message)
+
+			require.NoError(t, v.Update(&VerifyArguments{
+				PublicKey: tc.publicKey,
+				Signature: base64.StdEncoding.EncodeToString(tc.signature),
+			}))
+			require.Equal(t, module, loaded)
+			require.Equal(t, component.HealthTypeHealthy, v.CurrentHealth().Health)
+		})
+	}
+}
+
+func TestVerifyArguments_Validate(t *testing.T) {
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		cfg         string
+		expectedErr string
+	}{
+		{
+			name:        "empty",
+			cfg:         ``,
+			expectedErr: "at least one of sha256 and public_key must be set",
+		},
+		{
+			name:        "invalid digest",
+			cfg:         `sha256 = "abcd"`,
+			expectedErr: "sha256 must be a hex-encoded SHA-256 digest",
+		},
+		{
+			name:        "missing signature",
+			cfg:         `public_key = ` + syntaxString(encodePublicKey(t, pub)),
+			expectedErr: "public_key and signature must be set together",
+		},
+		{
+			name: "invalid public key",
+			cfg: `
+				public_key = "key"
+				signature  = "c2lnbmF0dXJl"
+			`,
+			expectedErr: "public_key must be PEM-encoded",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var args VerifyArguments
+			require.ErrorContains(t, syntax.Unmarshal([]byte(tc.cfg), &args), tc.expectedErr)
+		})
+	}
+}
+
+func encodePublicKey(t *testing.T, key any) string {
+	bb, err := x509.MarshalPKIXPublicKey(key)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: bb}))
+}
+
+func syntaxString(s string) string {
+	return "`" + s + "`"
+}
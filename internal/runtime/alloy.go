@@ -115,6 +115,10 @@ type Options struct {
 	// EnableCommunityComps enables the use of community components.
 	EnableCommunityComps bool
 
+	// ModuleLock pins the modules imported by import.git and import.http
+	// blocks. It may be nil.
+	ModuleLock *importsource.Lockfile
+
 	// TaskShutdownDeadline is the maximum duration to wait for a component to shut down before giving up and logging an error.
 	TaskShutdownDeadline time.Duration
 }
@@ -208,6 +212,7 @@ func newController(o controllerOptions) *Runtime {
 			DataPath:             o.DataPath,
 			MinStability:         o.MinStability,
 			EnableCommunityComps: o.EnableCommunityComps,
+			ModuleLock:           o.ModuleLock,
 			OnBlockNodeUpdate: func(cn controller.BlockNode) {
 				// Changed node should be queued for reevaluation.
 				f.updateQueue.Enqueue(&controller.QueuedNode{Node: cn, LastUpdatedTime: time.Now()})
@@ -232,6 +237,7 @@ func newController(o controllerOptions) *Runtime {
 					DataPath:             o.DataPath,
 					MinStability:         o.MinStability,
 					EnableCommunityComps: o.EnableCommunityComps,
+					ModuleLock:           o.ModuleLock,
 					ID:                   opts.Id,
 					ServiceMap:           serviceMap,
 					WorkerPool:           workerPool,
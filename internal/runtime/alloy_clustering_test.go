@@ -0,0 +1,209 @@
+package runtime
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/grafana/ckit/peer"
+	"github.com/grafana/ckit/shard"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+
+	"github.com/grafana/alloy/internal/component"
+	"github.com/grafana/alloy/internal/featuregate"
+	"github.com/grafana/alloy/internal/runtime/internal/testservices"
+	"github.com/grafana/alloy/internal/service"
+)
+
+func TestSingletonClustering(t *testing.T) {
+	defer verifyNoGoroutineLeaks(t)
+
+	cluster := &fakeOwnership{ready: true}
+	builds, running, stop := runSingleton(t, cluster)
+	defer stop()
+
+	// The component isn't built on instances which don't own it.
+	require.Never(t, func() bool { return running.Load() > 0 }, 200*time.Millisecond, 10*time.Millisecond)
+	require.Equal(t, int64(0), builds.Load())
+
+	cluster.setOwner(true)
+	require.Eventually(t, func() bool { return running.Load() == 1 }, 5*time.Second, 10*time.Millisecond)
+
+	// The component stops when another instance takes over...
+	cluster.setOwner(false)
+	require.Eventually(t, func() bool { return running.Load() == 0 }, 5*time.Second, 10*time.Millisecond)
+
+	// ...and a new one is built when the component moves back.
+	cluster.setOwner(true)
+	require.Eventually(t, func() bool { return running.Load() == 1 }, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, int64(2), builds.Load())
+}
+
+func TestSingletonClustering_WaitsForReadyCluster(t *testing.T) {
+	defer verifyNoGoroutineLeaks(t)
+
+	// This instance owns the component but the cluster hasn't reached its
+	// minimum size yet.
+	cluster := &fakeOwnership{owner: true}
+	builds, running, stop := runSingleton(t, cluster)
+	defer stop()
+
+	require.Never(t, func() bool { return running.Load() > 0 }, 200*time.Millisecond, 10*time.Millisecond)
+	require.Equal(t, int64(0), builds.Load())
+
+	cluster.setReady(true)
+	require.Eventually(t, func() bool { return running.Load() == 1 }, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestSingletonClustering_UnexpectedServiceData(t *testing.T) {
+	defer verifyNoGoroutineLeaks(t)
+
+	// The component must not run on every instance when the cluster can't be
+	// used to pick the one it runs on.
+	builds, running, stop := runSingleton(t, struct{}{})
+	defer stop()
+	require.Never(t, func() bool { return running.Load() > 0 }, 200*time.Millisecond, 10*time.Millisecond)
+	require.Equal(t, int64(0), builds.Load())
+}
+
+// runSingleton runs a controller with a single component in singleton mode
+// and a cluster service exposing data. It returns the number of times the
+// component was built, the number of its instances which are running, and a
+// function which stops the controller.
+func runSingleton(t *testing.T, data any) (builds, running *atomic.Int64, stop func()) {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(t.Context())
+
+	builds = atomic.NewInt64(0)
+	running = atomic.NewInt64(0)
+
+	var (
+		clusterSvc = &testservices.Fake{
+			DefinitionFunc: func() service.Definition {
+				return service.Definition{Name: "cluster"}
+			},
+			DataFunc: func() any { return data },
+		}
+
+		registry = component.NewRegistryMap(
+			featuregate.StabilityExperimental,
+			true,
+			map[string]component.Registration{
+				"singleton": {
+					Name:      "singleton",
+					Stability: featuregate.StabilityGenerallyAvailable,
+					Args:      struct{}{},
+					Build: func(opts component.Options, args component.Arguments) (component.Component, error) {
+						builds.Inc()
+						return &runningComponent{running: running}, nil
+					},
+				},
+			},
+		)
+	)
+
+	f, err := ParseSource(t.Name(), []byte(`
+		singleton "example" {
+			clustering {
+				mode = "singleton"
+			}
+		}
+	`))
+	require.NoError(t, err)
+
+	opts := testOptions(t)
+	opts.MinStability = featuregate.StabilityExperimental
+	opts.Services = append(opts.Services, clusterSvc)
+
+	ctrl := newController(controllerOptions{
+		Options:           opts,
+		ComponentRegistry: registry,
+		ModuleRegistry:    newModuleRegistry(),
+	})
+	require.NoError(t, ctrl.LoadSource(f, nil, ""))
+
+	done := make(chan struct{})
+	go func() {
+		ctrl.Run(ctx)
+		close(done)
+	}()
+	return builds, running, func() {
+		cancel()
+		<-done
+	}
+}
+
+// fakeOwnership is a cluster where this instance either owns every key or
+// none of them.
+type fakeOwnership struct {
+	mut         sync.Mutex
+	owner       bool
+	ready       bool
+	subscribers []func()
+}
+
+func (c *fakeOwnership) Lookup(_ shard.Key, _ int, _ shard.Op) ([]peer.Peer, error) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	return []peer.Peer{{Name: "peer", Self: c.owner}}, nil
+}
+
+func (c *fakeOwnership) Peers() []peer.Peer {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	return []peer.Peer{{Name: "peer", Self: c.owner}}
+}
+
+func (c *fakeOwnership) Ready() bool {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	return c.ready
+}
+
+func (c *fakeOwnership) Subscribe(f func()) func() {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	c.subscribers = append(c.subscribers, f)
+	return func() {}
+}
+
+func (c *fakeOwnership) setOwner(owner bool) {
+	c.mut.Lock()
+	c.owner = owner
+	c.mut.Unlock()
+	c.notify()
+}
+
+func (c *fakeOwnership) setReady(ready bool) {
+	c.mut.Lock()
+	c.ready = ready
+	c.mut.Unlock()
+	c.notify()
+}
+
+func (c *fakeOwnership) notify() {
+	c.mut.Lock()
+	subscribers := c.subscribers
+	c.mut.Unlock()
+
+	for _, f := range subscribers {
+		f()
+	}
+}
+
+// runningComponent counts the instances which are running.
+type runningComponent struct {
+	running *atomic.Int64
+}
+
+func (c *runningComponent) Run(ctx context.Context) error {
+	c.running.Inc()
+	defer c.running.Dec()
+	<-ctx.Done()
+	return nil
+}
+
+func (c *runningComponent) Update(_ component.Arguments) error { return nil }
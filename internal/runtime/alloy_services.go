@@ -81,6 +81,7 @@ func (f *Runtime) NewController(id string) service.Controller {
 				Reg:                  f.opts.Reg,
 				Services:             f.opts.Services,
 				EnableCommunityComps: f.opts.EnableCommunityComps,
+				ModuleLock:           f.opts.ModuleLock,
 				OnExportsChange:      nil, // NOTE(@tpaschalis, @wildum) The isolated controller shouldn't be able to export any values.
 			},
 			IsModule:       true,
@@ -0,0 +1,29 @@
+package componenttest
+
+import (
+	"github.com/grafana/ckit/peer"
+	"github.com/grafana/ckit/shard"
+
+	"github.com/grafana/alloy/internal/service/cluster/clustertypes"
+)
+
+// singleNodeCluster implements clustertypes.Cluster for a cluster where the
+// component runs on the only node.
+type singleNodeCluster struct{}
+
+var _ clustertypes.Cluster = singleNodeCluster{}
+
+var self = peer.Peer{
+	Name:  "self",
+	Addr:  "127.0.0.1",
+	Self:  true,
+	State: peer.StateParticipant,
+}
+
+func (singleNodeCluster) Lookup(_ shard.Key, _ int, _ shard.Op) ([]peer.Peer, error) {
+	return []peer.Peer{self}, nil
+}
+
+func (singleNodeCluster) Peers() []peer.Peer { return []peer.Peer{self} }
+
+func (singleNodeCluster) Ready() bool { return true }
@@ -12,6 +12,7 @@ import (
 	"go.uber.org/atomic"
 
 	"github.com/grafana/alloy/internal/runtime/equality"
+	"github.com/grafana/alloy/internal/service/cluster/clustertypes"
 	"github.com/grafana/alloy/internal/service/labelstore"
 	"github.com/grafana/alloy/internal/service/livedebugging"
 
@@ -187,6 +188,8 @@ func (c *Controller) buildComponent(dataPath string, args component.Arguments, o
 				return labelstore.New(nil, prometheus.DefaultRegisterer), nil
 			case livedebugging.ServiceName:
 				return livedebugging.NewLiveDebugging(), nil
+			case clustertypes.ServiceName:
+				return singleNodeCluster{}, nil
 			default:
 				return nil, fmt.Errorf("no service named %s defined", name)
 			}
@@ -6,6 +6,7 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -15,6 +16,11 @@ import (
 	"time"
 
 	"github.com/grafana/alloy/internal/featuregate"
+	"github.com/grafana/alloy/internal/nodeconf/importsource"
+	alloy_runtime "github.com/grafana/alloy/internal/runtime"
+	"github.com/grafana/alloy/internal/runtime/internal/testservices"
+	"github.com/grafana/alloy/internal/runtime/logging"
+	"github.com/grafana/alloy/internal/service"
 	"github.com/grafana/alloy/internal/vcs"
 	"github.com/stretchr/testify/require"
 )
@@ -294,6 +300,119 @@ testImport.add "cc" {
 	}, 5*time.Second, 100*time.Millisecond)
 }
 
+func TestPullUpdatingFromLockfile(t *testing.T) {
+	testRepo := t.TempDir()
+
+	initializeRepo(t, testRepo)
+	runGit(t, testRepo, "checkout", "-b", "main")
+
+	math := filepath.Join(testRepo, "math.alloy")
+	err := os.WriteFile(math, []byte(contents), 0666)
+	require.NoError(t, err)
+
+	runGit(t, testRepo, "add", ".")
+
+	runGit(t, testRepo, "commit", "-m \"test\"")
+
+	getHead := exec.Command("git", "rev-parse", "HEAD")
+	var stdBuffer bytes.Buffer
+	getHead.Dir = testRepo
+	getHead.Stdout = bufio.NewWriter(&stdBuffer)
+	err = getHead.Run()
+	require.NoError(t, err)
+	hash := strings.TrimSpace(stdBuffer.String())
+
+	// After this update the sum should still be 2 and not 3 since main is
+	// pinned to the initial hash by the lockfile.
+	err = os.WriteFile(math, []byte(contentsMore), 0666)
+	require.NoError(t, err)
+
+	runGit(t, testRepo, "add", ".")
+
+	runGit(t, testRepo, "commit", "-m \"test2\"")
+
+	tests := []struct {
+		name        string
+		repository  string
+		lock        func(dir string) *importsource.Lockfile
+		expectedSum int
+	}{
+		{
+			name:       "pinned",
+			repository: testRepo,
+			lock: func(dir string) *importsource.Lockfile {
+				lock := importsource.NewLockfile(dir)
+				lock.Git = []importsource.LockedGit{{Repository: testRepo, Revision: "main", Commit: hash}}
+				return lock
+			},
+			expectedSum: 2,
+		},
+		{
+			name:       "vendored",
+			repository: "https://example.com/unreachable.git",
+			lock: func(dir string) *importsource.Lockfile {
+				require.NoError(t, os.MkdirAll(filepath.Join(dir, "vendor"), 0750))
+				require.NoError(t, os.WriteFile(filepath.Join(dir, "vendor", "math.alloy"), []byte(contentsMore), 0666))
+
+				lock := importsource.NewLockfile(dir)
+				lock.Git = []importsource.LockedGit{{Repository: "https://example.com/unreachable.git", Revision: "main", Commit: hash, Vendor: "vendor"}}
+				return lock
+			},
+			expectedSum: 3,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			main := `
+import.git "testImport" {
+	repository = "` + tc.repository + `"
+	path = "math.alloy"
+	pull_frequency = "1s"
+}
+
+testImport.add "cc" {
+	a = 1
+	b = 1
+}
+`
+			defer verifyNoGoroutineLeaks(t)
+
+			s, err := logging.New(io.Discard, logging.DefaultOptions)
+			require.NoError(t, err)
+			ctrl := alloy_runtime.New(alloy_runtime.Options{
+				Logger:       s,
+				DataPath:     t.TempDir(),
+				MinStability: featuregate.StabilityPublicPreview,
+				Services:     []service.Service{&testservices.Fake{}},
+				ModuleLock:   tc.lock(t.TempDir()),
+			})
+			f, err := alloy_runtime.ParseSource(t.Name(), []byte(main))
+			require.NoError(t, err)
+			err = ctrl.LoadSource(f, nil, "")
+			require.NoError(t, err)
+			ctx, cancel := context.WithCancel(t.Context())
+
+			var wg sync.WaitGroup
+			defer func() {
+				cancel()
+				wg.Wait()
+			}()
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				ctrl.Run(ctx)
+			}()
+
+			require.Eventually(t, func() bool {
+				export := getExport[map[string]interface{}](t, ctrl, "", "testImport.add.cc")
+				return export["sum"] == tc.expectedSum
+			}, 5*time.Second, 100*time.Millisecond)
+		})
+	}
+}
+
 func initializeRepo(t *testing.T, testRepo string) {
 	runGit(t, testRepo, "init", testRepo)
 	runGit(t, testRepo, "config", "user.email", "you@example.com")
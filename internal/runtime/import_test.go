@@ -2,8 +2,11 @@ package runtime_test
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"io/fs"
+	"log"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"strings"
@@ -11,11 +14,19 @@ import (
 	"testing"
 	"time"
 
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/tools/txtar"
 
 	"github.com/grafana/alloy/internal/featuregate"
+	"github.com/grafana/alloy/internal/nodeconf/importsource"
 	alloy_runtime "github.com/grafana/alloy/internal/runtime"
 	"github.com/grafana/alloy/internal/runtime/internal/testcomponents"
 	_ "github.com/grafana/alloy/internal/runtime/internal/testcomponents/module/string"
@@ -168,6 +179,89 @@ func TestImportHTTP(t *testing.T) {
 	}
 }
 
+func TestImportOCI(t *testing.T) {
+	directory := "./testdata/import_oci"
+	for _, file := range getTestFiles(directory, t) {
+		tc := buildTestImportFile(t, filepath.Join(directory, file.Name()))
+		t.Run(tc.description, func(t *testing.T) {
+			defer verifyNoGoroutineLeaks(t)
+
+			// The modules are pushed to an in-process registry. Every module is
+			// stored in its own repository, named after the module file.
+			srv := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+			defer srv.Close()
+			host := strings.TrimPrefix(srv.URL, "http://")
+
+			pushOCIModule(t, host, "module.alloy", tc.module)
+			if tc.nestedModule != "" {
+				pushOCIModule(t, host, "nested_module.alloy", tc.nestedModule)
+			}
+
+			var update func()
+			if tc.update != nil {
+				update = func() {
+					pushOCIModule(t, host, tc.update.name, tc.update.updateConfig)
+				}
+			}
+
+			testConfigWithStability(t, featuregate.StabilityExperimental,
+				strings.ReplaceAll(tc.main, "{{registry}}", host),
+				strings.ReplaceAll(tc.reloadConfig, "{{registry}}", host),
+				update)
+		})
+	}
+}
+
+func TestImportOCIError(t *testing.T) {
+	defer verifyNoGoroutineLeaks(t)
+
+	srv := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+	defer srv.Close()
+	host := strings.TrimPrefix(srv.URL, "http://")
+
+	pushOCIModule(t, host, "module.alloy", `
+import.file "nested" {
+  filename = "nested_module.alloy"
+}
+
+declare "a" {}
+`)
+
+	main := fmt.Sprintf(`
+import.oci "testImport" {
+  reference = "%s/modules/module:v1"
+}
+
+testImport.a "cc" {}
+`, host)
+
+	t.Run("nested import.file", func(t *testing.T) {
+		// The module is rejected, so the declare it contains isn't loaded.
+		testConfigErrorWithStability(t, featuregate.StabilityExperimental, main,
+			`custom component config not found in the registry, namespace: "testImport", componentName: "a"`)
+	})
+
+	t.Run("stability", func(t *testing.T) {
+		testConfigErrorWithStability(t, featuregate.StabilityPublicPreview, main,
+			`config block "import.oci" is at stability level "experimental", which is below the minimum allowed stability level "public-preview"`)
+	})
+}
+
+// pushOCIModule pushes a module artifact containing a single file to
+// <host>/modules/<name without extension>:v1. {{registry}} is replaced with
+// host in the content of the module.
+func pushOCIModule(t *testing.T, host string, filename string, content string) {
+	ref, err := name.ParseReference(fmt.Sprintf("%s/modules/%s:v1", host, strings.TrimSuffix(filename, ".alloy")))
+	require.NoError(t, err)
+
+	img, err := mutate.Append(empty.Image, mutate.Addendum{
+		Layer:       static.NewLayer([]byte(strings.ReplaceAll(content, "{{registry}}", host)), types.MediaType("text/plain")),
+		Annotations: map[string]string{importsource.AnnotationTitle: filename},
+	})
+	require.NoError(t, err)
+	require.NoError(t, remote.Write(ref, img))
+}
+
 type testImportFileFolder struct {
 	description  string      // description at the top of the txtar file
 	main         string      // root config that the controller should load
@@ -301,7 +395,11 @@ func TestImportError(t *testing.T) {
 
 func testConfig(t *testing.T, config string, reloadConfig string, update func()) {
 	defer verifyNoGoroutineLeaks(t)
-	ctrl, f := setup(t, config, nil, featuregate.StabilityPublicPreview)
+	testConfigWithStability(t, featuregate.StabilityPublicPreview, config, reloadConfig, update)
+}
+
+func testConfigWithStability(t *testing.T, stability featuregate.Stability, config string, reloadConfig string, update func()) {
+	ctrl, f := setup(t, config, nil, stability)
 
 	err := ctrl.LoadSource(f, nil, "")
 	require.NoError(t, err)
@@ -362,7 +460,11 @@ func testConfig(t *testing.T, config string, reloadConfig string, update func())
 
 func testConfigError(t *testing.T, config string, expectedError string) {
 	defer verifyNoGoroutineLeaks(t)
-	ctrl, f := setup(t, config, nil, featuregate.StabilityPublicPreview)
+	testConfigErrorWithStability(t, featuregate.StabilityPublicPreview, config, expectedError)
+}
+
+func testConfigErrorWithStability(t *testing.T, stability featuregate.Stability, config string, expectedError string) {
+	ctrl, f := setup(t, config, nil, stability)
 	err := ctrl.LoadSource(f, nil, "")
 	require.ErrorContains(t, err, expectedError)
 	ctx, cancel := context.WithCancel(t.Context())
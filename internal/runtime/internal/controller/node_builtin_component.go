@@ -10,6 +10,7 @@ import (
 	"reflect"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/go-kit/log"
@@ -18,6 +19,8 @@ import (
 
 	"github.com/grafana/alloy/internal/component"
 	"github.com/grafana/alloy/internal/featuregate"
+	"github.com/grafana/alloy/internal/nodeconf/clustering"
+	"github.com/grafana/alloy/internal/nodeconf/importsource"
 	"github.com/grafana/alloy/internal/runtime/equality"
 	"github.com/grafana/alloy/internal/runtime/logging"
 	"github.com/grafana/alloy/internal/runtime/tracing"
@@ -84,6 +87,7 @@ type ComponentGlobals struct {
 	NewModuleController  func(opts ModuleControllerOpts) ModuleController // Func to generate a module controller.
 	GetServiceData       func(name string) (interface{}, error)           // Get data for a service.
 	EnableCommunityComps bool                                             // Enables the use of community components.
+	ModuleLock           *importsource.Lockfile                           // Pins the modules imported by import.git and import.http blocks.
 }
 
 // BuiltinComponentNode is a controller node which manages a builtin component.
@@ -98,17 +102,28 @@ type BuiltinComponentNode struct {
 	componentName     string
 	nodeID            string // Cached from id.String() to avoid allocating new strings every time NodeID is called.
 	reg               component.Registration
+	globals           ComponentGlobals
 	managedOpts       component.Options
-	registry          *prometheus.Registry
+	registry          atomic.Pointer[prometheus.Registry]
 	exportsType       reflect.Type
 	moduleController  ModuleController
 	OnBlockNodeUpdate func(cn BlockNode) // Informs controller that we need to reevaluate
 
-	mut     sync.RWMutex
-	block   *ast.BlockStmt // Current Alloy block to derive args from
-	eval    *vm.Evaluator
-	managed component.Component // Inner managed component
-	args    component.Arguments // Evaluated arguments for the managed component
+	mut            sync.RWMutex
+	block          *ast.BlockStmt // Current Alloy block to derive args from
+	eval           *vm.Evaluator
+	clusteringEval *vm.Evaluator       // Evaluator of the clustering block handled by the controller, if any
+	managed        component.Component // Inner managed component
+	args           component.Arguments // Evaluated arguments for the managed component
+	evaluated      bool                // Whether Evaluate succeeded at least once
+
+	// scheduleMut guards the scheduling of components in singleton mode, see
+	// node_builtin_component_singleton.go.
+	scheduleMut sync.Mutex
+	singleton   bool
+	scheduled   bool               // Whether the managed component runs on this instance
+	rescheduled chan struct{}      // Signals changes of scheduled to Run
+	stopRun     context.CancelFunc // Stops the managed component when it's no longer scheduled
 
 	// NOTE(rfratto): health and exports have their own mutex because they may be
 	// set asynchronously while mut is still being held (i.e., when calling Evaluate
@@ -158,12 +173,15 @@ func NewBuiltinComponentNode(globals ComponentGlobals, reg component.Registratio
 		nodeID:            nodeID,
 		componentName:     strings.Join(b.Name, "."),
 		reg:               reg,
+		globals:           globals,
 		exportsType:       getExportsType(reg),
 		moduleController:  globals.NewModuleController(ModuleControllerOpts{Id: globalID}),
 		OnBlockNodeUpdate: globals.OnBlockNodeUpdate,
 
 		block: b,
-		eval:  vm.New(b.Body),
+
+		scheduled:   true,
+		rescheduled: make(chan struct{}, 1),
 
 		// Prepopulate arguments and exports with their zero values.
 		args:    reg.Args,
@@ -175,12 +193,14 @@ func NewBuiltinComponentNode(globals ComponentGlobals, reg component.Registratio
 		dataFlowEdgeRefs: []string{},
 	}
 	cn.managedOpts = getManagedOptions(globals, cn)
+	cn.setEvaluators(b)
 
 	return cn
 }
 
 func getManagedOptions(globals ComponentGlobals, cn *BuiltinComponentNode) component.Options {
-	cn.registry = prometheus.NewRegistry()
+	registry := prometheus.NewRegistry()
+	cn.registry.Store(registry)
 	parent, id := splitPath(cn.globalID)
 	return component.Options{
 		ID:     cn.globalID,
@@ -188,7 +208,7 @@ func getManagedOptions(globals ComponentGlobals, cn *BuiltinComponentNode) compo
 		Registerer: prometheus.WrapRegistererWith(prometheus.Labels{
 			"component_path": parent,
 			"component_id":   id,
-		}, cn.registry),
+		}, registry),
 		Tracer: tracing.WrapTracer(globals.TraceProvider, cn.globalID),
 
 		DataPath: filepath.Join(globals.DataPath, cn.globalID),
@@ -250,7 +270,18 @@ func (cn *BuiltinComponentNode) UpdateBlock(b *ast.BlockStmt) {
 	cn.mut.Lock()
 	defer cn.mut.Unlock()
 	cn.block = b
-	cn.eval = vm.New(b.Body)
+	cn.setEvaluators(b)
+}
+
+// setEvaluators creates the evaluators for the arguments of the managed
+// component and for the clustering block handled by the controller.
+func (cn *BuiltinComponentNode) setEvaluators(b *ast.BlockStmt) {
+	body, clusteringBlock := clustering.Split(cn.reg.Args, b.Body)
+	cn.eval = vm.New(body)
+	cn.clusteringEval = nil
+	if clusteringBlock != nil {
+		cn.clusteringEval = vm.New(clusteringBlock.Body)
+	}
 }
 
 // Evaluate implements BlockNode and updates the arguments for the managed component
@@ -276,6 +307,11 @@ func (cn *BuiltinComponentNode) evaluate(scope *vm.Scope) error {
 	cn.mut.Lock()
 	defer cn.mut.Unlock()
 
+	singleton, err := cn.evaluateClustering(scope)
+	if err != nil {
+		return err
+	}
+
 	argsPointer := cn.reg.CloneArguments()
 	if err := cn.eval.Evaluate(scope, argsPointer); err != nil {
 		return fmt.Errorf("decoding configuration: %w", err)
@@ -285,6 +321,14 @@ func (cn *BuiltinComponentNode) evaluate(scope *vm.Scope) error {
 	// components expect a non-pointer.
 	argsCopyValue := reflect.ValueOf(argsPointer).Elem().Interface()
 
+	if !cn.setSingleton(singleton) {
+		// The component runs on another instance of the cluster. It's built
+		// with the latest arguments if it's scheduled on this instance later.
+		cn.args = argsCopyValue
+		cn.evaluated = true
+		return nil
+	}
+
 	if cn.managed == nil {
 		// We haven't built the managed component successfully yet.
 		managed, err := cn.reg.Build(cn.managedOpts, argsCopyValue)
@@ -293,6 +337,7 @@ func (cn *BuiltinComponentNode) evaluate(scope *vm.Scope) error {
 		}
 		cn.managed = managed
 		cn.args = argsCopyValue
+		cn.evaluated = true
 
 		return nil
 	}
@@ -317,19 +362,43 @@ func (cn *BuiltinComponentNode) evaluate(scope *vm.Scope) error {
 // canceled. Evaluate must have been called at least once without returning an
 // error before calling Run.
 //
+// Components in singleton mode only run while they are scheduled on this
+// instance of the cluster, and stay idle otherwise.
+//
 // Run will immediately return ErrUnevaluated if Evaluate has never been called
 // successfully. Otherwise, Run will return nil.
 func (cn *BuiltinComponentNode) Run(ctx context.Context) error {
 	cn.mut.RLock()
-	managed := cn.managed
+	evaluated := cn.evaluated
 	cn.mut.RUnlock()
 
-	if managed == nil {
+	if !evaluated {
 		return ErrUnevaluated
 	}
 
-	cn.setRunHealth(component.HealthTypeHealthy, "started component")
-	err := cn.managed.Run(ctx)
+	if c, _ := cn.cluster(); c != nil {
+		unsubscribe := c.Subscribe(cn.reschedule)
+		defer unsubscribe()
+	}
+
+	var err error
+	for {
+		managed, runCtx, cancel, waitErr := cn.waitScheduled(ctx)
+		if waitErr != nil || managed == nil {
+			err = waitErr
+			break
+		}
+
+		cn.setRunHealth(component.HealthTypeHealthy, "started component")
+		err = managed.Run(runCtx)
+		descheduled := ctx.Err() == nil && runCtx.Err() != nil
+		cancel()
+
+		if !descheduled {
+			break
+		}
+		cn.discard(managed)
+	}
 
 	// Note: logging of this error is handled by the scheduler.
 	if err != nil {
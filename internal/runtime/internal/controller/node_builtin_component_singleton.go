@@ -0,0 +1,197 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/grafana/ckit/shard"
+
+	"github.com/grafana/alloy/internal/component"
+	"github.com/grafana/alloy/internal/featuregate"
+	"github.com/grafana/alloy/internal/nodeconf/clustering"
+	"github.com/grafana/alloy/internal/runtime/logging/level"
+	"github.com/grafana/alloy/internal/service/cluster/clustertypes"
+	"github.com/grafana/alloy/syntax/vm"
+)
+
+// clusterOwnership is the part of the cluster service data used to schedule
+// components in singleton mode.
+type clusterOwnership interface {
+	clustertypes.Cluster
+	clustertypes.Notifier
+}
+
+// cluster returns the cluster of the cluster service, or nil if the service
+// isn't available. An error is returned if the service data isn't a cluster.
+func (cn *BuiltinComponentNode) cluster() (clusterOwnership, error) {
+	if cn.globals.GetServiceData == nil {
+		return nil, nil
+	}
+	data, err := cn.globals.GetServiceData(clustertypes.ServiceName)
+	if err != nil || data == nil {
+		return nil, nil
+	}
+	c, ok := data.(clusterOwnership)
+	if !ok {
+		return nil, fmt.Errorf("unexpected data type %T for the %s service", data, clustertypes.ServiceName)
+	}
+	return c, nil
+}
+
+// evaluateClustering evaluates the clustering block handled by the
+// controller and returns whether the component runs in singleton mode.
+// evaluateClustering must only be called with cn.mut held.
+func (cn *BuiltinComponentNode) evaluateClustering(scope *vm.Scope) (bool, error) {
+	if cn.clusteringEval == nil {
+		return false, nil
+	}
+
+	if err := featuregate.CheckAllowed(clustering.Stability, cn.globals.MinStability, fmt.Sprintf("%s block", clustering.BlockName)); err != nil {
+		return false, err
+	}
+
+	var args clustering.Arguments
+	if err := cn.clusteringEval.Evaluate(scope, &args); err != nil {
+		return false, fmt.Errorf("decoding %s block: %w", clustering.BlockName, err)
+	}
+	return args.Mode == clustering.ModeSingleton, nil
+}
+
+// setSingleton sets whether the component runs in singleton mode and returns
+// whether the component is scheduled on this instance.
+func (cn *BuiltinComponentNode) setSingleton(singleton bool) bool {
+	cn.scheduleMut.Lock()
+	defer cn.scheduleMut.Unlock()
+
+	cn.singleton = singleton
+	return cn.updateScheduleLocked()
+}
+
+// reschedule is called when the cluster changes.
+func (cn *BuiltinComponentNode) reschedule() {
+	cn.scheduleMut.Lock()
+	defer cn.scheduleMut.Unlock()
+
+	cn.updateScheduleLocked()
+}
+
+// updateScheduleLocked determines whether the component runs on this
+// instance, and stops it when it's no longer the case. updateScheduleLocked
+// must only be called with cn.scheduleMut held.
+func (cn *BuiltinComponentNode) updateScheduleLocked() bool {
+	scheduled := !cn.singleton || cn.ownsComponent()
+	if scheduled == cn.scheduled {
+		return scheduled
+	}
+
+	cn.scheduled = scheduled
+	if cn.singleton {
+		level.Info(cn.managedOpts.Logger).Log("msg", "scheduling of singleton component changed", "scheduled", scheduled)
+	}
+	if !scheduled && cn.stopRun != nil {
+		cn.stopRun()
+		cn.stopRun = nil
+	}
+
+	select {
+	case cn.rescheduled <- struct{}{}:
+	default:
+	}
+	return scheduled
+}
+
+// ownsComponent returns whether this instance owns the component ID in the
+// cluster. Like other clustered work, singleton components don't run anywhere
+// until the cluster is ready. When the owner can't be determined, for example
+// while this instance hasn't joined the cluster yet, the component stays idle
+// until the next change to the cluster.
+func (cn *BuiltinComponentNode) ownsComponent() bool {
+	c, err := cn.cluster()
+	if err != nil {
+		level.Error(cn.managedOpts.Logger).Log("msg", "unable to schedule singleton component", "err", err)
+		return false
+	}
+	if c == nil {
+		return true
+	}
+	if !c.Ready() {
+		return false
+	}
+
+	peers, err := c.Lookup(shard.StringKey(cn.globalID), 1, shard.OpReadWrite)
+	if err == nil && len(peers) != 1 {
+		err = fmt.Errorf("unexpected peers from ownership check: %+v", peers)
+	}
+	if err != nil {
+		level.Warn(cn.managedOpts.Logger).Log("msg", "unable to determine the owner of singleton component", "err", err)
+		return false
+	}
+	return peers[0].Self
+}
+
+// waitScheduled waits until the component is scheduled on this instance and
+// returns the managed component to run with the context to run it with. The
+// context is canceled when the component is no longer scheduled. managed is
+// nil if ctx is canceled first.
+func (cn *BuiltinComponentNode) waitScheduled(ctx context.Context) (managed component.Component, runCtx context.Context, cancel context.CancelFunc, err error) {
+	for {
+		cn.scheduleMut.Lock()
+		scheduled := cn.scheduled
+		if scheduled {
+			runCtx, cancel = context.WithCancel(ctx)
+			cn.stopRun = cancel
+		}
+		cn.scheduleMut.Unlock()
+
+		if scheduled {
+			managed, err = cn.buildManaged()
+			if err != nil {
+				cancel()
+				return nil, nil, nil, err
+			}
+			return managed, runCtx, cancel, nil
+		}
+
+		cn.setRunHealth(component.HealthTypeHealthy, "component is idle: it runs on another instance of the cluster")
+		select {
+		case <-ctx.Done():
+			return nil, nil, nil, nil
+		case <-cn.rescheduled:
+		}
+	}
+}
+
+// buildManaged returns the managed component, and builds it first if it
+// isn't built yet.
+func (cn *BuiltinComponentNode) buildManaged() (component.Component, error) {
+	cn.mut.Lock()
+	defer cn.mut.Unlock()
+
+	if cn.managed != nil {
+		return cn.managed, nil
+	}
+	managed, err := cn.reg.Build(cn.managedOpts, cn.args)
+	if err != nil {
+		return nil, fmt.Errorf("building component: %w", err)
+	}
+	cn.managed = managed
+	return managed, nil
+}
+
+// discard drops a managed component which stopped running because it's no
+// longer scheduled on this instance. Components can't be run twice, so a new
+// one is built the next time the component is scheduled on this instance.
+func (cn *BuiltinComponentNode) discard(managed component.Component) {
+	cn.mut.Lock()
+	if cn.managed == managed {
+		cn.managed = nil
+		// Drop the metrics of the discarded component.
+		cn.managedOpts = getManagedOptions(cn.globals, cn)
+	}
+	cn.mut.Unlock()
+
+	// Dependants must not use the exports of the discarded component.
+	if cn.exportsType != nil {
+		cn.setExports(cn.reg.Exports)
+	}
+}
@@ -31,6 +31,7 @@ import (
 	"github.com/grafana/alloy/internal/featuregate"
 	"github.com/grafana/alloy/internal/runtime/logging/level"
 	"github.com/grafana/alloy/internal/service"
+	"github.com/grafana/alloy/internal/service/cluster/clustertypes"
 	"github.com/grafana/alloy/internal/service/cluster/discovery"
 	httpservice "github.com/grafana/alloy/internal/service/http"
 	"github.com/grafana/alloy/internal/service/remotecfg"
@@ -39,7 +40,7 @@ import (
 
 const (
 	// ServiceName defines the name used for the cluster service.
-	ServiceName = "cluster"
+	ServiceName = clustertypes.ServiceName
 
 	// tokensPerNode is used to decide how many tokens each node should be given in
 	// the hash ring. All nodes must use the same value, otherwise they will have
@@ -89,6 +90,13 @@ type Options struct {
 	MinimumClusterSize     int           // Minimum cluster size before admitting traffic to components that use clustering.
 	MinimumSizeWaitTimeout time.Duration // Maximum duration to wait for minimum cluster size before proceeding; 0 means no timeout.
 
+	// NodeWeight is the weight advertised to other nodes, which reflects the
+	// capacity of the node. Weights lower than 1 are treated as 1.
+	NodeWeight int
+	// EnableWeightedDistribution distributes targets in proportion to the
+	// weights of the nodes. All nodes of the cluster must use the same value.
+	EnableWeightedDistribution bool
+
 	// Function to discover peers to join. If this function is nil or returns an
 	// empty slice, no peers will be joined.
 	DiscoverPeers discovery.DiscoverFn
@@ -100,9 +108,12 @@ type Service struct {
 	tracer trace.TracerProvider
 	opts   Options
 
-	sharder shard.Sharder
-	node    *ckit.Node
-	randGen *rand.Rand
+	sharder    shard.Sharder
+	node       *ckit.Node
+	httpClient *http.Client
+	randGen    *rand.Rand
+
+	stateMetrics *stateMetrics
 
 	// alloyCluster is given to components via calls to Data() and implements Cluster.
 	alloyCluster *alloyCluster
@@ -130,8 +141,8 @@ type ComponentBlock struct {
 }
 
 var (
-	_ service.Service            = (*Service)(nil)
-	_ httpservice.ServiceHandler = (*Service)(nil)
+	_ service.Service           = (*Service)(nil)
+	_ httpservice.RoutesHandler = (*Service)(nil)
 )
 
 // New returns a new, unstarted instance of the cluster service.
@@ -201,11 +212,22 @@ func New(opts Options) (*Service, error) {
 
 		sharder:             ckitConfig.Sharder,
 		node:                node,
+		httpClient:          httpClient,
 		randGen:             rand.New(rand.NewSource(time.Now().UnixNano())),
+		stateMetrics:        newStateMetrics(),
 		notifyClusterChange: make(chan struct{}, 1),
 	}
 	s.alloyCluster = newAlloyCluster(ckitConfig.Sharder, s.triggerClusterChangeNotification, opts, l)
 
+	if opts.EnableClustering && opts.Metrics != nil {
+		if err := opts.Metrics.Register(s.alloyCluster.store.Metrics()); err != nil {
+			return nil, fmt.Errorf("failed to register metrics: %w", err)
+		}
+		if err := opts.Metrics.Register(s.stateMetrics.exchanges); err != nil {
+			return nil, fmt.Errorf("failed to register metrics: %w", err)
+		}
+	}
+
 	return s, nil
 }
 
@@ -268,14 +290,22 @@ func (s *Service) Definition() service.Definition {
 // resulting handler always returns 404 when clustering is disabled.
 func (s *Service) ServiceHandler(_ service.Host) (base string, handler http.Handler) {
 	base, handler = s.node.Handler()
+	return base, s.clusteringHandler(handler)
+}
+
+// Routes returns the handler on which nodes exchange their replicated state.
+// The handler always returns 404 when clustering is disabled.
+func (s *Service) Routes(_ service.Host) map[string]http.Handler {
+	return map[string]http.Handler{stateRoute: s.clusteringHandler(s.stateHandler())}
+}
 
+func (s *Service) clusteringHandler(handler http.Handler) http.Handler {
 	if !s.opts.EnableClustering {
-		handler = http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
 			http.Error(w, "clustering is disabled", http.StatusNotFound)
 		})
 	}
-
-	return base, handler
+	return handler
 }
 
 // ChangeState changes the state of the service. If clustering is enabled,
@@ -353,6 +383,26 @@ func (s *Service) Run(ctx context.Context, host service.Host) error {
 		}
 	}()
 
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		s.runStateExpiry(ctx)
+	}()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		s.runLoadAdvertiser(ctx, host)
+	}()
+
+	if s.opts.EnableClustering {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.runStateGossip(ctx)
+		}()
+	}
+
 	if s.opts.EnableClustering && s.opts.RejoinInterval > 0 {
 		wg.Add(1)
 
@@ -441,6 +491,11 @@ func (s *Service) notifyComponentsOfClusterChanges(ctx context.Context, limiter
 
 		subSpan.End()
 	}
+
+	// Notify the component controller, which schedules singleton components.
+	if ctx.Err() == nil {
+		s.alloyCluster.notifySubscribers()
+	}
 	span.End()
 }
 
@@ -102,6 +102,36 @@ func TestClusterE2E(t *testing.T) {
 				verifyLookupInvariants(t, state.peers)
 			},
 		},
+		{
+			name:             "replicated state converges",
+			alloyConfig:      `testcomponents.cluster_state_tracker "foo" {}`,
+			nodeCountInitial: 3,
+			assertionsInitial: func(t *assert.CollectT, state *testState) {
+				for _, p := range state.peers {
+					verifyPeers(t, p, 3)
+				}
+			},
+			changes: func(state *testState) {
+				for _, p := range state.peers {
+					p.clusterService.Data().(cluster.StateProvider).State("test").Add("requests", 2, 0)
+				}
+				state.peers[0].clusterService.Data().(cluster.StateProvider).State("test").Set("leader", "node-0", 0)
+			},
+			assertionsFinal: func(t *assert.CollectT, state *testState) {
+				for _, p := range state.peers {
+					ns := p.clusterService.Data().(cluster.StateProvider).State("test")
+					require.Equal(t, int64(6), ns.Counter("requests"))
+					value, ok := ns.Get("leader")
+					require.True(t, ok)
+					require.Equal(t, "node-0", value)
+					// Every node also advertises its weight and number of targets.
+					verifyMetrics(t, p,
+						`cluster_kv_entries{kind="counter"} 1`,
+						`cluster_kv_entries{kind="register"} 7`,
+					)
+				}
+			},
+		},
 		{
 			name:             "4 nodes are joined by another 4 nodes",
 			alloyConfig:      `testcomponents.cluster_state_tracker "foo" {}`,
@@ -10,6 +10,8 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/grafana/alloy/internal/runtime/logging/level"
+	"github.com/grafana/alloy/internal/service/cluster/clustertypes"
+	"github.com/grafana/alloy/internal/service/cluster/state"
 )
 
 type clusterState int
@@ -24,35 +26,10 @@ const (
 )
 
 // Cluster is a read-only view of a cluster.
-type Cluster interface {
-	// Lookup determines the set of replicationFactor owners for a given key.
-	// peer.Peer.Self can be used to determine if the local node is the owner,
-	// allowing for short-circuiting logic to connect directly to the local node
-	// instead of using the network.
-	//
-	// Callers can use github.com/grafana/ckit/shard.StringKey or
-	// shard.NewKeyBuilder to create a key.
-	//
-	// An error will be returned if the type of eligible peers for the provided
-	// op is less than numOwners.
-	//
-	// NOTE: If the cluster is not ready to accept traffic as designated by Ready, the local node should not accept
-	// traffic to prevent overload. Always use Ready to verify before assigning work to instance.
-	Lookup(key shard.Key, replicationFactor int, op shard.Op) ([]peer.Peer, error)
-
-	// Peers returns the current set of peers for a Node.
-	//
-	// NOTE: If the cluster is not ready to accept traffic as designated by Ready, the local node should not accept
-	// traffic to prevent overload. Always use Ready to verify before assigning work to instance.
-	Peers() []peer.Peer
-
-	// Ready returns true if the cluster is ready to accept traffic; otherwise, false. The cluster is ready to accept
-	// traffic when:
-	// - there is no minimum size requirement specified
-	// - there is a minimum size requirement and the cluster size is >= that size
-	// - there is a minimum size requirement and cluster size is too small, but the configured wait deadline has passed.
-	Ready() bool
-}
+type Cluster = clustertypes.Cluster
+
+// Notifier is implemented by the Cluster exposed by the cluster service.
+type Notifier = clustertypes.Notifier
 
 // alloyCluster implements the Cluster interface and manages the admission control logic.
 type alloyCluster struct {
@@ -63,12 +40,25 @@ type alloyCluster struct {
 	clusterChangeCallback func()
 	clusterReadyGauge     prometheus.Gauge
 
+	// store holds the key-value state replicated between the nodes.
+	store *state.Store
+
 	rwMutex       sync.RWMutex
 	deadlineTimer *time.Timer
 	clusterState  clusterState
+
+	subscribersMut sync.Mutex
+	subscribers    map[int]func()
+	nextSubscriber int
+
+	targetsMut sync.Mutex
+	targets    map[string]int // Number of targets owned by this node, by component ID.
 }
 
-var _ Cluster = (*alloyCluster)(nil)
+var (
+	_ Cluster  = (*alloyCluster)(nil)
+	_ Notifier = (*alloyCluster)(nil)
+)
 
 func newAlloyCluster(sharder shard.Sharder, clusterChangeCallback func(), opts Options, log log.Logger) *alloyCluster {
 	c := &alloyCluster{
@@ -76,6 +66,8 @@ func newAlloyCluster(sharder shard.Sharder, clusterChangeCallback func(), opts O
 		sharder:               sharder,
 		opts:                  opts,
 		clusterChangeCallback: clusterChangeCallback,
+		store:                 state.New(opts.NodeName),
+		targets:               make(map[string]int),
 	}
 
 	c.clusterReadyGauge = prometheus.NewGauge(prometheus.GaugeOpts{
@@ -94,8 +86,21 @@ func newAlloyCluster(sharder shard.Sharder, clusterChangeCallback func(), opts O
 		},
 	})
 
+	nodeWeightGauge := prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "cluster_node_weight",
+		Help: "The weight advertised by the node, used to distribute targets when weighted distribution is enabled.",
+		ConstLabels: prometheus.Labels{
+			"cluster_name": opts.ClusterName,
+		},
+	})
+	nodeWeightGauge.Set(float64(c.nodeWeight()))
+
 	// Register metrics if clustering is enabled and metrics are provided
 	if opts.EnableClustering && opts.Metrics != nil {
+		if err := opts.Metrics.Register(nodeWeightGauge); err != nil {
+			level.Warn(log).Log("msg", "failed to register node weight metric", "err", err)
+		}
+
 		if err := opts.Metrics.Register(minClusterSizeGauge); err != nil {
 			level.Warn(log).Log("msg", "failed to register minimum cluster size metric", "err", err)
 		} else {
@@ -239,6 +244,39 @@ func (c *alloyCluster) transitionToStateDeadlinePassed() {
 	c.clusterChangeCallback()
 }
 
+// Subscribe implements Notifier.
+func (c *alloyCluster) Subscribe(f func()) (unsubscribe func()) {
+	c.subscribersMut.Lock()
+	defer c.subscribersMut.Unlock()
+
+	if c.subscribers == nil {
+		c.subscribers = make(map[int]func())
+	}
+	id := c.nextSubscriber
+	c.nextSubscriber++
+	c.subscribers[id] = f
+
+	return func() {
+		c.subscribersMut.Lock()
+		defer c.subscribersMut.Unlock()
+		delete(c.subscribers, id)
+	}
+}
+
+// notifySubscribers calls the functions registered with Subscribe.
+func (c *alloyCluster) notifySubscribers() {
+	c.subscribersMut.Lock()
+	subscribers := make([]func(), 0, len(c.subscribers))
+	for _, f := range c.subscribers {
+		subscribers = append(subscribers, f)
+	}
+	c.subscribersMut.Unlock()
+
+	for _, f := range subscribers {
+		f()
+	}
+}
+
 func (c *alloyCluster) shutdown() {
 	c.rwMutex.Lock()
 	defer c.rwMutex.Unlock()
@@ -0,0 +1,196 @@
+package cluster
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"time"
+
+	"github.com/grafana/ckit/peer"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/grafana/alloy/internal/runtime/logging/level"
+	"github.com/grafana/alloy/internal/service/cluster/state"
+)
+
+const (
+	// stateRoute is the route on which nodes exchange their replicated state.
+	stateRoute = "/api/v1/cluster/state"
+
+	// stateGossipInterval is how frequently a node exchanges its replicated
+	// state with other nodes.
+	stateGossipInterval = time.Second
+
+	// stateGossipFanout is the number of random peers a node exchanges its
+	// replicated state with every stateGossipInterval.
+	stateGossipFanout = 3
+
+	// stateExpireInterval is how frequently expired entries are removed from
+	// the replicated state.
+	stateExpireInterval = 10 * time.Second
+
+	// maxStateSize is the maximum size of the replicated state accepted from
+	// other nodes.
+	maxStateSize = 16 << 20
+)
+
+// StateProvider is implemented by the Cluster exposed by the cluster service.
+// It gives components access to a key-value state replicated between every
+// node of the cluster.
+//
+// Updates are applied locally and propagated to other nodes in the
+// background, so different nodes may briefly observe different values.
+type StateProvider interface {
+	// State returns the replicated state of namespace. Components are
+	// expected to use their global ID as namespace.
+	State(namespace string) *state.Namespace
+
+	// StateInfo returns the entries of the replicated state for debugging.
+	StateInfo() []state.EntryInfo
+}
+
+var _ StateProvider = (*alloyCluster)(nil)
+
+// State implements StateProvider.
+func (c *alloyCluster) State(namespace string) *state.Namespace {
+	return c.store.Namespace(namespace)
+}
+
+// StateInfo implements StateProvider.
+func (c *alloyCluster) StateInfo() []state.EntryInfo {
+	return c.store.Info()
+}
+
+// stateMetrics holds the metrics of the state exchanges between nodes.
+type stateMetrics struct {
+	exchanges *prometheus.CounterVec
+}
+
+func newStateMetrics() *stateMetrics {
+	return &stateMetrics{
+		exchanges: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "cluster_kv_gossip_exchanges_total",
+			Help: "Total number of exchanges of the replicated key-value state initiated with other nodes, by result.",
+		}, []string{"result"}),
+	}
+}
+
+// stateHandler merges the state sent by another node and replies with the
+// state of this node.
+func (s *Service) stateHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		var remote map[string]*state.Entry
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxStateSize)).Decode(&remote); err != nil {
+			http.Error(w, fmt.Sprintf("failed to decode state: %s", err), http.StatusBadRequest)
+			return
+		}
+		s.alloyCluster.store.Merge(remote)
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(s.alloyCluster.store.Snapshot())
+	})
+}
+
+// runStateGossip periodically exchanges the replicated state with random
+// peers until ctx is canceled.
+func (s *Service) runStateGossip(ctx context.Context) {
+	t := time.NewTicker(stateGossipInterval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			for _, p := range s.randomStatePeers() {
+				if err := s.exchangeState(ctx, p); err != nil {
+					s.stateMetrics.exchanges.WithLabelValues("failure").Inc()
+					level.Debug(s.log).Log("msg", "failed to exchange replicated state", "peer", p.Name, "err", err)
+					continue
+				}
+				s.stateMetrics.exchanges.WithLabelValues("success").Inc()
+			}
+		}
+	}
+}
+
+// randomStatePeers returns up to stateGossipFanout random peers other than
+// this node.
+func (s *Service) randomStatePeers() []peer.Peer {
+	var peers []peer.Peer
+	for _, p := range s.node.Peers() {
+		if !p.Self {
+			peers = append(peers, p)
+		}
+	}
+	rand.Shuffle(len(peers), func(i, j int) {
+		peers[i], peers[j] = peers[j], peers[i]
+	})
+	return peers[:min(len(peers), stateGossipFanout)]
+}
+
+// exchangeState sends the state of this node to p and merges the state it
+// replies with.
+func (s *Service) exchangeState(ctx context.Context, p peer.Peer) error {
+	body, err := json.Marshal(s.alloyCluster.store.Snapshot())
+	if err != nil {
+		return err
+	}
+
+	scheme := "http"
+	if s.opts.EnableTLS {
+		scheme = "https"
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, stateGossipInterval)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, scheme+"://"+p.Addr+stateRoute, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, bytes.TrimSpace(msg))
+	}
+
+	var remote map[string]*state.Entry
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxStateSize)).Decode(&remote); err != nil {
+		return fmt.Errorf("failed to decode state: %w", err)
+	}
+	s.alloyCluster.store.Merge(remote)
+	return nil
+}
+
+// runStateExpiry periodically removes the expired entries of the replicated
+// state until ctx is canceled.
+func (s *Service) runStateExpiry(ctx context.Context) {
+	t := time.NewTicker(stateExpireInterval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			s.alloyCluster.store.Expire()
+		}
+	}
+}
@@ -3,7 +3,10 @@ package cluster
 import (
 	"fmt"
 	"math/rand"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -313,3 +316,54 @@ func newTestService(opts Options, peers []peer.Peer, callback func()) *Service {
 		sharder:      sharder,
 	}
 }
+
+func TestWeights(t *testing.T) {
+	peers := []peer.Peer{
+		{Name: "a", Self: true, State: peer.StateParticipant},
+		{Name: "b", State: peer.StateParticipant},
+		{Name: "c", State: peer.StateParticipant},
+		{Name: "d", State: peer.StateViewer},
+	}
+
+	s := newTestService(Options{NodeName: "a", NodeWeight: 4}, peers, func() {})
+	require.Nil(t, s.alloyCluster.Weights(), "weights must be nil when weighted distribution is disabled")
+
+	s = newTestService(Options{NodeName: "a", NodeWeight: 4, EnableWeightedDistribution: true}, peers, func() {})
+	s.alloyCluster.ReportTargets("prometheus.scrape.default", 10)
+	s.alloyCluster.ReportTargets("prometheus.scrape.removed", 5)
+	s.alloyCluster.advertiseLoad(func(id string) bool { return id == "prometheus.scrape.default" })
+	s.alloyCluster.State(loadNamespace).Set("weight/b", "2", 0)
+
+	// Peers which didn't advertise a weight get the average weight.
+	require.Equal(t, map[string]int{"a": 4, "b": 2, "c": 3}, s.alloyCluster.Weights())
+	require.Equal(t, map[string]Load{
+		"a": {Weight: 4, Targets: 10},
+		"b": {Weight: 2},
+		"c": {},
+		"d": {},
+	}, s.alloyCluster.Loads())
+}
+
+func TestServiceHandler(t *testing.T) {
+	s, err := New(Options{
+		EnableClustering: true,
+		NodeName:         "a",
+		AdvertiseAddress: "127.0.0.1:12345",
+	})
+	require.NoError(t, err)
+
+	// The cluster service claims the base route of the cluster transport, and
+	// serves the replicated state on a route of its own outside of it.
+	base, _ := s.ServiceHandler(nil)
+	ckitBase, _ := s.node.Handler()
+	require.Equal(t, ckitBase, base)
+
+	routes := s.Routes(nil)
+	require.Len(t, routes, 1)
+	require.Contains(t, routes, "/api/v1/cluster/state")
+	require.False(t, strings.HasPrefix(stateRoute, base), "the state route must not be nested under the transport")
+
+	rec := httptest.NewRecorder()
+	routes[stateRoute].ServeHTTP(rec, httptest.NewRequest(http.MethodPost, stateRoute, strings.NewReader("{}")))
+	require.Equal(t, http.StatusOK, rec.Code)
+}
@@ -0,0 +1,193 @@
+package cluster
+
+import (
+	"context"
+	"maps"
+	"strconv"
+	"time"
+
+	"github.com/grafana/ckit/peer"
+
+	"github.com/grafana/alloy/internal/component"
+	"github.com/grafana/alloy/internal/service"
+	"github.com/grafana/alloy/internal/service/cluster/state"
+	"github.com/grafana/alloy/internal/service/remotecfg"
+)
+
+const (
+	// loadNamespace is the namespace of the replicated state where nodes
+	// advertise their weight and number of targets. Component IDs always
+	// contain a dot, so it never collides with the namespace of a component.
+	loadNamespace = "cluster"
+
+	// loadAdvertiseInterval is how frequently a node advertises its weight and
+	// number of targets.
+	loadAdvertiseInterval = 15 * time.Second
+
+	// loadTTL is how long the weight and number of targets of a node are kept
+	// after the node stopped advertising them.
+	loadTTL = time.Minute
+)
+
+// Balancer is implemented by the Cluster exposed by the cluster service. It
+// exposes the weight advertised by each peer, which lets components
+// distribute work in proportion to the capacity of the peers.
+type Balancer interface {
+	// Weights returns the weight of each participant peer when weighted
+	// distribution is enabled, and nil otherwise. Peers which haven't
+	// advertised a weight yet get the average weight of the other peers.
+	Weights() map[string]int
+
+	// ReportTargets records the number of targets owned by this node for the
+	// component with the given ID. The total is advertised to other peers.
+	ReportTargets(componentID string, targets int)
+
+	// Loads returns the weight and the number of targets advertised by each
+	// peer.
+	Loads() map[string]Load
+}
+
+// Load is the weight and the number of targets advertised by a peer. Fields
+// are zero when the peer didn't advertise them.
+type Load struct {
+	Weight  int `json:"weight"`
+	Targets int `json:"targets"`
+}
+
+var _ Balancer = (*alloyCluster)(nil)
+
+// Weights implements Balancer.
+func (c *alloyCluster) Weights() map[string]int {
+	if !c.opts.EnableWeightedDistribution {
+		return nil
+	}
+
+	var (
+		peers   = c.sharder.Peers()
+		weights = make(map[string]int, len(peers))
+		ns      = c.store.Namespace(loadNamespace)
+
+		known, total int
+	)
+	for _, p := range peers {
+		if p.State != peer.StateParticipant {
+			continue
+		}
+		weights[p.Name] = advertisedInt(ns, "weight/"+p.Name)
+		if weights[p.Name] > 0 {
+			known++
+			total += weights[p.Name]
+		}
+	}
+
+	fallback := 1
+	if known > 0 {
+		fallback = max(1, total/known)
+	}
+	for name, w := range weights {
+		if w <= 0 {
+			weights[name] = fallback
+		}
+	}
+	return weights
+}
+
+// ReportTargets implements Balancer.
+func (c *alloyCluster) ReportTargets(componentID string, targets int) {
+	c.targetsMut.Lock()
+	defer c.targetsMut.Unlock()
+	c.targets[componentID] = targets
+}
+
+// Loads implements Balancer.
+func (c *alloyCluster) Loads() map[string]Load {
+	peers := c.sharder.Peers()
+	ns := c.store.Namespace(loadNamespace)
+
+	loads := make(map[string]Load, len(peers))
+	for _, p := range peers {
+		loads[p.Name] = Load{
+			Weight:  advertisedInt(ns, "weight/"+p.Name),
+			Targets: advertisedInt(ns, "targets/"+p.Name),
+		}
+	}
+	return loads
+}
+
+// advertiseLoad advertises the weight and the number of targets of this node.
+// Targets reported by components which don't exist anymore are forgotten.
+func (c *alloyCluster) advertiseLoad(exists func(componentID string) bool) {
+	c.targetsMut.Lock()
+	var total int
+	for id, n := range c.targets {
+		if !exists(id) {
+			delete(c.targets, id)
+			continue
+		}
+		total += n
+	}
+	c.targetsMut.Unlock()
+
+	ns := c.store.Namespace(loadNamespace)
+	ns.Set("weight/"+c.opts.NodeName, strconv.Itoa(c.nodeWeight()), loadTTL)
+	ns.Set("targets/"+c.opts.NodeName, strconv.Itoa(total), loadTTL)
+}
+
+func (c *alloyCluster) nodeWeight() int {
+	return max(1, c.opts.NodeWeight)
+}
+
+func advertisedInt(ns *state.Namespace, key string) int {
+	v, ok := ns.Get(key)
+	if !ok {
+		return 0
+	}
+	n, _ := strconv.Atoi(v)
+	return n
+}
+
+// runLoadAdvertiser periodically advertises the weight and the number of
+// targets of this node until ctx is canceled. Components are notified when
+// the weights of the peers change, so that they redistribute their targets.
+func (s *Service) runLoadAdvertiser(ctx context.Context, host service.Host) {
+	advertise := time.NewTicker(loadAdvertiseInterval)
+	defer advertise.Stop()
+	watch := time.NewTicker(stateGossipInterval)
+	defer watch.Stop()
+
+	s.alloyCluster.advertiseLoad(componentExists(host))
+	weights := s.alloyCluster.Weights()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-advertise.C:
+			s.alloyCluster.advertiseLoad(componentExists(host))
+		case <-watch.C:
+			if w := s.alloyCluster.Weights(); !maps.Equal(w, weights) {
+				weights = w
+				s.triggerClusterChangeNotification()
+			}
+		}
+	}
+}
+
+// componentExists returns a function reporting whether a component with the
+// given global ID is running, either from the main configuration or from the
+// remote configuration.
+func componentExists(host service.Host) func(componentID string) bool {
+	components := component.GetAllComponents(host, component.InfoOptions{})
+	if remoteCfgHost, err := remotecfg.GetHost(host); err == nil {
+		components = append(components, component.GetAllComponents(remoteCfgHost, component.InfoOptions{})...)
+	}
+
+	ids := make(map[string]struct{}, len(components))
+	for _, comp := range components {
+		ids[comp.ID.String()] = struct{}{}
+	}
+	return func(componentID string) bool {
+		_, ok := ids[componentID]
+		return ok
+	}
+}
@@ -0,0 +1,53 @@
+// Package clustertypes holds the name and the interfaces of the cluster
+// service. It has no dependencies on the rest of Alloy, so that packages which
+// can't import the cluster service, such as the component controller, can
+// still look it up.
+package clustertypes
+
+import (
+	"github.com/grafana/ckit/peer"
+	"github.com/grafana/ckit/shard"
+)
+
+// ServiceName defines the name used for the cluster service.
+const ServiceName = "cluster"
+
+// Cluster is a read-only view of a cluster.
+type Cluster interface {
+	// Lookup determines the set of replicationFactor owners for a given key.
+	// peer.Peer.Self can be used to determine if the local node is the owner,
+	// allowing for short-circuiting logic to connect directly to the local node
+	// instead of using the network.
+	//
+	// Callers can use github.com/grafana/ckit/shard.StringKey or
+	// shard.NewKeyBuilder to create a key.
+	//
+	// An error will be returned if the type of eligible peers for the provided
+	// op is less than numOwners.
+	//
+	// NOTE: If the cluster is not ready to accept traffic as designated by Ready, the local node should not accept
+	// traffic to prevent overload. Always use Ready to verify before assigning work to instance.
+	Lookup(key shard.Key, replicationFactor int, op shard.Op) ([]peer.Peer, error)
+
+	// Peers returns the current set of peers for a Node.
+	//
+	// NOTE: If the cluster is not ready to accept traffic as designated by Ready, the local node should not accept
+	// traffic to prevent overload. Always use Ready to verify before assigning work to instance.
+	Peers() []peer.Peer
+
+	// Ready returns true if the cluster is ready to accept traffic; otherwise, false. The cluster is ready to accept
+	// traffic when:
+	// - there is no minimum size requirement specified
+	// - there is a minimum size requirement and the cluster size is >= that size
+	// - there is a minimum size requirement and cluster size is too small, but the configured wait deadline has passed.
+	Ready() bool
+}
+
+// Notifier is implemented by the Cluster exposed by the cluster service. It
+// lets the component controller follow changes to the cluster without being
+// a cluster.Component.
+type Notifier interface {
+	// Subscribe registers f to be called every time components are notified of
+	// a change to the cluster. The returned function unregisters f.
+	Subscribe(f func()) (unsubscribe func())
+}
@@ -0,0 +1,40 @@
+package state
+
+import "github.com/prometheus/client_golang/prometheus"
+
+type metrics struct {
+	entries        *prometheus.GaugeVec
+	mergedEntries  prometheus.Counter
+	expiredEntries prometheus.Counter
+}
+
+var _ prometheus.Collector = (*metrics)(nil)
+
+func newMetrics() *metrics {
+	return &metrics{
+		entries: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "cluster_kv_entries",
+			Help: "Number of entries in the replicated key-value state of the cluster, by kind.",
+		}, []string{"kind"}),
+		mergedEntries: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "cluster_kv_merged_entries_total",
+			Help: "Total number of entries of the replicated key-value state updated by merges with other nodes.",
+		}),
+		expiredEntries: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "cluster_kv_expired_entries_total",
+			Help: "Total number of entries of the replicated key-value state removed after they expired.",
+		}),
+	}
+}
+
+func (m *metrics) Describe(ch chan<- *prometheus.Desc) {
+	m.entries.Describe(ch)
+	m.mergedEntries.Describe(ch)
+	m.expiredEntries.Describe(ch)
+}
+
+func (m *metrics) Collect(ch chan<- prometheus.Metric) {
+	m.entries.Collect(ch)
+	m.mergedEntries.Collect(ch)
+	m.expiredEntries.Collect(ch)
+}
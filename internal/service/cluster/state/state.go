@@ -0,0 +1,417 @@
+// Package state implements the key-value state which the cluster service
+// replicates between the nodes of a cluster.
+//
+// Values are conflict-free replicated data types (CRDTs): nodes exchange their
+// whole state with random peers and merge it with their own, and every node
+// converges to the same value regardless of the order of the exchanges.
+package state
+
+import (
+	"maps"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Kind is the kind of value held by an entry.
+type Kind string
+
+// Supported kinds of values.
+const (
+	// KindCounter is a counter which can be incremented and decremented by
+	// every node (PN-counter).
+	KindCounter Kind = "counter"
+
+	// KindRegister is a string value where the last write wins (LWW register).
+	KindRegister Kind = "register"
+)
+
+// tombstoneRetention is how long deleted registers and expired counters are
+// kept around, so that the deletion reaches every node before the register is
+// dropped, and a counter which is reset starts a newer epoch than the copies
+// of other nodes.
+const tombstoneRetention = 5 * time.Minute
+
+// Entry is the replicated state of a single key.
+type Entry struct {
+	Kind Kind `json:"kind"`
+
+	// Increments and Decrements hold the sum of the changes made to a counter
+	// by each node.
+	Increments map[string]int64 `json:"increments,omitempty"`
+	Decrements map[string]int64 `json:"decrements,omitempty"`
+
+	// Epoch is incremented every time a counter is reset after it expired.
+	// A counter of a newer epoch replaces the counters of older epochs, so
+	// that nodes which didn't see the counter expire yet don't undo the reset.
+	Epoch int64 `json:"epoch,omitempty"`
+
+	// Value, Deleted, Timestamp and Node hold the last write to a register.
+	// Writes are ordered by Timestamp, and then by Node.
+	Value     string `json:"value,omitempty"`
+	Deleted   bool   `json:"deleted,omitempty"`
+	Timestamp int64  `json:"timestamp,omitempty"`
+	Node      string `json:"node,omitempty"`
+
+	// Expires is the time at which the entry expires, in Unix nanoseconds.
+	// Entries without an expiration time never expire.
+	Expires int64 `json:"expires,omitempty"`
+}
+
+func (e *Entry) expired(now int64) bool {
+	return e.Expires != 0 && e.Expires <= now
+}
+
+func (e *Entry) counterValue() int64 {
+	var v int64
+	for _, inc := range e.Increments {
+		v += inc
+	}
+	for _, dec := range e.Decrements {
+		v -= dec
+	}
+	return v
+}
+
+func (e *Entry) clone() *Entry {
+	c := *e
+	c.Increments = maps.Clone(e.Increments)
+	c.Decrements = maps.Clone(e.Decrements)
+	return &c
+}
+
+// merge merges remote into e and returns whether e changed.
+func (e *Entry) merge(remote *Entry) bool {
+	if e.Kind != remote.Kind {
+		// Keys are expected to hold a single kind of value. Conflicts are
+		// resolved deterministically in favor of counters.
+		if remote.Kind == KindCounter {
+			*e = *remote.clone()
+			return true
+		}
+		return false
+	}
+
+	switch e.Kind {
+	case KindCounter:
+		switch {
+		case remote.Epoch > e.Epoch:
+			*e = *remote.clone()
+			return true
+		case remote.Epoch < e.Epoch:
+			return false
+		}
+		changed := mergeMax(&e.Increments, remote.Increments)
+		changed = mergeMax(&e.Decrements, remote.Decrements) || changed
+		// Entries without an expiration time take precedence.
+		if e.Expires != 0 && (remote.Expires == 0 || remote.Expires > e.Expires) {
+			e.Expires = remote.Expires
+			changed = true
+		}
+		return changed
+	case KindRegister:
+		if remote.Timestamp > e.Timestamp || (remote.Timestamp == e.Timestamp && remote.Node > e.Node) {
+			*e = *remote.clone()
+			return true
+		}
+		return false
+	default:
+		return false
+	}
+}
+
+func mergeMax(dst *map[string]int64, src map[string]int64) bool {
+	var changed bool
+	for node, v := range src {
+		if cur, ok := (*dst)[node]; ok && cur >= v {
+			continue
+		}
+		if *dst == nil {
+			*dst = make(map[string]int64, len(src))
+		}
+		(*dst)[node] = v
+		changed = true
+	}
+	return changed
+}
+
+// Store holds the replicated state of a node.
+type Store struct {
+	node    string
+	now     func() time.Time
+	metrics *metrics
+
+	mut     sync.RWMutex
+	entries map[string]*Entry
+}
+
+// New creates a Store for the node with the given name.
+func New(node string) *Store {
+	return &Store{
+		node:    node,
+		now:     time.Now,
+		metrics: newMetrics(),
+		entries: make(map[string]*Entry),
+	}
+}
+
+// Metrics returns the metrics of the Store.
+func (s *Store) Metrics() prometheus.Collector { return s.metrics }
+
+// Namespace returns the view of the state for a namespace. Components are
+// expected to use their ID as namespace.
+func (s *Store) Namespace(namespace string) *Namespace {
+	return &Namespace{store: s, prefix: namespace + "/"}
+}
+
+// Snapshot returns a copy of the entries which haven't expired.
+func (s *Store) Snapshot() map[string]*Entry {
+	s.mut.RLock()
+	defer s.mut.RUnlock()
+
+	now := s.now().UnixNano()
+	snapshot := make(map[string]*Entry, len(s.entries))
+	for key, e := range s.entries {
+		if !e.expired(now) {
+			snapshot[key] = e.clone()
+		}
+	}
+	return snapshot
+}
+
+// Merge merges the entries received from another node into the Store.
+func (s *Store) Merge(remote map[string]*Entry) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	now := s.now().UnixNano()
+	var changed int
+	for key, e := range remote {
+		if e == nil || e.expired(now) {
+			continue
+		}
+		cur, ok := s.entries[key]
+		if !ok {
+			s.entries[key] = e.clone()
+			changed++
+			continue
+		}
+		if cur.merge(e) {
+			changed++
+		}
+	}
+
+	s.metrics.mergedEntries.Add(float64(changed))
+	s.updateEntriesMetric()
+}
+
+// Expire removes the entries which expired. Expired counters are kept for
+// tombstoneRetention to remember their epoch.
+func (s *Store) Expire() {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	now := s.now().UnixNano()
+	for key, e := range s.entries {
+		if !e.expired(now) {
+			continue
+		}
+		if e.Kind == KindCounter && e.Expires+int64(tombstoneRetention) > now {
+			continue
+		}
+		delete(s.entries, key)
+		s.metrics.expiredEntries.Inc()
+	}
+	s.updateEntriesMetric()
+}
+
+// EntryInfo describes an entry for debugging purposes.
+type EntryInfo struct {
+	Key       string    `json:"key"`
+	Kind      Kind      `json:"kind"`
+	Value     string    `json:"value"`
+	UpdatedBy []string  `json:"updatedBy"`
+	ExpiresAt time.Time `json:"expiresAt,omitzero"`
+}
+
+// Info returns information about the live entries of the Store, sorted by
+// key.
+func (s *Store) Info() []EntryInfo {
+	snapshot := s.Snapshot()
+
+	infos := make([]EntryInfo, 0, len(snapshot))
+	for key, e := range snapshot {
+		info := EntryInfo{Key: key, Kind: e.Kind}
+		if e.Expires != 0 {
+			info.ExpiresAt = time.Unix(0, e.Expires)
+		}
+
+		switch e.Kind {
+		case KindCounter:
+			info.Value = strconv.FormatInt(e.counterValue(), 10)
+			nodes := make(map[string]struct{})
+			for node := range e.Increments {
+				nodes[node] = struct{}{}
+			}
+			for node := range e.Decrements {
+				nodes[node] = struct{}{}
+			}
+			for node := range nodes {
+				info.UpdatedBy = append(info.UpdatedBy, node)
+			}
+			sort.Strings(info.UpdatedBy)
+		case KindRegister:
+			if e.Deleted {
+				continue
+			}
+			info.Value = e.Value
+			info.UpdatedBy = []string{e.Node}
+		}
+		infos = append(infos, info)
+	}
+
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Key < infos[j].Key })
+	return infos
+}
+
+// update applies f to the entry of key, creating the entry first if it
+// doesn't exist or expired. A counter created in place of an expired one
+// starts the next epoch.
+func (s *Store) update(key string, kind Kind, f func(e *Entry, now time.Time, created bool)) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	now := s.now()
+	e, ok := s.entries[key]
+	created := !ok || e.expired(now.UnixNano()) || e.Kind != kind
+	if created {
+		next := &Entry{Kind: kind}
+		if ok && e.Kind == KindCounter && kind == KindCounter {
+			next.Epoch = e.Epoch + 1
+		}
+		e = next
+		s.entries[key] = e
+	}
+	f(e, now, created)
+	s.updateEntriesMetric()
+}
+
+// get returns a copy of the live entry of key.
+func (s *Store) get(key string, kind Kind) (*Entry, bool) {
+	s.mut.RLock()
+	defer s.mut.RUnlock()
+
+	e, ok := s.entries[key]
+	if !ok || e.Kind != kind || e.expired(s.now().UnixNano()) {
+		return nil, false
+	}
+	return e.clone(), true
+}
+
+// updateEntriesMetric must only be called with s.mut held.
+func (s *Store) updateEntriesMetric() {
+	now := s.now().UnixNano()
+	counts := map[Kind]int{KindCounter: 0, KindRegister: 0}
+	for _, e := range s.entries {
+		if !e.expired(now) {
+			counts[e.Kind]++
+		}
+	}
+	for kind, count := range counts {
+		s.metrics.entries.WithLabelValues(string(kind)).Set(float64(count))
+	}
+}
+
+// Namespace is the view of the replicated state for a namespace. Keys of
+// different namespaces never collide.
+type Namespace struct {
+	store  *Store
+	prefix string
+}
+
+// Add adds delta to the counter of key and returns its new value as seen by
+// this node. When ttl isn't zero, the counter is reset once ttl elapsed
+// without any other call to Add. A ttl of zero keeps the counter forever.
+func (n *Namespace) Add(key string, delta int64, ttl time.Duration) int64 {
+	var value int64
+	n.store.update(n.prefix+key, KindCounter, func(e *Entry, now time.Time, created bool) {
+		switch {
+		case delta > 0:
+			mergeMax(&e.Increments, map[string]int64{n.store.node: e.Increments[n.store.node] + delta})
+		case delta < 0:
+			mergeMax(&e.Decrements, map[string]int64{n.store.node: e.Decrements[n.store.node] - delta})
+		}
+		switch {
+		case ttl == 0:
+			e.Expires = 0
+		case created || e.Expires != 0:
+			e.Expires = max(e.Expires, now.Add(ttl).UnixNano())
+		}
+		value = e.counterValue()
+	})
+	return value
+}
+
+// Counter returns the value of the counter of key, which is the sum of the
+// changes made by every node which reached this node.
+func (n *Namespace) Counter(key string) int64 {
+	e, ok := n.store.get(n.prefix+key, KindCounter)
+	if !ok {
+		return 0
+	}
+	return e.counterValue()
+}
+
+// Set sets the register of key to value. When ttl isn't zero, the register is
+// removed once ttl elapsed. A ttl of zero keeps the register forever.
+func (n *Namespace) Set(key string, value string, ttl time.Duration) {
+	n.store.update(n.prefix+key, KindRegister, func(e *Entry, now time.Time, _ bool) {
+		n.write(e, now, value, false, ttl)
+	})
+}
+
+// Get returns the value of the register of key.
+func (n *Namespace) Get(key string) (string, bool) {
+	e, ok := n.store.get(n.prefix+key, KindRegister)
+	if !ok || e.Deleted {
+		return "", false
+	}
+	return e.Value, true
+}
+
+// Delete deletes the register of key.
+func (n *Namespace) Delete(key string) {
+	n.store.update(n.prefix+key, KindRegister, func(e *Entry, now time.Time, _ bool) {
+		n.write(e, now, "", true, tombstoneRetention)
+	})
+}
+
+func (n *Namespace) write(e *Entry, now time.Time, value string, deleted bool, ttl time.Duration) {
+	// Writes made by this node must be ordered even if the clock goes
+	// backwards.
+	ts := now.UnixNano()
+	if ts <= e.Timestamp {
+		ts = e.Timestamp + 1
+	}
+
+	e.Value, e.Deleted, e.Timestamp, e.Node = value, deleted, ts, n.store.node
+	e.Expires = 0
+	if ttl != 0 {
+		e.Expires = now.Add(ttl).UnixNano()
+	}
+}
+
+// Keys returns the keys of the namespace which hold a live value.
+func (n *Namespace) Keys() []string {
+	var keys []string
+	for _, info := range n.store.Info() {
+		if key, ok := strings.CutPrefix(info.Key, n.prefix); ok {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
@@ -0,0 +1,161 @@
+package state
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCounter(t *testing.T) {
+	a, b := New("a"), New("b")
+
+	require.Equal(t, int64(2), a.Namespace("ns").Add("hits", 2, 0))
+	require.Equal(t, int64(5), b.Namespace("ns").Add("hits", 5, 0))
+	require.Equal(t, int64(4), b.Namespace("ns").Add("hits", -1, 0))
+
+	exchange(a, b)
+	require.Equal(t, int64(6), a.Namespace("ns").Counter("hits"))
+	require.Equal(t, int64(6), b.Namespace("ns").Counter("hits"))
+
+	// Merging the same state again doesn't change the counter.
+	exchange(a, b)
+	require.Equal(t, int64(6), a.Namespace("ns").Counter("hits"))
+
+	// Namespaces are isolated.
+	require.Equal(t, int64(0), a.Namespace("other").Counter("hits"))
+}
+
+func TestRegister(t *testing.T) {
+	a, b := New("a"), New("b")
+	clock := time.Unix(1000, 0)
+	a.now = func() time.Time { return clock }
+	b.now = func() time.Time { return clock }
+
+	a.Namespace("ns").Set("leader", "a", 0)
+	clock = clock.Add(time.Second)
+	b.Namespace("ns").Set("leader", "b", 0)
+
+	exchange(a, b)
+	for _, s := range []*Store{a, b} {
+		v, ok := s.Namespace("ns").Get("leader")
+		require.True(t, ok)
+		require.Equal(t, "b", v)
+	}
+
+	// Concurrent writes are ordered by node name.
+	a.Namespace("ns").Set("owner", "a", 0)
+	b.Namespace("ns").Set("owner", "b", 0)
+	exchange(a, b)
+	v, _ := a.Namespace("ns").Get("owner")
+	require.Equal(t, "b", v)
+
+	// Deletions are replicated.
+	clock = clock.Add(time.Second)
+	a.Namespace("ns").Delete("leader")
+	exchange(a, b)
+	_, ok := b.Namespace("ns").Get("leader")
+	require.False(t, ok)
+	require.Equal(t, []string{"owner"}, b.Namespace("ns").Keys())
+}
+
+func TestMerge_Commutative(t *testing.T) {
+	newStore := func(node string) *Store {
+		s := New(node)
+		s.Namespace("ns").Add("hits", 3, 0)
+		s.Namespace("ns").Set("value", node, 0)
+		return s
+	}
+	a, b, c := newStore("a"), newStore("b"), newStore("c")
+
+	ab, ba := New("x"), New("y")
+	ab.Merge(a.Snapshot())
+	ab.Merge(b.Snapshot())
+	ab.Merge(c.Snapshot())
+	ba.Merge(c.Snapshot())
+	ba.Merge(b.Snapshot())
+	ba.Merge(a.Snapshot())
+
+	require.Equal(t, ab.Snapshot(), ba.Snapshot())
+	require.Equal(t, int64(9), ab.Namespace("ns").Counter("hits"))
+}
+
+func TestExpiry(t *testing.T) {
+	s := New("a")
+	clock := time.Unix(1000, 0)
+	s.now = func() time.Time { return clock }
+
+	ns := s.Namespace("ns")
+	ns.Add("hits", 1, time.Minute)
+	ns.Set("value", "v", time.Minute)
+
+	// Adding to the counter extends its lifetime.
+	clock = clock.Add(30 * time.Second)
+	ns.Add("hits", 1, time.Minute)
+
+	clock = clock.Add(45 * time.Second)
+	require.Equal(t, int64(2), ns.Counter("hits"))
+	_, ok := ns.Get("value")
+	require.False(t, ok)
+
+	s.Expire()
+	require.Len(t, s.Snapshot(), 1)
+
+	// Expired entries received from other nodes are ignored.
+	other := New("b")
+	other.now = func() time.Time { return clock.Add(time.Hour) }
+	other.Merge(s.Snapshot())
+	require.Empty(t, other.Snapshot())
+
+	// Expired counters start over.
+	clock = clock.Add(time.Minute)
+	require.Equal(t, int64(1), ns.Add("hits", 1, time.Minute))
+}
+
+func TestExpiry_ResetCounter(t *testing.T) {
+	a, b := New("a"), New("b")
+	clockA, clockB := time.Unix(1000, 0), time.Unix(1000, 0)
+	a.now = func() time.Time { return clockA }
+	b.now = func() time.Time { return clockB }
+
+	a.Namespace("ns").Add("hits", 5, time.Minute)
+	b.Namespace("ns").Add("hits", 2, time.Minute)
+	exchange(a, b)
+	require.Equal(t, int64(7), b.Namespace("ns").Counter("hits"))
+
+	// The clock of b lags behind, so b still holds the counter when it expires
+	// and starts over on a.
+	stale := b.Snapshot()
+	clockA = clockA.Add(2 * time.Minute)
+	clockB = clockB.Add(30 * time.Second)
+	a.Expire()
+	require.Equal(t, int64(1), a.Namespace("ns").Add("hits", 1, time.Minute))
+
+	// Merging the copy from before the reset doesn't bring the old value back.
+	a.Merge(stale)
+	require.Equal(t, int64(1), a.Namespace("ns").Counter("hits"))
+
+	// The reset replaces the copy of b.
+	exchange(a, b)
+	require.Equal(t, int64(1), a.Namespace("ns").Counter("hits"))
+	require.Equal(t, int64(1), b.Namespace("ns").Counter("hits"))
+}
+
+func TestInfo(t *testing.T) {
+	a, b := New("a"), New("b")
+	a.Namespace("ns").Add("hits", 1, 0)
+	b.Namespace("ns").Add("hits", 1, 0)
+	b.Namespace("ns").Set("value", "v", 0)
+	exchange(a, b)
+
+	require.Equal(t, []EntryInfo{
+		{Key: "ns/hits", Kind: KindCounter, Value: "2", UpdatedBy: []string{"a", "b"}},
+		{Key: "ns/value", Kind: KindRegister, Value: "v", UpdatedBy: []string{"b"}},
+	}, a.Info())
+}
+
+// exchange merges the state of a and b like two nodes gossiping.
+func exchange(a, b *Store) {
+	b.Merge(a.Snapshot())
+	a.Merge(b.Snapshot())
+}
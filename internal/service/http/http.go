@@ -7,11 +7,13 @@ import (
 	"crypto/tls"
 	"fmt"
 	"io"
+	"maps"
 	"net"
 	"net/http"
 	_ "net/http/pprof" // Register pprof handlers
 	"os"
 	"path"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -102,6 +104,11 @@ type Service struct {
 
 	memLis *memconn.Listener
 
+	// host is set once the Service is running and is used to look up
+	// components on behalf of [Data.ComponentProvider].
+	hostMut sync.RWMutex
+	host    service.Host
+
 	componentHttpPathPrefix          string
 	componentHttpPathPrefixRemotecfg string
 }
@@ -168,6 +175,10 @@ func (s *Service) Run(ctx context.Context, host service.Host) error {
 	var wg sync.WaitGroup
 	defer wg.Wait()
 
+	s.hostMut.Lock()
+	s.host = host
+	s.hostMut.Unlock()
+
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 	defer func() {
@@ -412,6 +423,16 @@ func (s *Service) getServiceRoutes(host service.Host) []serviceRoute {
 			Base:    base,
 			Handler: handler,
 		})
+
+		if rh, ok := sh.(RoutesHandler); ok {
+			extra := rh.Routes(host)
+			for _, route := range slices.Sorted(maps.Keys(extra)) {
+				routes = append(routes, serviceRoute{
+					Base:    route,
+					Handler: extra[route],
+				})
+			}
+		}
 	}
 
 	sort.Sort(routes)
@@ -516,6 +537,8 @@ func (s *Service) Data() any {
 		MemoryListenAddr: s.opts.MemoryListenAddr,
 		BaseHTTPPath:     s.componentHttpPathPrefix,
 
+		ComponentProvider: &componentProvider{s: s},
+
 		DialFunc: func(ctx context.Context, network, address string) (net.Conn, error) {
 			switch address {
 			case s.opts.MemoryListenAddr:
@@ -539,6 +562,10 @@ type Data struct {
 	// BaseHTTPPath is the base path where component HTTP routes are exposed.
 	BaseHTTPPath string
 
+	// ComponentProvider exposes the components whose HTTP routes are served by
+	// the HTTP service. Lookups fail until the HTTP service is running.
+	ComponentProvider component.Provider
+
 	// DialFunc is a function which establishes in-memory network connection when
 	// address is MemoryListenAddr. If address is not MemoryListenAddr, DialFunc
 	// establishes an outbound network connection.
@@ -582,6 +609,17 @@ type ServiceHandler interface {
 	ServiceHandler(host service.Host) (base string, handler http.Handler)
 }
 
+// RoutesHandler is a ServiceHandler which also exposes HTTP handlers outside
+// of its base route.
+type RoutesHandler interface {
+	ServiceHandler
+
+	// Routes returns additional HTTP handlers to register for the provided
+	// service, keyed by their base route. They're prioritized along with the
+	// base routes of every service.
+	Routes(host service.Host) map[string]http.Handler
+}
+
 // lazyListener is a [net.Listener] which lazily initializes the underlying
 // listener.
 type lazyListener struct {
@@ -700,6 +738,47 @@ func printFileRedacted(f *ast.File) ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
+// componentProvider implements [component.Provider] on top of the host the
+// Service runs with. Components of the remotecfg module are looked up through
+// the remotecfg host, like component HTTP routes.
+type componentProvider struct {
+	s *Service
+}
+
+var _ component.Provider = (*componentProvider)(nil)
+
+func (p *componentProvider) getHost(moduleID string) (service.Host, error) {
+	p.s.hostMut.RLock()
+	host := p.s.host
+	p.s.hostMut.RUnlock()
+
+	if host == nil {
+		return nil, fmt.Errorf("http service isn't running")
+	}
+	if moduleID == remotecfg.ServiceName || strings.HasPrefix(moduleID, remotecfg.ServiceName+"/") {
+		return remotecfg.GetHost(host)
+	}
+	return host, nil
+}
+
+// GetComponent implements [component.Provider].
+func (p *componentProvider) GetComponent(id component.ID, opts component.InfoOptions) (*component.Info, error) {
+	host, err := p.getHost(id.ModuleID)
+	if err != nil {
+		return nil, err
+	}
+	return host.GetComponent(id, opts)
+}
+
+// ListComponents implements [component.Provider].
+func (p *componentProvider) ListComponents(moduleID string, opts component.InfoOptions) ([]*component.Info, error) {
+	host, err := p.getHost(moduleID)
+	if err != nil {
+		return nil, err
+	}
+	return host.ListComponents(moduleID, opts)
+}
+
 func remoteCfgHostProvider(host service.Host) func() (service.Host, error) {
 	return func() (service.Host, error) {
 		return remotecfg.GetHost(host)
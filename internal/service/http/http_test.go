@@ -330,6 +330,23 @@ func TestUnhealthy(t *testing.T) {
 	})
 }
 
+func TestServiceRoutes(t *testing.T) {
+	env, err := newTestEnvironment(t)
+	require.NoError(t, err)
+
+	host := fakeHost{consumers: []service.Consumer{{
+		Type:  service.ConsumerTypeService,
+		ID:    "routes",
+		Value: fakeRoutes{},
+	}}}
+
+	var bases []string
+	for _, route := range env.svc.getServiceRoutes(host) {
+		bases = append(bases, route.Base)
+	}
+	require.Equal(t, []string{"/api/v1/routes/extra/", "/api/v1/routes/"}, bases)
+}
+
 type testEnvironment struct {
 	svc        *Service
 	addr       string
@@ -379,6 +396,7 @@ func (env *testEnvironment) ListenAddr() string { return env.addr }
 
 type fakeHost struct {
 	components []*component.Info
+	consumers  []service.Consumer
 }
 
 var _ service.Host = (fakeHost{})
@@ -397,7 +415,7 @@ func (f fakeHost) ListComponents(moduleID string, opts component.InfoOptions) ([
 	return nil, fmt.Errorf("no such module %q", moduleID)
 }
 
-func (fakeHost) GetServiceConsumers(serviceName string) []service.Consumer { return nil }
+func (f fakeHost) GetServiceConsumers(serviceName string) []service.Consumer { return f.consumers }
 
 func (fakeHost) NewController(id string) service.Controller { return nil }
 
@@ -421,3 +439,23 @@ func (f fakeRemotecfg) Definition() service.Definition {
 func (f fakeRemotecfg) Run(ctx context.Context, host service.Host) error { return nil }
 func (f fakeRemotecfg) Update(newConfig any) error                       { return nil }
 func (f fakeRemotecfg) Data() any                                        { return remotecfg.Data{} }
+
+// fakeRoutes is a service which exposes a handler outside of its base route.
+type fakeRoutes struct{}
+
+var _ RoutesHandler = fakeRoutes{}
+
+func (fakeRoutes) Definition() service.Definition {
+	return service.Definition{Name: "routes", DependsOn: []string{ServiceName}}
+}
+func (fakeRoutes) Run(ctx context.Context, host service.Host) error { return nil }
+func (fakeRoutes) Update(newConfig any) error                       { return nil }
+func (fakeRoutes) Data() any                                        { return nil }
+
+func (fakeRoutes) ServiceHandler(host service.Host) (string, http.Handler) {
+	return "/api/v1/routes/", http.NotFoundHandler()
+}
+
+func (fakeRoutes) Routes(host service.Host) map[string]http.Handler {
+	return map[string]http.Handler{"/api/v1/routes/extra/": http.NotFoundHandler()}
+}
@@ -9,6 +9,10 @@ const (
 	OtelMetric       DataType = "otel_metric"
 	OtelLog          DataType = "otel_log"
 	OtelTrace        DataType = "otel_trace"
+	PyroscopeProfile DataType = "pyroscope_profile"
+	// SendOutcome is published by components which send data out of Alloy
+	// to describe the result of a send. It doesn't flow to other components.
+	SendOutcome DataType = "send_outcome"
 )
 
 type DataOption func(Data) Data
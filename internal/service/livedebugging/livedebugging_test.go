@@ -1,6 +1,7 @@
 package livedebugging
 
 import (
+	"errors"
 	"testing"
 	"time"
 
@@ -120,6 +121,22 @@ func TestDeleteCallback(t *testing.T) {
 	require.Empty(t, livedebugging.callbacks[componentID])
 }
 
+func TestOutcomeData(t *testing.T) {
+	data := NewOutcomeData("fake.liveDebugging", 5, Outcome{
+		Result:     OutcomeDropped,
+		Endpoint:   "localhost:3100",
+		StatusCode: 429,
+		Retries:    2,
+		Err:        errors.New("too many requests"),
+	})
+	require.Equal(t, SendOutcome, data.Type)
+	require.Equal(t, uint64(5), data.Count)
+	require.Equal(t, `outcome=dropped endpoint="localhost:3100" status=429 retries=2 error="too many requests"`, data.DataFunc())
+
+	data = NewOutcomeData("fake.liveDebugging", 1, Outcome{Result: OutcomeSent})
+	require.Equal(t, "outcome=sent retries=0", data.DataFunc())
+}
+
 func createServiceHost(liveDebugging *liveDebugging) service.Host {
 	host := &testlivedebugging.FakeServiceHost{
 		ComponentsInfo: map[component.ID]testlivedebugging.FakeInfo{
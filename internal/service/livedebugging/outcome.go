@@ -0,0 +1,61 @@
+package livedebugging
+
+import (
+	"fmt"
+	"strings"
+)
+
+// OutcomeResult is the result of sending data out of Alloy.
+type OutcomeResult string
+
+const (
+	// OutcomeSent means that the data was delivered to the endpoint.
+	OutcomeSent OutcomeResult = "sent"
+	// OutcomeDropped means that the data couldn't be delivered and was
+	// discarded, possibly after retrying.
+	OutcomeDropped OutcomeResult = "dropped"
+	// OutcomeAccepted means that the data was handed over to a client which
+	// delivers it asynchronously, for example through a sending queue.
+	OutcomeAccepted OutcomeResult = "accepted"
+	// OutcomeRejected means that the data was refused before being sent, for
+	// example because a sending queue was full.
+	OutcomeRejected OutcomeResult = "rejected"
+)
+
+// Outcome describes the result of sending a batch of data to an endpoint
+// outside of Alloy.
+type Outcome struct {
+	Result OutcomeResult
+	// Endpoint the data was sent to. Optional.
+	Endpoint string
+	// StatusCode is the HTTP status code of the last attempt. It is 0 if no
+	// response was received.
+	StatusCode int
+	// Retries is the number of attempts made after the first one.
+	Retries int
+	// Err is the error of the last attempt, if any.
+	Err error
+}
+
+// String returns the outcome in logfmt.
+func (o Outcome) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "outcome=%s", o.Result)
+	if o.Endpoint != "" {
+		fmt.Fprintf(&sb, " endpoint=%q", o.Endpoint)
+	}
+	if o.StatusCode != 0 {
+		fmt.Fprintf(&sb, " status=%d", o.StatusCode)
+	}
+	fmt.Fprintf(&sb, " retries=%d", o.Retries)
+	if o.Err != nil {
+		fmt.Fprintf(&sb, " error=%q", o.Err.Error())
+	}
+	return sb.String()
+}
+
+// NewOutcomeData creates the debugging data describing the outcome of
+// sending count spans, metrics, logs, or profiles.
+func NewOutcomeData(componentID ComponentID, count uint64, outcome Outcome) Data {
+	return NewData(componentID, SendOutcome, count, outcome.String)
+}
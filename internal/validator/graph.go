@@ -8,6 +8,7 @@ import (
 
 	"github.com/grafana/alloy/internal/dag"
 	"github.com/grafana/alloy/internal/featuregate"
+	"github.com/grafana/alloy/internal/nodeconf/clustering"
 	"github.com/grafana/alloy/syntax/ast"
 	"github.com/grafana/alloy/syntax/diag"
 	"github.com/grafana/alloy/syntax/typecheck"
@@ -93,7 +94,25 @@ func validateGraph(s *state, minStability featuregate.Stability) diag.Diagnostic
 			if reg.Args == nil {
 				continue
 			}
-			diags.Merge(typecheck.Block(node.block, reg.CloneArguments()))
+
+			// The clustering block is handled by the controller unless the
+			// component declares its own.
+			block := node.block
+			if body, clusteringBlock := clustering.Split(reg.Args, block.Body); clusteringBlock != nil {
+				if err := featuregate.CheckAllowed(clustering.Stability, minStability, fmt.Sprintf("%s block", clustering.BlockName)); err != nil {
+					diags.Add(diag.Diagnostic{
+						Severity: diag.SeverityLevelError,
+						StartPos: clusteringBlock.NamePos.Position(),
+						EndPos:   clusteringBlock.NamePos.Add(len(clustering.BlockName) - 1).Position(),
+						Message:  err.Error(),
+					})
+				}
+				diags.Merge(typecheck.Block(clusteringBlock, &clustering.Arguments{}))
+				withoutClustering := *block
+				withoutClustering.Body = body
+				block = &withoutClustering
+			}
+			diags.Merge(typecheck.Block(block, reg.CloneArguments()))
 		case *moduleNode:
 			diags.Merge(node.n.diags)
 			if node.n.args != nil {
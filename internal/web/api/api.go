@@ -53,6 +53,7 @@ func (a *AlloyAPI) RegisterRoutes(urlPrefix string, r *mux.Router) {
 	r.Handle(path.Join(urlPrefix, "/remotecfg/components/{id:.+}"), httputil.CompressionHandler{Handler: getComponentHandlerRemoteCfg(a.alloy)})
 
 	r.Handle(path.Join(urlPrefix, "/peers"), httputil.CompressionHandler{Handler: getClusteringPeersHandler(a.alloy)})
+	r.Handle(path.Join(urlPrefix, "/cluster/state"), httputil.CompressionHandler{Handler: getClusteringStateHandler(a.alloy)})
 	r.Handle(path.Join(urlPrefix, "/debug/{id:.+}"), liveDebugging(a.alloy, a.CallbackManager, a.logger))
 
 	r.Handle(path.Join(urlPrefix, "/graph"), graph(a.alloy, a.CallbackManager, a.logger))
@@ -149,8 +150,59 @@ func getClusteringPeersHandler(host service.Host) http.HandlerFunc {
 			http.Error(w, "cluster service not running", http.StatusInternalServerError)
 			return
 		}
-		peers := svc.Data().(cluster.Cluster).Peers()
-		bb, err := json.Marshal(peers)
+		c := svc.Data().(cluster.Cluster)
+		balancer, _ := c.(cluster.Balancer)
+
+		var loads map[string]cluster.Load
+		if balancer != nil {
+			loads = balancer.Loads()
+		}
+
+		peers := c.Peers()
+		infos := make([]peerInfo, 0, len(peers))
+		for _, p := range peers {
+			infos = append(infos, peerInfo{
+				Name:    p.Name,
+				Addr:    p.Addr,
+				Self:    p.Self,
+				State:   p.State.String(),
+				Weight:  loads[p.Name].Weight,
+				Targets: loads[p.Name].Targets,
+			})
+		}
+		bb, err := json.Marshal(infos)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		_, _ = w.Write(bb)
+	}
+}
+
+// peerInfo describes a peer of the cluster, along with the weight and the
+// number of targets it advertised.
+type peerInfo struct {
+	Name    string `json:"name"`
+	Addr    string `json:"addr"`
+	Self    bool   `json:"isSelf"`
+	State   string `json:"state"`
+	Weight  int    `json:"weight"`
+	Targets int    `json:"targets"`
+}
+
+func getClusteringStateHandler(host service.Host) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		svc, found := host.GetService(cluster.ServiceName)
+		if !found {
+			http.Error(w, "cluster service not running", http.StatusInternalServerError)
+			return
+		}
+		provider, ok := svc.Data().(cluster.StateProvider)
+		if !ok {
+			http.Error(w, "cluster service does not replicate state", http.StatusInternalServerError)
+			return
+		}
+		bb, err := json.Marshal(provider.StateInfo())
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
@@ -187,6 +239,10 @@ func graph(h service.Host, callbackManager livedebugging.CallbackManager, logger
 
 		droppedData := false
 		err = callbackManager.AddCallbackMulti(host, id, moduleID, func(data livedebugging.Data) {
+			// Send outcomes describe data leaving Alloy, not data flowing between components.
+			if data.Type == livedebugging.SendOutcome {
+				return
+			}
 			select {
 			case <-ctx.Done():
 				return
@@ -6,7 +6,7 @@ interface PeerListProps {
   peers: PeerInfo[];
 }
 
-const TABLEHEADERS = ['Node Name', 'Advertised Address', 'Current State', 'Local Node'];
+const TABLEHEADERS = ['Node Name', 'Advertised Address', 'Current State', 'Weight', 'Targets', 'Local Node'];
 
 const PeerList = ({ peers }: PeerListProps) => {
   const tableStyles = { width: '130px' };
@@ -15,7 +15,7 @@ const PeerList = ({ peers }: PeerListProps) => {
    * Custom renderer for table data
    */
   const renderTableData = () => {
-    return peers.map(({ name, addr, state, isSelf }) => (
+    return peers.map(({ name, addr, state, weight, targets, isSelf }) => (
       <tr key={name} style={{ lineHeight: '2.5' }}>
         <td>
           <span className={styles.idName}>{name}</span>
@@ -26,6 +26,12 @@ const PeerList = ({ peers }: PeerListProps) => {
         <td>
           <span className={styles.idName}>{state}</span>
         </td>
+        <td>
+          <span className={styles.idName}>{weight > 0 ? weight : '-'}</span>
+        </td>
+        <td>
+          <span className={styles.idName}>{weight > 0 ? targets : '-'}</span>
+        </td>
         <td>
           <span> {isSelf ? '✅' : ' '}</span>
         </td>
@@ -0,0 +1,46 @@
+import { type StateEntry } from '../clustering/types';
+import styles from './PeerList.module.css';
+import Table from './Table';
+
+interface StateListProps {
+  entries: StateEntry[];
+}
+
+const TABLEHEADERS = ['Key', 'Kind', 'Value', 'Updated By', 'Expires At'];
+
+const StateList = ({ entries }: StateListProps) => {
+  const tableStyles = { width: '130px' };
+
+  /**
+   * Custom renderer for table data
+   */
+  const renderTableData = () => {
+    return entries.map(({ key, kind, value, updatedBy, expiresAt }) => (
+      <tr key={key} style={{ lineHeight: '2.5' }}>
+        <td>
+          <span className={styles.idName}>{key}</span>
+        </td>
+        <td>
+          <span className={styles.idName}>{kind}</span>
+        </td>
+        <td>
+          <span className={styles.idName}>{value}</span>
+        </td>
+        <td>
+          <span className={styles.idName}>{updatedBy.join(', ')}</span>
+        </td>
+        <td>
+          <span className={styles.idName}>{expiresAt ? new Date(expiresAt).toLocaleString() : 'never'}</span>
+        </td>
+      </tr>
+    ));
+  };
+
+  return (
+    <div className={styles.list}>
+      <Table tableHeaders={TABLEHEADERS} renderTableData={renderTableData} style={tableStyles} />
+    </div>
+  );
+};
+
+export default StateList;
@@ -6,4 +6,20 @@ export interface PeerInfo {
   state: string;
 
   isSelf: boolean;
+
+  weight: number;
+
+  targets: number;
+}
+
+export interface StateEntry {
+  key: string;
+
+  kind: 'counter' | 'register';
+
+  value: string;
+
+  updatedBy: string[];
+
+  expiresAt?: string;
 }
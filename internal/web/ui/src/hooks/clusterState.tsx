@@ -0,0 +1,28 @@
+import { useEffect, useState } from 'react';
+
+import { type StateEntry } from '../features/clustering/types';
+
+/**
+ * useClusterState retrieves the entries of the replicated cluster state from
+ * the API.
+ */
+export const useClusterState = (): StateEntry[] => {
+  const [entries, setEntries] = useState<StateEntry[]>([]);
+
+  useEffect(function () {
+    const worker = async () => {
+      const statePath = './api/v0/web/cluster/state';
+
+      // Request is relative to the <base> tag inside of <head>.
+      const resp = await fetch(statePath, {
+        cache: 'no-cache',
+        credentials: 'same-origin',
+      });
+      setEntries(await resp.json());
+    };
+
+    worker().catch(console.error);
+  }, []);
+
+  return entries;
+};
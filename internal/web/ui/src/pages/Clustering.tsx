@@ -1,15 +1,20 @@
 import { faNetworkWired } from '@fortawesome/free-solid-svg-icons';
 
 import PeerList from '../features/clustering/PeerList';
+import StateList from '../features/clustering/StateList';
 import Page from '../features/layout/Page';
+import { useClusterState } from '../hooks/clusterState';
 import { usePeerInfo } from '../hooks/peerInfo';
 
 function PageClusteringPeers() {
   const peers = usePeerInfo();
+  const entries = useClusterState();
 
   return (
     <Page name="Clustering" desc="List of clustering peers" icon={faNetworkWired}>
       <PeerList peers={peers} />
+      <h2>Replicated state</h2>
+      <StateList entries={entries} />
     </Page>
   );
 }